@@ -0,0 +1,109 @@
+package acceptance
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"os/exec"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("assign-stemcell command", func() {
+	var (
+		server      *httptest.Server
+		assignBody  []byte
+		assignCalls int
+	)
+
+	BeforeEach(func() {
+		assignCalls = 0
+
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch req.URL.Path {
+			case "/uaa/oauth/token":
+				w.Write([]byte(`{
+				"access_token": "some-opsman-token",
+				"token_type": "bearer",
+				"expires_in": 3600
+			}`))
+			case "/api/v0/stemcell_assignments":
+				if req.Method == "PATCH" {
+					var err error
+					assignCalls++
+					assignBody, err = ioutil.ReadAll(req.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					w.Write([]byte(`{}`))
+					return
+				}
+
+				w.Write([]byte(`{
+					"products": [{
+						"guid": "cf-guid",
+						"identifier": "cf",
+						"staged_stemcell_version": "3541.10",
+						"required_stemcell_version": "3541",
+						"required_stemcell_os": "ubuntu-trusty",
+						"available_stemcell_versions": ["3541.10", "3541.12"]
+					}]
+				}`))
+			default:
+				out, err := httputil.DumpRequest(req, true)
+				Expect(err).NotTo(HaveOccurred())
+				Fail(fmt.Sprintf("unexpected request: %s", out))
+			}
+		}))
+	})
+
+	It("assigns the latest stemcell to the product", func() {
+		command := exec.Command(pathToMain,
+			"--target", server.URL,
+			"--username", "some-username",
+			"--password", "some-password",
+			"--skip-ssl-validation",
+			"assign-stemcell",
+			"--product-name", "cf",
+			"--stemcell", "latest",
+		)
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(0))
+		Expect(session.Out).To(gbytes.Say("assigning stemcell 3541.12 to cf"))
+		Expect(session.Out).To(gbytes.Say("finished assigning stemcell"))
+
+		Expect(assignCalls).To(Equal(1))
+		Expect(assignBody).To(MatchJSON(`{
+			"products": [{"guid": "cf-guid", "staged_stemcell_version": "3541.12"}]
+		}`))
+	})
+
+	It("refuses a stemcell version that is not available", func() {
+		command := exec.Command(pathToMain,
+			"--target", server.URL,
+			"--username", "some-username",
+			"--password", "some-password",
+			"--skip-ssl-validation",
+			"assign-stemcell",
+			"--product-name", "cf",
+			"--stemcell", "3541.1",
+		)
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(1))
+		Expect(session.Err).To(gbytes.Say("stemcell version 3541.1 is not available for cf; available versions: 3541.10, 3541.12"))
+		Expect(assignCalls).To(Equal(0))
+	})
+})
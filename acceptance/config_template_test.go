@@ -0,0 +1,89 @@
+package acceptance
+
+import (
+	"archive/zip"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+
+	"github.com/onsi/gomega/gexec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("config-template command", func() {
+	var productFile *os.File
+
+	BeforeEach(func() {
+		var err error
+		productFile, err = ioutil.TempFile("", "some-product.pivotal")
+		Expect(err).NotTo(HaveOccurred())
+
+		zipper := zip.NewWriter(productFile)
+
+		productWriter, err := zipper.Create("./metadata/some-product.yml")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = io.WriteString(productWriter, `
+---
+product_version: 1.8.14
+name: some-product
+property_blueprints:
+- name: some-property
+  type: string
+  configurable: true
+- name: some-port
+  type: port
+  configurable: true
+  default: 8080
+job_types:
+- name: some-job
+  instance_definition:
+    name: instances
+    type: integer
+    configurable: true
+    default: 2`)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = zipper.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.Remove(productFile.Name())
+	})
+
+	It("prints a config template for the product", func() {
+		command := exec.Command(pathToMain,
+			"config-template",
+			"--product", productFile.Name(),
+		)
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(0))
+
+		Expect(string(session.Out.Contents())).To(Equal(`product-name: some-product
+product-properties:
+  .properties.some-property:
+    value: ((properties_some-property))
+  .properties.some-port:
+    value: 8080
+network-properties:
+  network:
+    name: ((network_name))
+  other_availability_zones:
+  - name: ((availability_zone_name))
+  singleton_availability_zone:
+    name: ((singleton_availability_zone_name))
+resource-config:
+  some-job:
+    instances: 2
+    instance_type:
+      id: automatic
+`))
+	})
+})
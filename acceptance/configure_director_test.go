@@ -27,6 +27,10 @@ var _ = Describe("configure-director command", func() {
 		networksConfigurationBody      []byte
 		networksConfigurationCallCount int
 		networksConfigurationMethod    string
+		resourceConfigBody             []byte
+		resourceConfigMethod           string
+		vmExtensionBody                []byte
+		vmExtensionMethod              string
 
 		server *httptest.Server
 	)
@@ -117,6 +121,39 @@ var _ = Describe("configure-director command", func() {
 
 				directorPropertiesCallCount++
 
+				w.Write([]byte(`{}`))
+			case "/api/v0/staged/products":
+				w.Write([]byte(`[
+					{"installation_name": "p-bosh-installation", "guid": "p-bosh-guid", "type": "p-bosh"},
+					{"installation_name": "cf-installation", "guid": "cf-guid", "type": "cf"}
+				]`))
+			case "/api/v0/staged/products/p-bosh-guid/jobs":
+				w.Write([]byte(`{"jobs": [
+					{"name": "director", "guid": "director-guid"},
+					{"name": "compilation", "guid": "compilation-guid"}
+				]}`))
+			case "/api/v0/staged/products/p-bosh-guid/jobs/director-guid/resource_config":
+				if req.Method == "GET" {
+					w.Write([]byte(`{
+						"instances": 1,
+						"instance_type": {"id": "automatic"},
+						"persistent_disk": {"size_mb": "automatic"}
+					}`))
+					return
+				}
+
+				var err error
+				resourceConfigBody, err = ioutil.ReadAll(req.Body)
+				Expect(err).NotTo(HaveOccurred())
+				resourceConfigMethod = req.Method
+
+				w.Write([]byte(`{}`))
+			case "/api/v0/staged/vm_extensions/some-extension":
+				var err error
+				vmExtensionBody, err = ioutil.ReadAll(req.Body)
+				Expect(err).NotTo(HaveOccurred())
+				vmExtensionMethod = req.Method
+
 				w.Write([]byte(`{}`))
 			default:
 				out, err := httputil.DumpRequest(req, true)
@@ -222,4 +259,36 @@ var _ = Describe("configure-director command", func() {
 			}
 	  }`))
 	})
+
+	It("configures the vm extensions and the resources of the director jobs", func() {
+		command := exec.Command(pathToMain,
+			"--target", server.URL,
+			"--username", "some-username",
+			"--password", "some-password",
+			"--skip-ssl-validation",
+			"configure-director",
+			"--vmextensions-configuration", `[{"name": "some-extension", "cloud_properties": {"some": "property"}}]`,
+			"--resource-configuration", `{"director": {"instance_type": {"id": "m1.large"}, "additional_vm_extensions": ["some-extension"]}}`,
+		)
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session, "40s").Should(gexec.Exit(0))
+
+		Expect(vmExtensionMethod).To(Equal("PUT"))
+		Expect(vmExtensionBody).To(MatchJSON(`{
+			"name": "some-extension",
+			"cloud_properties": {"some": "property"}
+		}`))
+
+		Expect(resourceConfigMethod).To(Equal("PUT"))
+		Expect(resourceConfigBody).To(MatchJSON(`{
+			"instances": 1,
+			"instance_type": {"id": "m1.large"},
+			"persistent_disk": {"size_mb": "automatic"},
+			"elb_names": null,
+			"additional_vm_extensions": ["some-extension"]
+		}`))
+	})
 })
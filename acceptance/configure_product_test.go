@@ -72,9 +72,15 @@ var _ = Describe("configure-product command", func() {
 		productNetworkBody      []byte
 		resourceConfigMethod    []string
 		resourceConfigBody      [][]byte
+		errandsMethod           []string
+		errandsBody             []byte
 	)
 
 	BeforeEach(func() {
+		productPropertiesMethod = ""
+		errandsMethod = []string{}
+		errandsBody = nil
+
 		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 			w.Header().Set("Content-Type", "application/json")
 
@@ -116,6 +122,27 @@ var _ = Describe("configure-product command", func() {
 					]
 				}`))
 			case "/api/v0/staged/products/some-product-guid/properties":
+				if req.Method == "GET" {
+					w.Write([]byte(`{
+						"properties": {
+							".properties.something": {"type": "string", "configurable": true, "credential": false, "value": null, "optional": false},
+							".a-job.job-property": {"type": "simple_credentials", "configurable": true, "credential": true, "value": null, "optional": false},
+							".top-level-property": {
+								"type": "collection",
+								"configurable": true,
+								"credential": false,
+								"optional": false,
+								"value": [{
+									"guid": {"type": "uuid", "configurable": false, "credential": false, "value": "some-guid", "optional": false},
+									"name": {"type": "string", "configurable": true, "credential": false, "value": "min", "optional": false},
+									"my-secret": {"type": "secret", "configurable": true, "credential": true, "value": {"secret": "***"}, "optional": false}
+								}]
+							}
+						}
+					}`))
+					return
+				}
+
 				var err error
 				productPropertiesMethod = req.Method
 				productPropertiesBody, err = ioutil.ReadAll(req.Body)
@@ -138,6 +165,23 @@ var _ = Describe("configure-product command", func() {
 
 				resourceConfigBody = append(resourceConfigBody, body)
 
+				w.Write([]byte(`{}`))
+			case "/api/v0/staged/products/some-product-guid/errands":
+				errandsMethod = append(errandsMethod, req.Method)
+				if req.Method == "GET" {
+					w.Write([]byte(`{
+						"errands": [
+							{"name": "smoke-tests", "post_deploy": true},
+							{"name": "delete-all-apps", "pre_delete": true}
+						]
+					}`))
+					return
+				}
+
+				var err error
+				errandsBody, err = ioutil.ReadAll(req.Body)
+				Expect(err).NotTo(HaveOccurred())
+
 				w.Write([]byte(`{}`))
 			default:
 				auth := req.Header.Get("Authorization")
@@ -210,6 +254,62 @@ var _ = Describe("configure-product command", func() {
       }`))
 	})
 
+	It("sets the state of every errand in one request", func() {
+		command := exec.Command(pathToMain,
+			"--target", server.URL,
+			"--username", "some-username",
+			"--password", "some-password",
+			"--skip-ssl-validation",
+			"configure-product",
+			"--product-name", "cf",
+			"--errand-config", `{
+				"smoke-tests": {"post-deploy-state": "when-changed"},
+				"delete-all-apps": {"pre-delete-state": "disabled"}
+			}`,
+		)
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(0))
+
+		Expect(session.Out).To(gbytes.Say("setting errand states"))
+		Expect(session.Out).To(gbytes.Say("finished setting errand states"))
+
+		Expect(errandsMethod).To(Equal([]string{"GET", "PUT"}))
+		Expect(errandsBody).To(MatchJSON(`{
+			"errands": [
+				{"name": "delete-all-apps", "pre_delete": false},
+				{"name": "smoke-tests", "post_deploy": "when-changed"}
+			]
+		}`))
+	})
+
+	It("rejects properties that do not match the staged property schema", func() {
+		command := exec.Command(pathToMain,
+			"--target", server.URL,
+			"--username", "some-username",
+			"--password", "some-password",
+			"--skip-ssl-validation",
+			"configure-product",
+			"--product-name", "cf",
+			"--product-properties", `{
+				".properties.something": {"value": 12},
+				".properties.unknown": {"value": "foo"}
+			}`,
+		)
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(1))
+		Expect(string(session.Err.Contents())).To(ContainSubstring(`product configuration is invalid:
+.properties.something: expected a string, got 12
+.properties.unknown: unknown property`))
+
+		Expect(productPropertiesMethod).To(BeEmpty())
+	})
+
 	It("successfully configures a product on nsx", func() {
 		command := exec.Command(pathToMain,
 			"--target", server.URL,
@@ -0,0 +1,104 @@
+package acceptance
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/onsi/gomega/gexec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("converge command", func() {
+	var (
+		server      *httptest.Server
+		manifestDir string
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch req.Method + " " + req.URL.Path {
+			case "POST /uaa/oauth/token":
+				w.Write([]byte(`{
+				"access_token": "some-opsman-token",
+				"token_type": "bearer",
+				"expires_in": 3600
+			}`))
+			case "GET /api/v0/diagnostic_report":
+				w.Write([]byte(`{
+					"stemcells": ["bosh-stemcell-3468-vsphere.tgz"],
+					"added_products": {
+						"deployed": [],
+						"staged": [
+							{"name": "p-bosh", "version": "2.1.0"},
+							{"name": "p-old", "version": "1.0.0"}
+						]
+					}
+				}`))
+			case "GET /api/v0/stemcell_assignments":
+				w.Write([]byte(`{"products": [], "stemcell_library": []}`))
+			case "GET /api/v0/available_products":
+				w.Write([]byte(`[{"name": "cf", "product_version": "2.1.0"}]`))
+			default:
+				out, err := httputil.DumpRequest(req, true)
+				Expect(err).NotTo(HaveOccurred())
+				Fail(fmt.Sprintf("unexpected request: %s", out))
+			}
+		}))
+
+		var err error
+		manifestDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(manifestDir)
+	})
+
+	It("prints the plan for the manifest", func() {
+		manifestPath := filepath.Join(manifestDir, "foundation.yml")
+		err := ioutil.WriteFile(manifestPath, []byte(`---
+products:
+- name: cf
+  version: 2.1.0
+  file: cf-2.1.0.pivotal
+  config: cf.yml
+  stemcell: bosh-stemcell-3468-vsphere.tgz
+  errands:
+    smoke_tests:
+      post-deploy: disabled
+`), 0644)
+		Expect(err).NotTo(HaveOccurred())
+
+		command := exec.Command(pathToMain,
+			"--target", server.URL,
+			"--username", "some-username",
+			"--password", "some-password",
+			"--skip-ssl-validation",
+			"converge",
+			"--manifest", manifestPath,
+			"--dry-run")
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(0))
+		Expect(string(session.Out.Contents())).To(Equal(`converge plan:
+  1. unstage product p-old
+  2. stage product cf 2.1.0
+  3. assign stemcell 3468 to cf
+  4. configure product cf
+  5. apply changes
+`))
+	})
+})
@@ -15,10 +15,13 @@ import (
 
 var _ = Describe("delete-unused-products command", func() {
 	var (
-		server *httptest.Server
+		server         *httptest.Server
+		deleteRequests []string
 	)
 
 	BeforeEach(func() {
+		deleteRequests = []string{}
+
 		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 			var responseString string
 			w.Header().Set("Content-Type", "application/json")
@@ -32,8 +35,23 @@ var _ = Describe("delete-unused-products command", func() {
 			}`
 			case "/api/v0/available_products":
 				if req.Method == "DELETE" {
+					deleteRequests = append(deleteRequests, req.URL.RawQuery)
 					responseString = "{}"
+				} else {
+					responseString = `[
+					{"name": "cf", "product_version": "1.10.0"},
+					{"name": "cf", "product_version": "1.9.0"},
+					{"name": "cf", "product_version": "1.8.0"},
+					{"name": "cf", "product_version": "1.11.0"}
+				]`
+				}
+			case "/api/v0/diagnostic_report":
+				responseString = `{
+				"added_products": {
+					"deployed": [{"name": "cf", "version": "1.11.0"}],
+					"staged": [{"name": "cf", "version": "1.11.0"}]
 				}
+			}`
 			default:
 				out, err := httputil.DumpRequest(req, true)
 				Expect(err).NotTo(HaveOccurred())
@@ -60,4 +78,53 @@ var _ = Describe("delete-unused-products command", func() {
 		Eventually(session.Out, 5).Should(gbytes.Say("trashing unused products"))
 		Eventually(session.Out, 5).Should(gbytes.Say("done"))
 	})
+
+	Context("when keep is provided", func() {
+		It("deletes all but the newest unused versions one by one", func() {
+			command := exec.Command(pathToMain,
+				"--target", server.URL,
+				"--username", "some-username",
+				"--password", "some-password",
+				"--skip-ssl-validation",
+				"delete-unused-products",
+				"--keep", "1",
+			)
+
+			session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(session, 5).Should(gexec.Exit(0))
+			Expect(session.Out).To(gbytes.Say("deleting cf 1.9.0"))
+			Expect(session.Out).To(gbytes.Say("deleting cf 1.8.0"))
+			Expect(session.Out).To(gbytes.Say("done"))
+
+			Expect(deleteRequests).To(Equal([]string{
+				"product_name=cf&version=1.9.0",
+				"product_name=cf&version=1.8.0",
+			}))
+		})
+	})
+
+	Context("when dry-run is provided", func() {
+		It("lists the unused products without deleting them", func() {
+			command := exec.Command(pathToMain,
+				"--target", server.URL,
+				"--username", "some-username",
+				"--password", "some-password",
+				"--skip-ssl-validation",
+				"delete-unused-products",
+				"--dry-run",
+			)
+
+			session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(session, 5).Should(gexec.Exit(0))
+			Expect(session.Out).To(gbytes.Say("would delete cf 1.10.0"))
+			Expect(session.Out).To(gbytes.Say("would delete cf 1.9.0"))
+			Expect(session.Out).To(gbytes.Say("would delete cf 1.8.0"))
+
+			Expect(deleteRequests).To(BeEmpty())
+		})
+	})
 })
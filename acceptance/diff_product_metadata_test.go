@@ -0,0 +1,132 @@
+package acceptance
+
+import (
+	"archive/zip"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+
+	"github.com/onsi/gomega/gexec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("diff-product-metadata command", func() {
+	var (
+		oldProductFile *os.File
+		newProductFile *os.File
+		configFile     *os.File
+	)
+
+	createProduct := func(metadata string) *os.File {
+		productFile, err := ioutil.TempFile("", "some-product.pivotal")
+		Expect(err).NotTo(HaveOccurred())
+
+		zipper := zip.NewWriter(productFile)
+
+		productWriter, err := zipper.Create("./metadata/some-product.yml")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = io.WriteString(productWriter, metadata)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = zipper.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		return productFile
+	}
+
+	BeforeEach(func() {
+		oldProductFile = createProduct(`
+---
+product_version: 1.0.0
+name: some-product
+stemcell_criteria:
+  os: ubuntu-trusty
+  version: "3468"
+property_blueprints:
+- name: some-property
+  type: string
+  configurable: true
+- name: some-old-property
+  type: integer
+  configurable: true
+  default: 1
+job_types:
+- name: some-job
+- name: some-errand
+  errand: true`)
+
+		newProductFile = createProduct(`
+---
+product_version: 1.1.0
+name: some-product
+stemcell_criteria:
+  os: ubuntu-xenial
+  version: "97"
+requires_product_versions:
+- name: cf
+  version: "~> 2.0"
+property_blueprints:
+- name: some-property
+  type: string
+  configurable: true
+- name: some-new-property
+  type: string
+  configurable: true
+job_types:
+- name: some-job`)
+
+		var err error
+		configFile, err = ioutil.TempFile("", "config.yml")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = configFile.WriteString(`---
+product-properties:
+  .properties.some-property:
+    value: some-value
+  .properties.some-old-property:
+    value: 2
+`)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.Remove(oldProductFile.Name())
+		os.Remove(newProductFile.Name())
+		os.Remove(configFile.Name())
+	})
+
+	It("prints the differences between the two products", func() {
+		command := exec.Command(pathToMain,
+			"diff-product-metadata",
+			"--old", oldProductFile.Name(),
+			"--new", newProductFile.Name(),
+			"--config", configFile.Name(),
+		)
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(0))
+
+		Expect(string(session.Out.Contents())).To(Equal(`comparing some-product 1.0.0 with some-product 1.1.0
+properties added:
+  .properties.some-new-property (string, required)
+properties removed:
+  .properties.some-old-property
+errands removed:
+  some-errand
+stemcell criteria changed:
+  os changed from ubuntu-trusty to ubuntu-xenial
+  version changed from 3468 to 97
+dependencies added:
+  cf ~> 2.0
+config file:
+  .properties.some-old-property: no longer exists
+  .properties.some-new-property: is required but not set
+`))
+	})
+})
@@ -27,13 +27,18 @@ Usage: om [options] <command> [<args>]
 Commands:
   activate-certificate-authority  activates a certificate authority on the Ops Manager
   apply-changes                   triggers an install on the Ops Manager targeted
+  assign-stemcell                 assigns a stemcell to a staged product
   available-products              list available products
   certificate-authorities         lists certificates managed by Ops Manager
+  config-template                 generates a config template for a product
   configure-authentication        configures Ops Manager with an internal userstore and admin user account
   configure-bosh                  configures Ops Manager deployed bosh director
   configure-director              configures the director
   configure-product               configures a staged product
+  configure-vm-types              configures custom VM types
+  converge                        converges the Ops Manager to a foundation manifest
   create-certificate-authority    creates a certificate authority on the Ops Manager
+  create-vm-extension             creates or updates a VM extension
   credential-references           list credential references for a deployed product
   credentials                     fetch credentials for a deployed product
   curl                            issues an authenticated API request
@@ -41,25 +46,39 @@ Commands:
   delete-installation             deletes all the products on the Ops Manager targeted
   delete-product                  deletes a product from the Ops Manager
   delete-unused-products          deletes unused products on the Ops Manager targeted
+  delete-vm-extension             deletes a VM extension
   deployed-products               lists deployed products
+  diff-product-metadata           compares the metadata of two product files
   errands                         list errands for a product
   export-installation             exports the installation of the target Ops Manager
   generate-certificate            generates a new certificate signed by Ops Manager's root CA
   generate-certificate-authority  generates a certificate authority on the Opsman
   help                            prints this usage information
+  iaas-configurations             lists director IaaS configurations
   import-installation             imports a given installation to the Ops Manager targeted
   installation-log                output installation logs
   installations                   list recent installation events
+  migrate-bosh-config             translates configure-bosh flags into configure-director configuration
   pending-changes                 lists pending changes
+  products                        lists available, staged and deployed products
   regenerate-certificates         regenerates a certificate authority on the Opsman
+  replicate-product               copies a product file under a new name
   revert-staged-changes           reverts staged changes on the Ops Manager targeted
+  run-verifiers                   runs verifiers
   set-errand-state                sets state for a product's errand
   stage-product                   stages a given product in the Ops Manager targeted
+  staged-config                   writes the staged configuration of a product
   staged-products                 lists staged products
+  stemcell-assignments            lists stemcell assignments for staged products
   unstage-product                 unstages a given product from the Ops Manager targeted
   upload-product                  uploads a given product to the Ops Manager targeted
   upload-stemcell                 uploads a given stemcell to the Ops Manager targeted
+  validate-config                 validates a product config file against a product file
+  validate-product                checks the integrity of a product file
+  verifiers                       lists verifiers
   version                         prints the om release version
+  vm-extensions                   lists VM extensions
+  vm-types                        lists VM types
 `
 
 const CONFIGURE_AUTHENTICATION_USAGE = `ॐ  configure-authentication
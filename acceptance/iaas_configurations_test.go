@@ -0,0 +1,92 @@
+package acceptance
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"os/exec"
+
+	"github.com/onsi/gomega/gexec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("iaas-configurations command", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch req.Method + " " + req.URL.Path {
+			case "POST /uaa/oauth/token":
+				w.Write([]byte(`{
+				"access_token": "some-opsman-token",
+				"token_type": "bearer",
+				"expires_in": 3600
+			}`))
+			case "GET /api/v0/staged/director/iaas_configurations":
+				w.Write([]byte(`{
+					"iaas_configurations": [
+						{"guid": "some-guid", "name": "vcenter-one", "vcenter_host": "vcenter-one.example.com"},
+						{"guid": "other-guid", "name": "vcenter-two", "vcenter_host": "vcenter-two.example.com"}
+					]
+				}`))
+			default:
+				out, err := httputil.DumpRequest(req, true)
+				Expect(err).NotTo(HaveOccurred())
+				Fail(fmt.Sprintf("unexpected request: %s", out))
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("lists the iaas configurations", func() {
+		command := exec.Command(pathToMain,
+			"--target", server.URL,
+			"--username", "some-username",
+			"--password", "some-password",
+			"--skip-ssl-validation",
+			"iaas-configurations")
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(0))
+
+		Expect(string(session.Out.Contents())).To(Equal(`+-------------+------------+
+|    NAME     |    GUID    |
++-------------+------------+
+| vcenter-one | some-guid  |
+| vcenter-two | other-guid |
++-------------+------------+
+`))
+	})
+
+	It("prints the whole configurations as json", func() {
+		command := exec.Command(pathToMain,
+			"--target", server.URL,
+			"--username", "some-username",
+			"--password", "some-password",
+			"--skip-ssl-validation",
+			"--format", "json",
+			"iaas-configurations")
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(0))
+
+		Expect(session.Out.Contents()).To(MatchJSON(`{
+			"iaas_configurations": [
+				{"guid": "some-guid", "name": "vcenter-one", "vcenter_host": "vcenter-one.example.com"},
+				{"guid": "other-guid", "name": "vcenter-two", "vcenter_host": "vcenter-two.example.com"}
+			]
+		}`))
+	})
+})
@@ -0,0 +1,42 @@
+package acceptance
+
+import (
+	"os/exec"
+
+	"github.com/onsi/gomega/gexec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("migrate-bosh-config command", func() {
+	It("prints the configure-director configuration and reports the fields it cannot migrate", func() {
+		command := exec.Command(pathToMain,
+			"migrate-bosh-config",
+			"--director-configuration", `{"ntp_servers_string": "ntp.example.com"}`,
+			"--network-assignment", `{"network": "some-network", "singleton_availability_zone": "az-1"}`,
+			"--resource-configuration", `{"director": {"instance_type": {"id": "m4.large"}}, "router": {"instances": 2}}`,
+		)
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(0))
+
+		Expect(string(session.Out.Contents())).To(Equal(`director-configuration:
+  ntp_servers_string: ntp.example.com
+network-assignment:
+  network:
+    name: some-network
+  singleton_availability_zone:
+    name: az-1
+resource-configuration:
+  director:
+    instance_type:
+      id: m4.large
+`))
+		Expect(string(session.Err.Contents())).To(Equal(`the following fields have no configure-director equivalent and were not migrated:
+  resource-configuration.router
+`))
+	})
+})
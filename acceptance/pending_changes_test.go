@@ -18,14 +18,14 @@ var _ = Describe("pending_changes command", func() {
 		server *httptest.Server
 	)
 
-	const tableOutput = `+----------------+---------+--------------------+
-|    PRODUCT     | ACTION  |      ERRANDS       |
-+----------------+---------+--------------------+
-| some-product-1 | update  | smoke-tests        |
-|                |         | deploy-autoscaling |
-| some-product-2 | install | deploy-broker      |
-| some-product-3 | delete  | delete-broker      |
-+----------------+---------+--------------------+
+	const tableOutput = `+----------------+---------+--------------------+--------------+---------+---------------------+
+|    PRODUCT     | ACTION  |      ERRANDS       |     NAME     | VERSION |    COMPLETENESS     |
++----------------+---------+--------------------+--------------+---------+---------------------+
+| some-product-1 | update  | smoke-tests        | product-one  | 1.1.0   | complete            |
+|                |         | deploy-autoscaling |              |         |                     |
+| some-product-2 | install | deploy-broker      | product-two  | 2.0.0   | stemcell is missing |
+| some-product-3 | delete  | delete-broker      | some-product | 3.0.0   | complete            |
++----------------+---------+--------------------+--------------+---------+---------------------+
 `
 
 	const jsonOutput = `{
@@ -35,26 +35,40 @@ var _ = Describe("pending_changes command", func() {
 					{"post_deploy": "true", "pre_delete": true, "name": "smoke-tests"},
 					{"post_deploy": "false", "pre_delete": false, "name": "deploy-autoscaling"}
 				],
-				"action": "update"
+				"action": "update",
+				"name": "product-one",
+				"version": "1.1.0",
+				"complete": true
 			},
 			{
 				"guid": "some-product-2",
 				"errands": [
 					{"post_deploy": "when-changed", "name": "deploy-broker"}
 				],
-				"action": "install"
+				"action": "install",
+				"name": "product-two",
+				"version": "2.0.0",
+				"complete": false,
+				"problems": ["stemcell is missing"]
 			},
 			{
 				"guid": "some-product-3",
 				"errands": [
 					{"post_deploy": "when-changed", "name": "delete-broker"}
 				],
-				"action": "delete"
+				"action": "delete",
+				"name": "some-product",
+				"version": "3.0.0",
+				"complete": true
 			}
 		]
 	}`
 
+	var diagnosticReportUnavailable bool
+
 	BeforeEach(func() {
+		diagnosticReportUnavailable = false
+
 		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 			w.Header().Set("Content-Type", "application/json")
 
@@ -73,14 +87,24 @@ var _ = Describe("pending_changes command", func() {
 							{"post_deploy": "true", "pre_delete": true, "name": "smoke-tests"},
 							{"post_deploy": "false", "pre_delete": false, "name": "deploy-autoscaling"}
 						],
-						"action": "update"
+						"action": "update",
+						"completeness_checks": {
+							"configuration_complete": true,
+							"stemcell_present": true,
+							"configurable_properties_valid": true
+						}
 					},
 					{
 						"guid": "some-product-2",
 						"errands": [
 							{"post_deploy": "when-changed", "name": "deploy-broker"}
 						],
-						"action": "install"
+						"action": "install",
+						"completeness_checks": {
+							"configuration_complete": true,
+							"stemcell_present": false,
+							"configurable_properties_valid": true
+						}
 					},
 					{
 						"guid": "some-product-3",
@@ -90,6 +114,29 @@ var _ = Describe("pending_changes command", func() {
 						"action": "delete"
 					}]
 				}`))
+			case "/api/v0/staged/products":
+				w.Write([]byte(`[
+					{"installation_name": "some-product-1", "guid": "some-product-1", "type": "product-one"},
+					{"installation_name": "some-product-2", "guid": "some-product-2", "type": "product-two"}
+				]`))
+			case "/api/v0/diagnostic_report":
+				if diagnosticReportUnavailable {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				w.Write([]byte(`{
+					"added_products": {
+						"staged": [
+							{"name": "product-one", "version": "1.1.0"},
+							{"name": "product-two", "version": "2.0.0"}
+						],
+						"deployed": [
+							{"name": "product-one", "version": "1.0.0"},
+							{"name": "some-product", "version": "3.0.0"}
+						]
+					}
+				}`))
 			default:
 				out, err := httputil.DumpRequest(req, true)
 				Expect(err).NotTo(HaveOccurred())
@@ -133,4 +180,75 @@ var _ = Describe("pending_changes command", func() {
 		})
 	})
 
+	Context("when --check is provided", func() {
+		It("exits with an error because the foundation is not converged", func() {
+			command := exec.Command(pathToMain,
+				"--target", server.URL,
+				"--username", "some-username",
+				"--password", "some-password",
+				"--skip-ssl-validation",
+				"pending-changes",
+				"--check")
+
+			session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(session).Should(gexec.Exit(1))
+
+			Expect(string(session.Out.Contents())).To(Equal(tableOutput))
+			Expect(string(session.Err.Contents())).To(ContainSubstring("foundation is not converged: 3 product(s) with pending changes and 1 incomplete product(s)"))
+		})
+	})
+
+	Context("when the diagnostic report is unavailable", func() {
+		It("lists the pending changes without versions", func() {
+			diagnosticReportUnavailable = true
+
+			command := exec.Command(pathToMain,
+				"--format", "json",
+				"--target", server.URL,
+				"--username", "some-username",
+				"--password", "some-password",
+				"--skip-ssl-validation",
+				"pending-changes")
+
+			session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(session).Should(gexec.Exit(0))
+
+			Expect(string(session.Out.Contents())).To(MatchJSON(`{
+				"pending_changes": [{
+						"guid": "some-product-1",
+						"errands": [
+							{"post_deploy": "true", "pre_delete": true, "name": "smoke-tests"},
+							{"post_deploy": "false", "pre_delete": false, "name": "deploy-autoscaling"}
+						],
+						"action": "update",
+						"name": "product-one",
+						"complete": true
+					},
+					{
+						"guid": "some-product-2",
+						"errands": [
+							{"post_deploy": "when-changed", "name": "deploy-broker"}
+						],
+						"action": "install",
+						"name": "product-two",
+						"complete": false,
+						"problems": ["stemcell is missing"]
+					},
+					{
+						"guid": "some-product-3",
+						"errands": [
+							{"post_deploy": "when-changed", "name": "delete-broker"}
+						],
+						"action": "delete",
+						"name": "some-product-3",
+						"complete": true
+					}
+				]
+			}`))
+		})
+	})
 })
@@ -0,0 +1,137 @@
+package acceptance
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("products command", func() {
+	var server *httptest.Server
+
+	const tableOutput = `+----------------+--------------------------+------------------+------------------+-----------------------+---------------------+-----------------+
+|      NAME      |    AVAILABLE VERSIONS    |  STAGED VERSION  | DEPLOYED VERSION |       STEMCELL        |        GUID         | PENDING UPGRADE |
++----------------+--------------------------+------------------+------------------+-----------------------+---------------------+-----------------+
+| acme-product-1 | 1.12.0, 1.13.0-build.100 | 1.13.0-build.100 | 1.12.0           | bosh-stemcell-3541.10 | acme-product-1-guid | yes             |
+| acme-product-2 | 1.8.9-build.1            |                  |                  |                       |                     |                 |
++----------------+--------------------------+------------------+------------------+-----------------------+---------------------+-----------------+
+`
+
+	const jsonOutput = `{
+		"products": [
+			{
+				"name": "acme-product-1",
+				"guid": "acme-product-1-guid",
+				"available_versions": ["1.12.0", "1.13.0-build.100"],
+				"staged_version": "1.13.0-build.100",
+				"deployed_version": "1.12.0",
+				"stemcell": "bosh-stemcell-3541.10",
+				"pending_upgrade": true
+			},
+			{
+				"name": "acme-product-2",
+				"available_versions": ["1.8.9-build.1"],
+				"pending_upgrade": false
+			}
+		]
+	}`
+
+	BeforeEach(func() {
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch req.URL.Path {
+			case "/uaa/oauth/token":
+				w.Write([]byte(`{
+				"access_token": "some-opsman-token",
+				"token_type": "bearer",
+				"expires_in": 3600
+			}`))
+			case "/api/v0/available_products":
+				auth := req.Header.Get("Authorization")
+				if auth != "Bearer some-opsman-token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				w.Write([]byte(`[
+					{"name":"acme-product-1","product_version":"1.13.0-build.100"},
+					{"name":"acme-product-1","product_version":"1.12.0"},
+					{"name":"acme-product-2","product_version":"1.8.9-build.1"}
+				]`))
+			case "/api/v0/staged/products":
+				auth := req.Header.Get("Authorization")
+				if auth != "Bearer some-opsman-token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				w.Write([]byte(`[
+					{"installation_name":"acme-product-1-guid","guid":"acme-product-1-guid","type":"acme-product-1"}
+				]`))
+			case "/api/v0/diagnostic_report":
+				auth := req.Header.Get("Authorization")
+				if auth != "Bearer some-opsman-token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				w.Write([]byte(`{
+					"added_products": {
+						"staged": [
+							{"name":"acme-product-1","version":"1.13.0-build.100","stemcell":"bosh-stemcell-3541.10"}
+						],
+						"deployed": [
+							{"name":"acme-product-1","version":"1.12.0","stemcell":"bosh-stemcell-3541.10"}
+						]
+					}
+				}`))
+			default:
+				out, err := httputil.DumpRequest(req, true)
+				Expect(err).NotTo(HaveOccurred())
+				Fail(fmt.Sprintf("unexpected request: %s", out))
+			}
+		}))
+	})
+
+	It("lists the available, staged and deployed products on Ops Manager", func() {
+		command := exec.Command(pathToMain,
+			"--target", server.URL,
+			"--username", "some-username",
+			"--password", "some-password",
+			"--skip-ssl-validation",
+			"products",
+		)
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(0))
+		Expect(string(session.Out.Contents())).To(Equal(tableOutput))
+	})
+
+	Context("when json format is requested", func() {
+		It("lists the available, staged and deployed products on Ops Manager", func() {
+			command := exec.Command(pathToMain,
+				"--format", "json",
+				"--target", server.URL,
+				"--username", "some-username",
+				"--password", "some-password",
+				"--skip-ssl-validation",
+				"products",
+			)
+
+			session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(session).Should(gexec.Exit(0))
+			Expect(string(session.Out.Contents())).To(MatchJSON(jsonOutput))
+		})
+	})
+})
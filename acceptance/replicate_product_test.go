@@ -0,0 +1,82 @@
+package acceptance
+
+import (
+	"archive/zip"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("replicate-product command", func() {
+	var (
+		productFile *os.File
+		outputDir   string
+	)
+
+	BeforeEach(func() {
+		var err error
+		productFile, err = ioutil.TempFile("", "some-product.pivotal")
+		Expect(err).NotTo(HaveOccurred())
+
+		zipper := zip.NewWriter(productFile)
+
+		writer, err := zipper.Create("metadata/some-product.yml")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = io.WriteString(writer, `---
+name: some-product
+product_version: 1.0.0
+label: Some Product
+job_types:
+- name: some-job`)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = zipper.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		outputDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.Remove(productFile.Name())
+		os.RemoveAll(outputDir)
+	})
+
+	It("writes a replicated product that config-template reads under the new name", func() {
+		outputPath := filepath.Join(outputDir, "some-product-blue.pivotal")
+
+		command := exec.Command(pathToMain,
+			"replicate-product",
+			"--product", productFile.Name(),
+			"--name", "blue",
+			"--output", outputPath,
+		)
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(0))
+		Expect(session.Out).To(gbytes.Say("wrote some-product-blue to " + outputPath))
+
+		command = exec.Command(pathToMain,
+			"config-template",
+			"--product", outputPath,
+		)
+
+		session, err = gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(0))
+		Expect(session.Out).To(gbytes.Say("product-name: some-product-blue"))
+		Expect(session.Out).To(gbytes.Say("some-job-blue:"))
+	})
+})
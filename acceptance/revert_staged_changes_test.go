@@ -17,11 +17,16 @@ import (
 var _ = Describe("revert-staged-changes command", func() {
 	var (
 		server          *httptest.Server
+		revertSupported bool
+		reverted        bool
 		receivedCookies []*http.Cookie
 		Forms           []url.Values
 	)
 
 	BeforeEach(func() {
+		revertSupported = true
+		reverted = false
+
 		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 			switch req.URL.Path {
 			case "/uaa/oauth/token":
@@ -31,6 +36,32 @@ var _ = Describe("revert-staged-changes command", func() {
 				"token_type": "bearer",
 				"expires_in": 3600
 			}`))
+			case "/api/v0/staged/pending_changes":
+				w.Write([]byte(`{
+					"product_changes": [
+						{"guid": "p-bosh-guid", "errands": [], "action": "unchanged"},
+						{"guid": "some-product-guid", "errands": [], "action": "update"}
+					]
+				}`))
+			case "/api/v0/staged/products":
+				w.Write([]byte(`[
+					{"guid": "p-bosh-guid", "type": "p-bosh"},
+					{"guid": "some-product-guid", "type": "some-product"}
+				]`))
+			case "/api/v0/deployed/products":
+				w.Write([]byte(`[
+					{"guid": "p-bosh-guid", "type": "p-bosh"}
+				]`))
+			case "/api/v0/staged":
+				Expect(req.Method).To(Equal("DELETE"))
+
+				if !revertSupported {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				reverted = true
+				w.Write([]byte(`{}`))
 			case "/":
 				http.SetCookie(w, &http.Cookie{
 					Name:  "somecookie",
@@ -84,18 +115,41 @@ var _ = Describe("revert-staged-changes command", func() {
 		)
 	})
 
-	It("reverts staged changes on the installation dashboard", func() {
+	It("reverts staged changes through the api and lists the discarded changes", func() {
 		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
 		Expect(err).NotTo(HaveOccurred())
 
 		Eventually(session).Should(gexec.Exit(0))
 
+		Expect(reverted).To(BeTrue())
+		Expect(Forms).To(BeEmpty())
+
 		Expect(session.Out).To(gbytes.Say("reverting staged changes on the targeted Ops Manager"))
-		Expect(receivedCookies).To(HaveLen(1))
-		Expect(receivedCookies[0].Name).To(Equal("somecookie"))
+		Expect(session.Out).To(gbytes.Say("discarded pending changes:"))
+		Expect(session.Out).To(gbytes.Say("some-product: update"))
+		Expect(session.Out).NotTo(gbytes.Say("p-bosh"))
+	})
+
+	Context("when the Ops Manager cannot revert staged changes through the api", func() {
+		BeforeEach(func() {
+			revertSupported = false
+		})
+
+		It("reverts staged changes on the installation dashboard", func() {
+			session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(session).Should(gexec.Exit(0))
+
+			Expect(session.Out).To(gbytes.Say("reverting staged changes on the targeted Ops Manager"))
+			Expect(session.Out).To(gbytes.Say("using the installation dashboard instead"))
+			Expect(session.Out).To(gbytes.Say("some-product: update"))
+			Expect(receivedCookies).To(HaveLen(1))
+			Expect(receivedCookies[0].Name).To(Equal("somecookie"))
 
-		Expect(Forms[0].Get("authenticity_token")).To(Equal("fake_authenticity"))
-		Expect(Forms[0].Get("_method")).To(Equal("delete"))
-		Expect(Forms[0].Get("commit")).To(Equal("Confirm"))
+			Expect(Forms[0].Get("authenticity_token")).To(Equal("fake_authenticity"))
+			Expect(Forms[0].Get("_method")).To(Equal("delete"))
+			Expect(Forms[0].Get("commit")).To(Equal("Confirm"))
+		})
 	})
 })
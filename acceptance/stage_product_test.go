@@ -67,10 +67,10 @@ var _ = Describe("stage-product command", func() {
 					} else {
 						responseString = `{}`
 						stageRequestMethod = req.Method
+						reqBody, err := ioutil.ReadAll(req.Body)
+						Expect(err).NotTo(HaveOccurred())
+						stageRequest = string(reqBody)
 					}
-					reqBody, err := ioutil.ReadAll(req.Body)
-					Expect(err).NotTo(HaveOccurred())
-					stageRequest = string(reqBody)
 				case "/api/v0/diagnostic_report":
 					responseString = `{}`
 				default:
@@ -161,9 +161,6 @@ var _ = Describe("stage-product command", func() {
 					if req.Method == "GET" {
 						responseString = `[]`
 					}
-					reqBody, err := ioutil.ReadAll(req.Body)
-					Expect(err).NotTo(HaveOccurred())
-					stageRequest = string(reqBody)
 				case "/api/v0/staged/products/cf-some-guid":
 					auth := req.Header.Get("Authorization")
 					if auth != "Bearer some-opsman-token" {
@@ -210,6 +207,52 @@ var _ = Describe("stage-product command", func() {
 					"to_version": "1.8.7-build.3"
 			}`))
 		})
+
+		Context("when a version constraint is provided", func() {
+			It("stages the highest matching version", func() {
+				command := exec.Command(pathToMain,
+					"--target", server.URL,
+					"--username", "some-username",
+					"--password", "some-password",
+					"--skip-ssl-validation",
+					"stage-product",
+					"--product-name", "cf",
+					"--product-version", "~> 1.8.0",
+				)
+
+				session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(session).Should(gexec.Exit(0))
+				Eventually(session.Out).Should(gbytes.Say("resolved cf ~> 1.8.0 to version 1.8.7-build.3"))
+				Eventually(session.Out).Should(gbytes.Say("staging cf 1.8.7-build.3"))
+				Eventually(session.Out).Should(gbytes.Say("finished staging"))
+
+				Expect(stageRequest).To(MatchJSON(`{
+					"to_version": "1.8.7-build.3"
+				}`))
+			})
+		})
+
+		Context("when no version matches the constraint", func() {
+			It("returns an error", func() {
+				command := exec.Command(pathToMain,
+					"--target", server.URL,
+					"--username", "some-username",
+					"--password", "some-password",
+					"--skip-ssl-validation",
+					"stage-product",
+					"--product-name", "cf",
+					"--product-version", "~> 1.9.0",
+				)
+
+				session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(session).Should(gexec.Exit(1))
+				Expect(string(session.Err.Contents())).To(ContainSubstring(`no version of cf matches "~> 1.9.0"; available versions: 1.8.7-build.3, 1.8.5-build.1`))
+			})
+		})
 	})
 
 	Context("when the same type of product is already staged", func() {
@@ -266,6 +309,13 @@ var _ = Describe("stage-product command", func() {
 					reqBody, err := ioutil.ReadAll(req.Body)
 					Expect(err).NotTo(HaveOccurred())
 					stageRequest = string(reqBody)
+				case "/api/v0/staged/products/cf-some-guid/dependencies":
+					auth := req.Header.Get("Authorization")
+					if auth != "Bearer some-opsman-token" {
+						w.WriteHeader(http.StatusUnauthorized)
+						return
+					}
+					responseString = `{"dependencies": []}`
 				case "/api/v0/staged/products/cf-some-guid":
 					auth := req.Header.Get("Authorization")
 					if auth != "Bearer some-opsman-token" {
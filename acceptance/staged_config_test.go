@@ -0,0 +1,107 @@
+package acceptance
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"os/exec"
+
+	"github.com/onsi/gomega/gexec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("staged-config command", func() {
+	var (
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch req.URL.Path {
+			case "/uaa/oauth/token":
+				w.Write([]byte(`{
+				"access_token": "some-opsman-token",
+				"token_type": "bearer",
+				"expires_in": 3600
+			}`))
+			case "/api/v0/staged/products":
+				w.Write([]byte(`[
+					{"installation_name":"p-bosh","guid":"p-bosh-guid","type":"p-bosh","product_version":"1.10.0.0"},
+					{"installation_name":"cf","guid":"cf-guid","type":"cf","product_version":"1.10.0-build.177"}
+				]`))
+			case "/api/v0/staged/products/cf-guid/jobs":
+				w.Write([]byte(`{
+					"jobs": [
+						{"name": "router", "guid": "router-guid"},
+						{"name": "diego_cell", "guid": "diego-cell-guid"}
+					]
+				}`))
+			case "/api/v0/staged/products/cf-guid/max_in_flight":
+				w.Write([]byte(`{
+					"max_in_flight": {
+						"router-guid": "20%",
+						"diego-cell-guid": 2
+					}
+				}`))
+			case "/api/v0/staged/products/cf-guid/syslog_configuration":
+				w.Write([]byte(`{
+					"syslog_configuration": {
+						"enabled": true,
+						"address": "syslog.example.com"
+					}
+				}`))
+			case "/api/v0/staged/products/cf-guid/errands":
+				w.Write([]byte(`{
+					"errands": [
+						{"name": "smoke_tests", "post_deploy": true, "pre_delete": false},
+						{"name": "push-apps-manager", "post_deploy": "when-changed"}
+					]
+				}`))
+			default:
+				out, err := httputil.DumpRequest(req, true)
+				Expect(err).NotTo(HaveOccurred())
+				Fail(fmt.Sprintf("unexpected request: %s", out))
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("writes the staged configuration of the product", func() {
+		command := exec.Command(pathToMain,
+			"--target", server.URL,
+			"--username", "some-username",
+			"--password", "some-password",
+			"--skip-ssl-validation",
+			"staged-config",
+			"--product-name", "cf")
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(0))
+
+		Expect(string(session.Out.Contents())).To(MatchYAML(`
+product-name: cf
+max-in-flight:
+  diego_cell: 2
+  router: 20%
+syslog-properties:
+  address: syslog.example.com
+  enabled: true
+errand-config:
+  push-apps-manager:
+    post-deploy-state: when-changed
+  smoke_tests:
+    post-deploy-state: enabled
+    pre-delete-state: disabled
+`))
+	})
+})
@@ -0,0 +1,104 @@
+package acceptance
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"os/exec"
+
+	"github.com/onsi/gomega/gexec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("stemcell-assignments command", func() {
+	var (
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch req.URL.Path {
+			case "/uaa/oauth/token":
+				w.Write([]byte(`{
+				"access_token": "some-opsman-token",
+				"token_type": "bearer",
+				"expires_in": 3600
+			}`))
+			case "/api/v0/stemcell_assignments":
+				w.Write([]byte(`{
+					"products": [{
+						"guid": "cf-guid",
+						"identifier": "cf",
+						"staged_product_version": "2.1.0",
+						"is_staged_for_deletion": false,
+						"staged_stemcell_version": "3541.10",
+						"required_stemcell_version": "3541",
+						"required_stemcell_os": "ubuntu-trusty",
+						"available_stemcell_versions": ["3541.10", "3541.12"]
+					}],
+					"stemcell_library": []
+				}`))
+			default:
+				out, err := httputil.DumpRequest(req, true)
+				Expect(err).NotTo(HaveOccurred())
+				Fail(fmt.Sprintf("unexpected request: %s", out))
+			}
+		}))
+	})
+
+	It("lists the stemcell assignments of the staged products", func() {
+		command := exec.Command(pathToMain,
+			"--target", server.URL,
+			"--username", "some-username",
+			"--password", "some-password",
+			"--skip-ssl-validation",
+			"stemcell-assignments")
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(0))
+
+		Expect(string(session.Out.Contents())).To(Equal(`+---------+---------------+-----------------+--------------------+
+| PRODUCT |  REQUIRED OS  | STAGED STEMCELL | AVAILABLE VERSIONS |
++---------+---------------+-----------------+--------------------+
+| cf      | ubuntu-trusty | 3541.10         | 3541.10, 3541.12   |
++---------+---------------+-----------------+--------------------+
+`))
+	})
+
+	Context("when JSON format is requested", func() {
+		It("lists the stemcell assignments in JSON format", func() {
+			command := exec.Command(pathToMain,
+				"--format", "json",
+				"--target", server.URL,
+				"--username", "some-username",
+				"--password", "some-password",
+				"--skip-ssl-validation",
+				"stemcell-assignments")
+
+			session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(session).Should(gexec.Exit(0))
+
+			Expect(string(session.Out.Contents())).To(MatchJSON(`{
+				"stemcell_assignments": [{
+					"guid": "cf-guid",
+					"identifier": "cf",
+					"staged_product_version": "2.1.0",
+					"is_staged_for_deletion": false,
+					"staged_stemcell_version": "3541.10",
+					"required_stemcell_version": "3541",
+					"required_stemcell_os": "ubuntu-trusty",
+					"available_stemcell_versions": ["3541.10", "3541.12"]
+				}]
+			}`))
+		})
+	})
+})
@@ -1,6 +1,8 @@
 package acceptance
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -9,6 +11,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sync"
 
 	"github.com/onsi/gomega/gbytes"
 	"github.com/onsi/gomega/gexec"
@@ -19,12 +22,16 @@ import (
 
 var _ = Describe("upload-stemcell command", func() {
 	var (
-		stemcellName string
-		content      *os.File
-		server       *httptest.Server
+		stemcellName  string
+		stemcellNames []string
+		stemcellMutex sync.Mutex
+		content       *os.File
+		server        *httptest.Server
 	)
 
 	BeforeEach(func() {
+		stemcellNames = []string{}
+
 		var err error
 		content, err = ioutil.TempFile("", "cool_name.com")
 		Expect(err).NotTo(HaveOccurred())
@@ -52,6 +59,8 @@ var _ = Describe("upload-stemcell command", func() {
 			}`
 			case "/api/v0/diagnostic_report":
 				responseString = "{}"
+			case "/api/v0/stemcell_assignments":
+				responseString = `{"products": [], "stemcell_library": []}`
 			case "/api/v0/stemcells":
 				auth := req.Header.Get("Authorization")
 
@@ -65,7 +74,11 @@ var _ = Describe("upload-stemcell command", func() {
 					panic(err)
 				}
 
+				stemcellMutex.Lock()
 				stemcellName = req.MultipartForm.File["stemcell[file]"][0].Filename
+				stemcellNames = append(stemcellNames, stemcellName)
+				stemcellMutex.Unlock()
+
 				responseString = "{}"
 			default:
 				out, err := httputil.DumpRequest(req, true)
@@ -102,6 +115,49 @@ var _ = Describe("upload-stemcell command", func() {
 		Expect(stemcellName).To(Equal(filepath.Base(content.Name())))
 	})
 
+	Context("when several stemcells are provided", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "")
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, name := range []string{"first-stemcell.tgz", "second-stemcell.tgz", "third-stemcell.tgz"} {
+				err = ioutil.WriteFile(filepath.Join(dir, name), []byte("content so validation does not fail"), 0644)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("uploads every stemcell and prints a summary", func() {
+			command := exec.Command(pathToMain,
+				"--target", server.URL,
+				"--username", "some-username",
+				"--password", "pass",
+				"--skip-ssl-validation",
+				"upload-stemcell",
+				"--stemcell", filepath.Join(dir, "*-stemcell.tgz"),
+				"--parallel", "2",
+			)
+
+			session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(session, 10).Should(gexec.Exit(0))
+			Expect(session.Out).To(gbytes.Say("uploading 3 stemcells to Ops Manager, 2 at a time"))
+			Expect(session.Out).To(gbytes.Say("upload summary:"))
+			Expect(session.Out).To(gbytes.Say(`first-stemcell.tgz: uploaded`))
+			Expect(session.Out).To(gbytes.Say(`second-stemcell.tgz: uploaded`))
+			Expect(session.Out).To(gbytes.Say(`third-stemcell.tgz: uploaded`))
+
+			Expect(stemcellNames).To(ConsistOf("first-stemcell.tgz", "second-stemcell.tgz", "third-stemcell.tgz"))
+		})
+	})
+
 	Context("when the stemcell already exists", func() {
 		It("exits early with no error", func() {
 			var diagnosticReport []byte
@@ -122,6 +178,8 @@ var _ = Describe("upload-stemcell command", func() {
 					}
 
 					w.Write(diagnosticReport)
+				case "/api/v0/stemcell_assignments":
+					w.Write([]byte(`{"products": [], "stemcell_library": []}`))
 				default:
 					out, err := httputil.DumpRequest(req, true)
 					Expect(err).NotTo(HaveOccurred())
@@ -155,6 +213,113 @@ var _ = Describe("upload-stemcell command", func() {
 		})
 	})
 
+	Context("when the stemcell contains a manifest", func() {
+		var (
+			stemcell            *os.File
+			diagnosticReport    string
+			stemcellAssignments string
+		)
+
+		BeforeEach(func() {
+			var err error
+			stemcell, err = ioutil.TempFile("", "renamed-stemcell.tgz")
+			Expect(err).NotTo(HaveOccurred())
+
+			gzipWriter := gzip.NewWriter(stemcell)
+			tarWriter := tar.NewWriter(gzipWriter)
+
+			manifest := `---
+name: bosh-vsphere-esxi-ubuntu-trusty-go_agent
+version: "3445.11"
+operating_system: ubuntu-trusty
+cloud_properties:
+  infrastructure: vsphere
+`
+			err = tarWriter.WriteHeader(&tar.Header{Name: "./stemcell.MF", Mode: 0644, Size: int64(len(manifest))})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = tarWriter.Write([]byte(manifest))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tarWriter.Close()).To(Succeed())
+			Expect(gzipWriter.Close()).To(Succeed())
+			Expect(stemcell.Close()).To(Succeed())
+
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch req.URL.Path {
+				case "/uaa/oauth/token":
+					w.Write([]byte(`{
+						"access_token": "some-opsman-token",
+						"token_type": "bearer",
+						"expires_in": 3600
+					}`))
+				case "/api/v0/diagnostic_report":
+					w.Write([]byte(diagnosticReport))
+				case "/api/v0/stemcell_assignments":
+					w.Write([]byte(stemcellAssignments))
+				default:
+					out, err := httputil.DumpRequest(req, true)
+					Expect(err).NotTo(HaveOccurred())
+					Fail(fmt.Sprintf("unexpected request: %s", out))
+				}
+			}))
+		})
+
+		AfterEach(func() {
+			os.Remove(stemcell.Name())
+		})
+
+		It("skips a stemcell that was uploaded under a different file name", func() {
+			diagnosticReport = `{
+				"infrastructure_type": "vsphere",
+				"stemcells": ["bosh-stemcell-3445.11-vsphere-esxi-ubuntu-trusty-go_agent.tgz"]
+			}`
+			stemcellAssignments = `{
+				"products": [],
+				"stemcell_library": [{"version": "3445.11", "os": "ubuntu-trusty", "infrastructure": "vsphere"}]
+			}`
+
+			command := exec.Command(pathToMain,
+				"--target", server.URL,
+				"--username", "some-username",
+				"--password", "some-password",
+				"--skip-ssl-validation",
+				"upload-stemcell",
+				"--stemcell", stemcell.Name(),
+			)
+
+			session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(session).Should(gexec.Exit(0))
+			Eventually(session.Out).Should(gbytes.Say("stemcell has already been uploaded"))
+		})
+
+		It("refuses a stemcell for a different infrastructure", func() {
+			diagnosticReport = `{
+				"infrastructure_type": "aws",
+				"stemcells": []
+			}`
+			stemcellAssignments = `{"products": [], "stemcell_library": []}`
+
+			command := exec.Command(pathToMain,
+				"--target", server.URL,
+				"--username", "some-username",
+				"--password", "some-password",
+				"--skip-ssl-validation",
+				"upload-stemcell",
+				"--stemcell", stemcell.Name(),
+			)
+
+			session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(session).Should(gexec.Exit(1))
+			Eventually(session.Err).Should(gbytes.Say("stemcell bosh-vsphere-esxi-ubuntu-trusty-go_agent 3445.11 is for the vsphere infrastructure, but Ops Manager is running on aws"))
+		})
+	})
+
 	Context("when an error occurs", func() {
 		Context("when the content to upload is empty", func() {
 			var emptyContent *os.File
@@ -0,0 +1,112 @@
+package acceptance
+
+import (
+	"archive/zip"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("validate-config command", func() {
+	var (
+		productFile *os.File
+		configFile  *os.File
+	)
+
+	BeforeEach(func() {
+		var err error
+		productFile, err = ioutil.TempFile("", "some-product.pivotal")
+		Expect(err).NotTo(HaveOccurred())
+
+		zipper := zip.NewWriter(productFile)
+
+		productWriter, err := zipper.Create("./metadata/some-product.yml")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = io.WriteString(productWriter, `
+---
+product_version: 1.8.14
+name: some-product
+property_blueprints:
+- name: some-property
+  type: string
+  configurable: true
+- name: some-port
+  type: port
+  configurable: true
+  default: 8080
+job_types:
+- name: some-job`)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = zipper.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		configFile, err = ioutil.TempFile("", "config.yml")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.Remove(productFile.Name())
+		os.Remove(configFile.Name())
+	})
+
+	It("reports that a valid config file is valid", func() {
+		err := ioutil.WriteFile(configFile.Name(), []byte(`---
+product-name: some-product
+product-properties:
+  .properties.some-property:
+    value: some-value
+resource-config:
+  some-job:
+    instances: 1
+`), 0644)
+		Expect(err).NotTo(HaveOccurred())
+
+		command := exec.Command(pathToMain,
+			"validate-config",
+			"--product", productFile.Name(),
+			"--config", configFile.Name(),
+		)
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(0))
+		Expect(session.Out).To(gbytes.Say("config file is valid for some-product 1.8.14"))
+	})
+
+	It("reports every problem with an invalid config file", func() {
+		err := ioutil.WriteFile(configFile.Name(), []byte(`---
+product-properties:
+  .properties.some-port:
+    value: eighty
+resource-config:
+  other-job:
+    instances: 1
+`), 0644)
+		Expect(err).NotTo(HaveOccurred())
+
+		command := exec.Command(pathToMain,
+			"validate-config",
+			"--product", productFile.Name(),
+			"--config", configFile.Name(),
+		)
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(1))
+		Expect(string(session.Err.Contents())).To(ContainSubstring(`product configuration is invalid:
+.properties.some-port: expected an integer, got "eighty"
+.properties.some-property: required property is missing
+resource-config.other-job: unknown job`))
+	})
+})
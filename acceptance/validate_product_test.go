@@ -0,0 +1,103 @@
+package acceptance
+
+import (
+	"archive/zip"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("validate-product command", func() {
+	var productFile *os.File
+
+	createProduct := func(files map[string]string) {
+		var err error
+		productFile, err = ioutil.TempFile("", "some-product.pivotal")
+		Expect(err).NotTo(HaveOccurred())
+
+		zipper := zip.NewWriter(productFile)
+		for name, contents := range files {
+			writer, err := zipper.Create(name)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = io.WriteString(writer, contents)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		err = zipper.Close()
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	AfterEach(func() {
+		os.Remove(productFile.Name())
+	})
+
+	It("succeeds for a valid product file", func() {
+		createProduct(map[string]string{
+			"metadata/some-product.yml": `---
+name: some-product
+product_version: 1.0.0
+metadata_version: "2.0"
+label: Some Product
+stemcell_criteria:
+  os: ubuntu-trusty
+  version: "3468"
+releases:
+- name: some-release
+  file: some-release-1.0.0.tgz
+  version: 1.0.0
+  sha1: 62798a2ca629640fe2c494e53bf265367cd8c72d
+job_types:
+- name: some-job`,
+			"releases/some-release-1.0.0.tgz": "some-release-contents",
+		})
+
+		command := exec.Command(pathToMain,
+			"validate-product",
+			"--product", productFile.Name(),
+		)
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(0))
+		Expect(session.Out).To(gbytes.Say("is valid"))
+	})
+
+	It("lists the problems of an invalid product file", func() {
+		createProduct(map[string]string{
+			"metadata/some-product.yml": `---
+name: some-product
+product_version: 1.0.0
+metadata_version: "2.0"
+label: Some Product
+stemcell_criteria:
+  os: ubuntu-trusty
+  version: "3468"
+releases:
+- name: some-release
+  file: some-release-1.0.0.tgz
+job_types:
+- name: some-job`,
+		})
+
+		command := exec.Command(pathToMain,
+			"validate-product",
+			"--product", productFile.Name(),
+		)
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(1))
+		Expect(session.Err).To(gbytes.Say("product file is invalid:"))
+		Expect(session.Err).To(gbytes.Say("release some-release: releases/some-release-1.0.0.tgz is missing from the product file"))
+	})
+})
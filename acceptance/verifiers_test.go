@@ -0,0 +1,110 @@
+package acceptance
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"os/exec"
+
+	"github.com/onsi/gomega/gexec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("verifiers commands", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch req.Method + " " + req.URL.Path {
+			case "POST /uaa/oauth/token":
+				w.Write([]byte(`{
+				"access_token": "some-opsman-token",
+				"token_type": "bearer",
+				"expires_in": 3600
+			}`))
+			case "GET /api/v0/staged/products":
+				w.Write([]byte(`[
+					{"installation_name": "p-bosh", "guid": "p-bosh-guid", "type": "p-bosh"},
+					{"installation_name": "cf", "guid": "cf-guid", "type": "cf"}
+				]`))
+			case "GET /api/v0/staged/products/cf-guid/verifiers/install_time":
+				w.Write([]byte(`{
+					"verifiers": [
+						{"type": "WildcardDomainVerifier", "enabled": true},
+						{"type": "AppsDomainVerifier", "enabled": false}
+					]
+				}`))
+			case "GET /api/v0/staged/products/cf-guid/pre_deploy_check":
+				w.Write([]byte(`{
+					"pre_deploy_check": {
+						"identifier": "cf-guid",
+						"complete": false,
+						"verifiers": [
+							{"type": "WildcardDomainVerifier", "errors": ["*.apps.example.com does not resolve"], "ignorable": false}
+						]
+					}
+				}`))
+			default:
+				out, err := httputil.DumpRequest(req, true)
+				Expect(err).NotTo(HaveOccurred())
+				Fail(fmt.Sprintf("unexpected request: %s", out))
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("lists the verifiers of a product", func() {
+		command := exec.Command(pathToMain,
+			"--target", server.URL,
+			"--username", "some-username",
+			"--password", "some-password",
+			"--skip-ssl-validation",
+			"verifiers",
+			"--product-name", "cf")
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(0))
+
+		Expect(string(session.Out.Contents())).To(Equal(`+------------------------+---------+
+|          TYPE          | ENABLED |
++------------------------+---------+
+| WildcardDomainVerifier | true    |
+| AppsDomainVerifier     | false   |
++------------------------+---------+
+`))
+	})
+
+	It("runs the verifiers of a product and fails when one of them fails", func() {
+		command := exec.Command(pathToMain,
+			"--target", server.URL,
+			"--username", "some-username",
+			"--password", "some-password",
+			"--skip-ssl-validation",
+			"run-verifiers",
+			"--product-name", "cf")
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(1))
+
+		Expect(string(session.Out.Contents())).To(Equal(`+------------------------+----------+-------------------------------------+
+|          TYPE          |  STATUS  |              MESSAGES               |
++------------------------+----------+-------------------------------------+
+| WildcardDomainVerifier | failed   | *.apps.example.com does not resolve |
+| AppsDomainVerifier     | disabled |                                     |
++------------------------+----------+-------------------------------------+
+`))
+		Expect(string(session.Err.Contents())).To(Equal("could not execute \"run-verifiers\": 1 of 2 verifiers failed\n"))
+	})
+})
@@ -0,0 +1,101 @@
+package acceptance
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"os/exec"
+
+	"github.com/onsi/gomega/gexec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("vm extensions commands", func() {
+	var (
+		server           *httptest.Server
+		createdExtension []byte
+		deletedExtension bool
+	)
+
+	BeforeEach(func() {
+		createdExtension = nil
+		deletedExtension = false
+
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch req.Method + " " + req.URL.Path {
+			case "POST /uaa/oauth/token":
+				w.Write([]byte(`{
+				"access_token": "some-opsman-token",
+				"token_type": "bearer",
+				"expires_in": 3600
+			}`))
+			case "GET /api/v0/staged/vm_extensions":
+				w.Write([]byte(`{
+					"vm_extensions": [
+						{"name": "some-extension", "cloud_properties": {"elbs": ["some-elb"]}}
+					]
+				}`))
+			case "PUT /api/v0/staged/vm_extensions/some-extension":
+				var err error
+				createdExtension, err = ioutil.ReadAll(req.Body)
+				Expect(err).NotTo(HaveOccurred())
+				w.Write([]byte(`{}`))
+			case "DELETE /api/v0/staged/vm_extensions/some-extension":
+				deletedExtension = true
+				w.Write([]byte(`{}`))
+			default:
+				out, err := httputil.DumpRequest(req, true)
+				Expect(err).NotTo(HaveOccurred())
+				Fail(fmt.Sprintf("unexpected request: %s", out))
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	run := func(args ...string) *gexec.Session {
+		command := exec.Command(pathToMain, append([]string{
+			"--target", server.URL,
+			"--username", "some-username",
+			"--password", "some-password",
+			"--skip-ssl-validation",
+		}, args...)...)
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(0))
+		return session
+	}
+
+	It("lists the vm extensions", func() {
+		session := run("vm-extensions")
+
+		Expect(string(session.Out.Contents())).To(Equal(`+----------------+------------------------+
+|      NAME      |    CLOUD PROPERTIES    |
++----------------+------------------------+
+| some-extension | {"elbs": ["some-elb"]} |
++----------------+------------------------+
+`))
+	})
+
+	It("creates a vm extension", func() {
+		run("create-vm-extension", "--name", "some-extension", "--cloud-properties", `{"elbs": ["some-elb"]}`)
+
+		Expect(createdExtension).To(MatchJSON(`{"name": "some-extension", "cloud_properties": {"elbs": ["some-elb"]}}`))
+	})
+
+	It("deletes a vm extension", func() {
+		run("delete-vm-extension", "--name", "some-extension")
+
+		Expect(deletedExtension).To(BeTrue())
+	})
+})
@@ -0,0 +1,120 @@
+package acceptance
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"os"
+	"os/exec"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("vm types commands", func() {
+	var (
+		server          *httptest.Server
+		configuredTypes []byte
+	)
+
+	BeforeEach(func() {
+		configuredTypes = nil
+
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch req.Method + " " + req.URL.Path {
+			case "POST /uaa/oauth/token":
+				w.Write([]byte(`{
+				"access_token": "some-opsman-token",
+				"token_type": "bearer",
+				"expires_in": 3600
+			}`))
+			case "GET /api/v0/vm_types":
+				w.Write([]byte(`{
+					"vm_types": [
+						{"name": "micro", "ram": 1024, "cpu": 1, "ephemeral_disk": 8192, "builtin": true},
+						{"name": "some-old-type", "ram": 2048, "cpu": 1, "ephemeral_disk": 16384, "builtin": false}
+					]
+				}`))
+			case "PUT /api/v0/vm_types":
+				var err error
+				configuredTypes, err = ioutil.ReadAll(req.Body)
+				Expect(err).NotTo(HaveOccurred())
+				w.Write([]byte(`{}`))
+			default:
+				out, err := httputil.DumpRequest(req, true)
+				Expect(err).NotTo(HaveOccurred())
+				Fail(fmt.Sprintf("unexpected request: %s", out))
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("lists the vm types", func() {
+		command := exec.Command(pathToMain,
+			"--target", server.URL,
+			"--username", "some-username",
+			"--password", "some-password",
+			"--skip-ssl-validation",
+			"vm-types")
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(0))
+
+		Expect(string(session.Out.Contents())).To(Equal(`+---------------+-----+----------+-----------+----------+
+|     NAME      | CPU | RAM (MB) | DISK (MB) | BUILT-IN |
++---------------+-----+----------+-----------+----------+
+| micro         | 1   | 1024     | 8192      | true     |
+| some-old-type | 1   | 2048     | 16384     | false    |
++---------------+-----+----------+-----------+----------+
+`))
+	})
+
+	It("replaces the custom vm types with the ones in the config file", func() {
+		configFile, err := ioutil.TempFile("", "vm-types.yml")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.Remove(configFile.Name())
+
+		_, err = configFile.WriteString(`---
+vm-types:
+- name: some-new-type
+  cpu: 2
+  ram: 4096
+  ephemeral_disk: 32768
+`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configFile.Close()).To(Succeed())
+
+		command := exec.Command(pathToMain,
+			"--target", server.URL,
+			"--username", "some-username",
+			"--password", "some-password",
+			"--skip-ssl-validation",
+			"configure-vm-types",
+			"--config", configFile.Name())
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(session).Should(gexec.Exit(0))
+		Expect(session.Out).To(gbytes.Say("removing custom vm types that are not in the config: some-old-type"))
+
+		Expect(configuredTypes).To(MatchJSON(`{
+			"vm_types": [
+				{"name": "micro", "cpu": 1, "ram": 1024, "ephemeral_disk": 8192, "builtin": true},
+				{"name": "some-new-type", "cpu": 2, "ram": 4096, "ephemeral_disk": 32768}
+			]
+		}`))
+	})
+})
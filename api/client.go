@@ -1,8 +1,57 @@
 package api
 
-import "net/http"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
 
 //go:generate counterfeiter -o ./fakes/httpclient.go --fake-name HttpClient . httpClient
 type httpClient interface {
 	Do(*http.Request) (*http.Response, error)
 }
+
+// doJSON sends body, when it is not nil, as json and decodes the response
+// into response, when it is not nil. The endpoint names the api endpoint in
+// error messages.
+func doJSON(client httpClient, method, path, endpoint string, body, response interface{}) error {
+	var contents []byte
+	if body != nil {
+		var err error
+		contents, err = json.Marshal(body)
+		if err != nil {
+			return err // cannot be tested
+		}
+	}
+
+	req, err := http.NewRequest(method, path, bytes.NewReader(contents))
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not make api request to %s endpoint: %s", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if err = ValidateStatusOK(resp); err != nil {
+		return err
+	}
+
+	if response == nil {
+		return nil
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(response)
+	if err != nil {
+		return fmt.Errorf("could not unmarshal %s response: %s", endpoint, err)
+	}
+
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"net/http"
 )
 
+const iaasConfigurationsEndpoint = "/api/v0/staged/director/iaas_configurations"
+
 type DirectorService struct {
 	client httpClient
 }
@@ -15,6 +17,11 @@ type AZConfiguration struct {
 	AvailabilityZones json.RawMessage `json:"availability_zones,omitempty"`
 }
 
+type AvailabilityZone struct {
+	GUID string `json:"guid"`
+	Name string `json:"name"`
+}
+
 type NetworkAndAZConfiguration struct {
 	NetworkAZ json.RawMessage `json:"network_and_az,omitempty"`
 }
@@ -26,6 +33,15 @@ type DirectorProperties struct {
 	SyslogConfiguration   json.RawMessage `json:"syslog_configuration,omitempty"`
 }
 
+// IAASConfiguration is one of the named IaaS configurations of a director
+// that deploys to several IaaSes, such as several vCenters. Properties holds
+// the whole configuration as returned by the api, including its guid and name.
+type IAASConfiguration struct {
+	GUID       string          `json:"guid"`
+	Name       string          `json:"name"`
+	Properties json.RawMessage `json:"-"`
+}
+
 func NewDirectorService(client httpClient) DirectorService {
 	return DirectorService{
 		client: client,
@@ -41,6 +57,33 @@ func (d DirectorService) AZConfiguration(input AZConfiguration) error {
 	return d.sendAPIRequest("PUT", "/api/v0/staged/director/availability_zones", jsonData)
 }
 
+func (d DirectorService) AvailabilityZones() ([]AvailabilityZone, error) {
+	req, err := http.NewRequest("GET", "/api/v0/staged/director/availability_zones", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not make api request to availability zones endpoint: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if err = ValidateStatusOK(resp); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		AvailabilityZones []AvailabilityZone `json:"availability_zones"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal availability zones response: %s", err)
+	}
+
+	return response.AvailabilityZones, nil
+}
+
 func (d DirectorService) NetworksConfiguration(input json.RawMessage) error {
 	jsonData, err := json.Marshal(&input)
 	if err != nil {
@@ -68,6 +111,67 @@ func (d DirectorService) Properties(input DirectorProperties) error {
 	return d.sendAPIRequest("PUT", "/api/v0/staged/director/properties", jsonData)
 }
 
+func (d DirectorService) IAASConfigurations() ([]IAASConfiguration, error) {
+	req, err := http.NewRequest("GET", iaasConfigurationsEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not make api request to iaas configurations endpoint: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if err = ValidateStatusOK(resp); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		IAASConfigurations []json.RawMessage `json:"iaas_configurations"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal iaas configurations response: %s", err)
+	}
+
+	var configurations []IAASConfiguration
+	for _, properties := range response.IAASConfigurations {
+		var configuration IAASConfiguration
+		err = json.Unmarshal(properties, &configuration)
+		if err != nil {
+			return nil, fmt.Errorf("could not unmarshal iaas configurations response: %s", err)
+		}
+
+		configuration.Properties = properties
+		configurations = append(configurations, configuration)
+	}
+
+	return configurations, nil
+}
+
+func (d DirectorService) CreateIAASConfiguration(properties json.RawMessage) error {
+	jsonData, err := json.Marshal(map[string]json.RawMessage{"iaas_configuration": properties})
+	if err != nil {
+		return fmt.Errorf("could not marshal json: %s", err)
+	}
+
+	return d.sendAPIRequest("POST", iaasConfigurationsEndpoint, jsonData)
+}
+
+func (d DirectorService) UpdateIAASConfiguration(guid string, properties json.RawMessage) error {
+	jsonData, err := json.Marshal(map[string]json.RawMessage{"iaas_configuration": properties})
+	if err != nil {
+		return fmt.Errorf("could not marshal json: %s", err)
+	}
+
+	return d.sendAPIRequest("PUT", fmt.Sprintf("%s/%s", iaasConfigurationsEndpoint, guid), jsonData)
+}
+
+func (d DirectorService) DeleteIAASConfiguration(guid string) error {
+	return d.sendAPIRequest("DELETE", fmt.Sprintf("%s/%s", iaasConfigurationsEndpoint, guid), nil)
+}
+
 func (d DirectorService) sendAPIRequest(verb, endpoint string, jsonData []byte) error {
 	req, err := http.NewRequest(verb, endpoint, bytes.NewReader(jsonData))
 	if err != nil {
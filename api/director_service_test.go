@@ -73,6 +73,56 @@ var _ = Describe("DirectorService", func() {
 		})
 	})
 
+	Describe("AvailabilityZones", func() {
+		It("lists the staged availability zones", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(strings.NewReader(`{"availability_zones": [
+					{"guid": "some-guid", "name": "az-1", "cluster": "cluster-1"},
+					{"guid": "other-guid", "name": "az-2"}
+				]}`))}, nil)
+
+			availabilityZones, err := directorService.AvailabilityZones()
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/director/availability_zones"))
+
+			Expect(availabilityZones).To(Equal([]api.AvailabilityZone{
+				{GUID: "some-guid", Name: "az-1"},
+				{GUID: "other-guid", Name: "az-2"},
+			}))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the http status is non-200", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusTeapot,
+					Body:       ioutil.NopCloser(strings.NewReader(`{}`))}, nil)
+
+				_, err := directorService.AvailabilityZones()
+				Expect(err).To(MatchError(ContainSubstring("418 I'm a teapot")))
+			})
+
+			It("returns an error when the api endpoint fails", func() {
+				client.DoReturns(nil, errors.New("api endpoint failed"))
+
+				_, err := directorService.AvailabilityZones()
+				Expect(err).To(MatchError("could not make api request to availability zones endpoint: api endpoint failed"))
+			})
+
+			It("returns an error when the response cannot be unmarshalled", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader(`%%%`))}, nil)
+
+				_, err := directorService.AvailabilityZones()
+				Expect(err).To(MatchError(ContainSubstring("could not unmarshal availability zones response:")))
+			})
+		})
+	})
+
 	Describe("NetworksConfiguration", func() {
 		It("configures networks", func() {
 			err := directorService.NetworksConfiguration(json.RawMessage(`{"networks": [{"network_property": "yup"}]}`))
@@ -244,4 +294,125 @@ var _ = Describe("DirectorService", func() {
 			})
 		})
 	})
+
+	Describe("IAASConfigurations", func() {
+		It("lists the iaas configurations", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(strings.NewReader(`{"iaas_configurations": [
+					{"guid": "some-guid", "name": "default", "vcenter_host": "vcenter-1.example.com"},
+					{"guid": "other-guid", "name": "other", "vcenter_host": "vcenter-2.example.com"}
+				]}`))}, nil)
+
+			configurations, err := directorService.IAASConfigurations()
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/director/iaas_configurations"))
+
+			Expect(configurations).To(HaveLen(2))
+			Expect(configurations[0].GUID).To(Equal("some-guid"))
+			Expect(configurations[0].Name).To(Equal("default"))
+			Expect(configurations[0].Properties).To(MatchJSON(`{"guid": "some-guid", "name": "default", "vcenter_host": "vcenter-1.example.com"}`))
+			Expect(configurations[1].GUID).To(Equal("other-guid"))
+			Expect(configurations[1].Name).To(Equal("other"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the http status is non-200", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusTeapot,
+					Body:       ioutil.NopCloser(strings.NewReader(`{}`))}, nil)
+
+				_, err := directorService.IAASConfigurations()
+				Expect(err).To(MatchError(ContainSubstring("418 I'm a teapot")))
+			})
+
+			It("returns an error when the api endpoint fails", func() {
+				client.DoReturns(nil, errors.New("api endpoint failed"))
+
+				_, err := directorService.IAASConfigurations()
+				Expect(err).To(MatchError("could not make api request to iaas configurations endpoint: api endpoint failed"))
+			})
+
+			It("returns an error when the response cannot be unmarshalled", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader(`%%%`))}, nil)
+
+				_, err := directorService.IAASConfigurations()
+				Expect(err).To(MatchError(ContainSubstring("could not unmarshal iaas configurations response:")))
+			})
+		})
+	})
+
+	Describe("CreateIAASConfiguration", func() {
+		It("creates an iaas configuration", func() {
+			err := directorService.CreateIAASConfiguration(json.RawMessage(`{"name": "default", "vcenter_host": "vcenter.example.com"}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("POST"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/director/iaas_configurations"))
+			Expect(req.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			jsonBody, err := ioutil.ReadAll(req.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jsonBody).To(MatchJSON(`{
+				"iaas_configuration": {"name": "default", "vcenter_host": "vcenter.example.com"}
+			}`))
+		})
+
+		It("returns an error when the api endpoint fails", func() {
+			client.DoReturns(nil, errors.New("api endpoint failed"))
+
+			err := directorService.CreateIAASConfiguration(json.RawMessage(`{}`))
+			Expect(err).To(MatchError("could not send api request to POST /api/v0/staged/director/iaas_configurations: api endpoint failed"))
+		})
+	})
+
+	Describe("UpdateIAASConfiguration", func() {
+		It("updates the iaas configuration", func() {
+			err := directorService.UpdateIAASConfiguration("some-guid", json.RawMessage(`{"name": "default", "vcenter_host": "vcenter.example.com"}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("PUT"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/director/iaas_configurations/some-guid"))
+
+			jsonBody, err := ioutil.ReadAll(req.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jsonBody).To(MatchJSON(`{
+				"iaas_configuration": {"name": "default", "vcenter_host": "vcenter.example.com"}
+			}`))
+		})
+
+		It("returns an error when the http status is non-200", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusTeapot,
+				Body:       ioutil.NopCloser(strings.NewReader(`{}`))}, nil)
+
+			err := directorService.UpdateIAASConfiguration("some-guid", json.RawMessage(`{}`))
+			Expect(err).To(MatchError(ContainSubstring("418 I'm a teapot")))
+		})
+	})
+
+	Describe("DeleteIAASConfiguration", func() {
+		It("deletes the iaas configuration", func() {
+			err := directorService.DeleteIAASConfiguration("some-guid")
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("DELETE"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/director/iaas_configurations/some-guid"))
+		})
+
+		It("returns an error when the api endpoint fails", func() {
+			client.DoReturns(nil, errors.New("api endpoint failed"))
+
+			err := directorService.DeleteIAASConfiguration("some-guid")
+			Expect(err).To(MatchError("could not send api request to DELETE /api/v0/staged/director/iaas_configurations/some-guid: api endpoint failed"))
+		})
+	})
 })
@@ -29,19 +29,20 @@ func NewErrandsService(client httpClient) ErrandsService {
 }
 
 func (es ErrandsService) SetState(productID string, errandName string, postDeployState interface{}, preDeleteState interface{}) error {
-	path := fmt.Sprintf("/api/v0/staged/products/%s/errands", productID)
-
-	errandsListOutput := ErrandsListOutput{
-		Errands: []Errand{
-			Errand{
-				Name:       errandName,
-				PostDeploy: postDeployState,
-				PreDelete:  preDeleteState,
-			},
+	return es.SetStates(productID, []Errand{
+		Errand{
+			Name:       errandName,
+			PostDeploy: postDeployState,
+			PreDelete:  preDeleteState,
 		},
-	}
+	})
+}
+
+// SetStates sets the state of several errands of a product in one request.
+func (es ErrandsService) SetStates(productID string, errands []Errand) error {
+	path := fmt.Sprintf("/api/v0/staged/products/%s/errands", productID)
 
-	payload, err := json.Marshal(errandsListOutput)
+	payload, err := json.Marshal(ErrandsListOutput{Errands: errands})
 	if err != nil {
 		return err // not tested
 	}
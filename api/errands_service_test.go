@@ -114,6 +114,45 @@ var _ = Describe("ErrandsService", func() {
 		})
 	})
 
+	Describe("SetStates", func() {
+		It("sets the state of several errands in one request", func() {
+			client.DoReturns(&http.Response{StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(strings.NewReader("{}")),
+			}, nil)
+
+			err := service.SetStates("some-product-id", []api.Errand{
+				{Name: "smoke-tests", PostDeploy: "when-changed"},
+				{Name: "delete-all-apps", PreDelete: false},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(client.DoCallCount()).To(Equal(1))
+			req := client.DoArgsForCall(0)
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/products/some-product-id/errands"))
+			Expect(req.Method).To(Equal("PUT"))
+
+			bodyBytes, err := ioutil.ReadAll(req.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(bodyBytes)).To(MatchJSON(`{
+				"errands": [
+					{"name": "smoke-tests", "post_deploy": "when-changed"},
+					{"name": "delete-all-apps", "pre_delete": false}
+				]
+			}`))
+		})
+
+		Context("when ops manager returns a not-OK response code", func() {
+			It("returns an error", func() {
+				client.DoReturns(&http.Response{StatusCode: http.StatusTeapot,
+					Body: ioutil.NopCloser(strings.NewReader("I'm a teapot")),
+				}, nil)
+
+				err := service.SetStates("some-product-id", []api.Errand{{Name: "smoke-tests", PostDeploy: true}})
+				Expect(err).To(MatchError("failed to set errand state: 418 I'm a teapot"))
+			})
+		})
+	})
+
 	Describe("List", func() {
 		It("lists errands for a product", func() {
 			var path string
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"reflect"
+	"strings"
 )
 
 type JobsService struct {
@@ -21,6 +23,78 @@ type JobProperties struct {
 	NSXSecurityGroups []string     `json:"nsx_security_groups,omitempty"`
 	NSXLBS            []NSXLB      `json:"nsx_lbs,omitempty"`
 	FloatingIPs       string       `json:"floating_ips,omitempty"`
+
+	// Extra holds the fields of the resource config that have no typed field
+	// above, such as additional_vm_extensions, so that they survive a round
+	// trip through GetExistingJobConfig and ConfigureJob.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// jobProperties has the fields of JobProperties without its JSON methods.
+type jobProperties JobProperties
+
+var jobPropertiesFields = jsonFieldNames(reflect.TypeOf(jobProperties{}))
+
+func (j JobProperties) MarshalJSON() ([]byte, error) {
+	known, err := json.Marshal(jobProperties(j))
+	if err != nil || len(j.Extra) == 0 {
+		return known, err
+	}
+
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(known, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range j.Extra {
+		if !jobPropertiesFields[key] {
+			fields[key] = value
+		}
+	}
+
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON decodes onto the existing values, so that a partial resource
+// config can be merged into the one returned by GetExistingJobConfig.
+func (j *JobProperties) UnmarshalJSON(data []byte) error {
+	err := json.Unmarshal(data, (*jobProperties)(j))
+	if err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range fields {
+		if jobPropertiesFields[key] {
+			continue
+		}
+
+		if j.Extra == nil {
+			j.Extra = map[string]json.RawMessage{}
+		}
+		j.Extra[key] = value
+	}
+
+	return nil
+}
+
+// IsZero reports whether the resource config has neither typed fields nor
+// fields in Extra set.
+func (j JobProperties) IsZero() bool {
+	if len(j.Extra) > 0 {
+		return false
+	}
+
+	typed := j
+	typed.Extra = nil
+
+	return reflect.DeepEqual(typed, JobProperties{})
 }
 
 type NSXLB struct {
@@ -141,3 +215,25 @@ func (j JobsService) ConfigureJob(productGUID, jobGUID string, jobProperties Job
 
 	return nil
 }
+
+// jsonFieldNames returns the json names of the fields of a struct type,
+// including the fields of the structs embedded in it, as encoding/json
+// promotes them.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+
+		switch {
+		case field.Anonymous && name == "":
+			for embedded := range jsonFieldNames(field.Type) {
+				names[embedded] = true
+			}
+		case name != "" && name != "-":
+			names[name] = true
+		}
+	}
+
+	return names
+}
@@ -1,6 +1,7 @@
 package api_test
 
 import (
+	"encoding/json"
 	"errors"
 	"io/ioutil"
 	"net/http"
@@ -149,6 +150,33 @@ var _ = Describe("JobsService", func() {
 			})
 		})
 
+		Context("with fields that have no typed field", func() {
+			It("keeps them in Extra", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(strings.NewReader(`{
+						"instances": 1,
+						"instance_type": { "id": "number-1" },
+						"additional_vm_extensions": ["some-extension"],
+						"swap_as_percent_of_memory_size": 50
+					}`)),
+				}, nil)
+
+				service := api.NewJobsService(client)
+				job, err := service.GetExistingJobConfig("some-product-guid", "some-guid")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(job).To(Equal(api.JobProperties{
+					Instances:    float64(1),
+					InstanceType: api.InstanceType{ID: "number-1"},
+					Extra: map[string]json.RawMessage{
+						"additional_vm_extensions":       json.RawMessage(`["some-extension"]`),
+						"swap_as_percent_of_memory_size": json.RawMessage(`50`),
+					},
+				}))
+			})
+		})
+
 		Context("with nsx", func() {
 			It("fetches the resource config for a given job including nsx properties", func() {
 				client.DoReturns(&http.Response{
@@ -256,6 +284,27 @@ var _ = Describe("JobsService", func() {
 		})
 	})
 
+	Describe("JobProperties.IsZero", func() {
+		It("is true when nothing is set", func() {
+			Expect(api.JobProperties{}.IsZero()).To(BeTrue())
+			Expect(api.JobProperties{Extra: map[string]json.RawMessage{}}.IsZero()).To(BeTrue())
+		})
+
+		It("is false when a typed field is set", func() {
+			Expect(api.JobProperties{Instances: 1}.IsZero()).To(BeFalse())
+		})
+
+		It("is false when only Extra is set", func() {
+			properties := api.JobProperties{
+				Extra: map[string]json.RawMessage{
+					"additional_vm_extensions": json.RawMessage(`["some-extension"]`),
+				},
+			}
+
+			Expect(properties.IsZero()).To(BeFalse())
+		})
+	})
+
 	Describe("ConfigureJob", func() {
 		It("configures job resources", func() {
 			client.DoReturns(&http.Response{
@@ -293,6 +342,35 @@ var _ = Describe("JobsService", func() {
 			}`))
 		})
 
+		It("sends the fields in Extra along with the typed fields", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+			}, nil)
+
+			service := api.NewJobsService(client)
+
+			err := service.ConfigureJob("some-product-guid", "some-job-guid", api.JobProperties{
+				Instances:    1,
+				InstanceType: api.InstanceType{ID: "number-1"},
+				Extra: map[string]json.RawMessage{
+					"additional_vm_extensions":       json.RawMessage(`["some-extension"]`),
+					"swap_as_percent_of_memory_size": json.RawMessage(`50`),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			reqBytes, err := ioutil.ReadAll(client.DoArgsForCall(0).Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reqBytes).To(MatchJSON(`{
+				"instances": 1,
+				"instance_type": { "id": "number-1" },
+				"elb_names": null,
+				"additional_vm_extensions": ["some-extension"],
+				"swap_as_percent_of_memory_size": 50
+			}`))
+		})
+
 		Context("when internet_connected property is false", func() {
 			It("passes the value to the flag in the JSON request", func() {
 				client.DoReturns(&http.Response{
@@ -7,16 +7,35 @@ import (
 	"net/http"
 )
 
-const pendingChangesEndpoint = "/api/v0/staged/pending_changes"
+const (
+	pendingChangesEndpoint = "/api/v0/staged/pending_changes"
+	stagedEndpoint         = "/api/v0/staged"
+)
 
 type PendingChangesOutput struct {
 	ChangeList []ProductChange `json:"product_changes"`
 }
 
 type ProductChange struct {
-	Product string   `json:"guid"`
-	Errands []Errand `json:"errands"`
-	Action  string   `json:"action"`
+	Product            string              `json:"guid"`
+	Errands            []Errand            `json:"errands"`
+	Action             string              `json:"action"`
+	CompletenessChecks *CompletenessChecks `json:"completeness_checks,omitempty"`
+}
+
+// CompletenessChecks is only reported by Ops Manager 2.2 and later.
+type CompletenessChecks struct {
+	ConfigurationComplete       bool `json:"configuration_complete"`
+	StemcellPresent             bool `json:"stemcell_present"`
+	ConfigurablePropertiesValid bool `json:"configurable_properties_valid"`
+}
+
+// RevertUnsupported is returned by Revert when the Ops Manager is too old to
+// revert staged changes through the API.
+type RevertUnsupported struct{}
+
+func (ru RevertUnsupported) Error() string {
+	return "reverting staged changes is not supported by the api of this Ops Manager"
 }
 
 type PendingChangesService struct {
@@ -58,3 +77,31 @@ func (pc PendingChangesService) List() (PendingChangesOutput, error) {
 
 	return pendingChanges, nil
 }
+
+// Revert discards every staged change. It reports false when there were no
+// staged changes to discard.
+func (pc PendingChangesService) Revert() (bool, error) {
+	req, err := http.NewRequest("DELETE", stagedEndpoint, nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := pc.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("could not make api request to staged endpoint: %s", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed:
+		return false, RevertUnsupported{}
+	case http.StatusNotModified:
+		return false, nil
+	}
+
+	if err = ValidateStatusOK(resp); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -45,7 +45,12 @@ var _ = Describe("PendingChangesService", func() {
 							"errands":[
 								{ "name":"errand-3", "post_deploy":"true" }
 							],
-							"action":"update"
+							"action":"update",
+							"completeness_checks": {
+								"configuration_complete": true,
+								"stemcell_present": false,
+								"configurable_properties_valid": true
+							}
 						}]
 				  }`)),
 				}, nil
@@ -68,6 +73,11 @@ var _ = Describe("PendingChangesService", func() {
 						{Name: "errand-3", PostDeploy: "true"},
 					},
 					Action: "update",
+					CompletenessChecks: &api.CompletenessChecks{
+						ConfigurationComplete:       true,
+						StemcellPresent:             false,
+						ConfigurablePropertiesValid: true,
+					},
 				},
 			},
 			))
@@ -109,4 +119,67 @@ var _ = Describe("PendingChangesService", func() {
 			})
 		})
 	})
+	Describe("Revert", func() {
+		It("reverts the staged changes", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+			}, nil)
+
+			reverted, err := service.Revert()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reverted).To(BeTrue())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("DELETE"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged"))
+		})
+
+		Context("when there are no staged changes", func() {
+			It("reports that nothing was reverted", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusNotModified,
+					Body:       ioutil.NopCloser(strings.NewReader(``)),
+				}, nil)
+
+				reverted, err := service.Revert()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reverted).To(BeFalse())
+			})
+		})
+
+		Describe("errors", func() {
+			Context("when the Ops Manager does not support reverting through the api", func() {
+				It("returns a RevertUnsupported error", func() {
+					client.DoReturns(&http.Response{
+						StatusCode: http.StatusNotFound,
+						Body:       ioutil.NopCloser(strings.NewReader(``)),
+					}, nil)
+
+					_, err := service.Revert()
+					Expect(err).To(BeAssignableToTypeOf(api.RevertUnsupported{}))
+				})
+			})
+
+			Context("the client can't connect to the server", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{}, errors.New("some error"))
+					_, err := service.Revert()
+					Expect(err).To(MatchError(ContainSubstring("could not make api request to staged endpoint")))
+				})
+			})
+
+			Context("when the server won't revert the staged changes", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{
+						StatusCode: http.StatusInternalServerError,
+						Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+					}, nil)
+
+					_, err := service.Revert()
+					Expect(err).To(MatchError(ContainSubstring("request failed")))
+				})
+			})
+		})
+	})
 })
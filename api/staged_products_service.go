@@ -27,6 +27,26 @@ type StagedProduct struct {
 	Type string
 }
 
+type ResponseProperty struct {
+	Value          interface{}              `json:"value"`
+	Type           string                   `json:"type"`
+	Configurable   bool                     `json:"configurable"`
+	IsCredential   bool                     `json:"credential"`
+	Optional       bool                     `json:"optional"`
+	SelectedOption string                   `json:"selected_option,omitempty"`
+	Options        []ResponsePropertyOption `json:"options,omitempty"`
+}
+
+type ResponsePropertyOption struct {
+	Label string      `json:"label"`
+	Value interface{} `json:"value"`
+}
+
+type ProductDependency struct {
+	Type    string `json:"type"`
+	Version string `json:"product_version"`
+}
+
 type UnstageProductInput struct {
 	ProductName string `json:"name"`
 }
@@ -177,6 +197,88 @@ func (p StagedProductsService) StagedProducts() (StagedProductsOutput, error) {
 	}, nil
 }
 
+func (p StagedProductsService) Properties(productGUID string) (map[string]ResponseProperty, error) {
+	var propertiesResponse struct {
+		Properties map[string]ResponseProperty `json:"properties"`
+	}
+
+	err := doJSON(p.client, "GET", fmt.Sprintf("/api/v0/staged/products/%s/properties", productGUID), "staged product properties", nil, &propertiesResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return propertiesResponse.Properties, nil
+}
+
+func (p StagedProductsService) Dependencies(productGUID string) ([]ProductDependency, error) {
+	var dependenciesResponse struct {
+		Dependencies []ProductDependency `json:"dependencies"`
+	}
+
+	err := doJSON(p.client, "GET", fmt.Sprintf("/api/v0/staged/products/%s/dependencies", productGUID), "staged product dependencies", nil, &dependenciesResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return dependenciesResponse.Dependencies, nil
+}
+
+// NetworksAndAZs returns the network and availability zones that are staged
+// for a product, in the same form as they are configured.
+func (p StagedProductsService) NetworksAndAZs(productGUID string) (map[string]interface{}, error) {
+	var response struct {
+		NetworksAndAZs map[string]interface{} `json:"networks_and_azs"`
+	}
+
+	err := doJSON(p.client, "GET", fmt.Sprintf("/api/v0/staged/products/%s/networks_and_azs", productGUID), "staged product networks and azs", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.NetworksAndAZs, nil
+}
+
+func (p StagedProductsService) MaxInFlight(productGUID string) (map[string]interface{}, error) {
+	var response struct {
+		MaxInFlight map[string]interface{} `json:"max_in_flight"`
+	}
+
+	err := doJSON(p.client, "GET", fmt.Sprintf("/api/v0/staged/products/%s/max_in_flight", productGUID), "staged product max in flight", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.MaxInFlight, nil
+}
+
+// ConfigureMaxInFlight sets the max in flight of the jobs of a staged
+// product. The values are keyed by job GUID and are either a number of
+// instances, a percentage such as "20%" or "default".
+func (p StagedProductsService) ConfigureMaxInFlight(productGUID string, maxInFlight map[string]interface{}) error {
+	return doJSON(p.client, "PUT", fmt.Sprintf("/api/v0/staged/products/%s/max_in_flight", productGUID), "staged product max in flight", map[string]interface{}{
+		"max_in_flight": maxInFlight,
+	}, nil)
+}
+
+func (p StagedProductsService) SyslogConfiguration(productGUID string) (map[string]interface{}, error) {
+	var response struct {
+		SyslogConfiguration map[string]interface{} `json:"syslog_configuration"`
+	}
+
+	err := doJSON(p.client, "GET", fmt.Sprintf("/api/v0/staged/products/%s/syslog_configuration", productGUID), "staged product syslog configuration", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.SyslogConfiguration, nil
+}
+
+func (p StagedProductsService) ConfigureSyslog(productGUID string, syslogConfiguration map[string]interface{}) error {
+	return doJSON(p.client, "PUT", fmt.Sprintf("/api/v0/staged/products/%s/syslog_configuration", productGUID), "staged product syslog configuration", map[string]interface{}{
+		"syslog_configuration": syslogConfiguration,
+	}, nil)
+}
+
 func (p StagedProductsService) Configure(input ProductsConfigurationInput) error {
 	reqList, err := createConfigureRequests(input)
 	if err != nil {
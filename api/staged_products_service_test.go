@@ -511,6 +511,442 @@ var _ = Describe("StagedProductsService", func() {
 		})
 	})
 
+	Describe("Properties", func() {
+		var (
+			client *fakes.HttpClient
+		)
+
+		BeforeEach(func() {
+			client = &fakes.HttpClient{}
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(bytes.NewBufferString(`{
+					"properties": {
+						".properties.some-string": {
+							"type": "string",
+							"configurable": true,
+							"credential": false,
+							"value": "some-value",
+							"optional": false
+						},
+						".properties.some-selector": {
+							"type": "selector",
+							"configurable": true,
+							"credential": false,
+							"value": "Internal",
+							"optional": false,
+							"selected_option": "internal"
+						},
+						".properties.some-dropdown": {
+							"type": "dropdown_select",
+							"configurable": true,
+							"credential": false,
+							"value": null,
+							"optional": true,
+							"options": [{"label": "Small", "value": "small"}]
+						}
+					}
+				}`)),
+			}, nil)
+		})
+
+		It("retrieves the property schema of the staged product", func() {
+			service := api.NewStagedProductsService(client)
+
+			properties, err := service.Properties("some-product-guid")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(properties).To(Equal(map[string]api.ResponseProperty{
+				".properties.some-string": {
+					Type:         "string",
+					Configurable: true,
+					Value:        "some-value",
+				},
+				".properties.some-selector": {
+					Type:           "selector",
+					Configurable:   true,
+					Value:          "Internal",
+					SelectedOption: "internal",
+				},
+				".properties.some-dropdown": {
+					Type:         "dropdown_select",
+					Configurable: true,
+					Optional:     true,
+					Options:      []api.ResponsePropertyOption{{Label: "Small", Value: "small"}},
+				},
+			}))
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/products/some-product-guid/properties"))
+		})
+
+		Context("failure cases", func() {
+			Context("when the request fails", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{}, errors.New("nope"))
+
+					service := api.NewStagedProductsService(client)
+
+					_, err := service.Properties("some-product-guid")
+					Expect(err).To(MatchError("could not make api request to staged product properties endpoint: nope"))
+				})
+			})
+
+			Context("when the server returns a non-200 status code", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{
+						StatusCode: http.StatusTeapot,
+						Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+					}, nil)
+
+					service := api.NewStagedProductsService(client)
+
+					_, err := service.Properties("some-product-guid")
+					Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
+				})
+			})
+
+			Context("when the server returns invalid JSON", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(bytes.NewBufferString("%%")),
+					}, nil)
+
+					service := api.NewStagedProductsService(client)
+
+					_, err := service.Properties("some-product-guid")
+					Expect(err).To(MatchError(ContainSubstring("could not unmarshal staged product properties response:")))
+				})
+			})
+		})
+	})
+
+	Describe("Dependencies", func() {
+		var (
+			client *fakes.HttpClient
+		)
+
+		BeforeEach(func() {
+			client = &fakes.HttpClient{}
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(bytes.NewBufferString(`{
+					"dependencies": [
+						{"type": "cf", "product_version": "~> 2.0"},
+						{"type": "p-mysql", "product_version": ">= 1.9.0"}
+					]
+				}`)),
+			}, nil)
+		})
+
+		It("retrieves the product versions the staged product depends on", func() {
+			service := api.NewStagedProductsService(client)
+
+			dependencies, err := service.Dependencies("some-product-guid")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(dependencies).To(Equal([]api.ProductDependency{
+				{Type: "cf", Version: "~> 2.0"},
+				{Type: "p-mysql", Version: ">= 1.9.0"},
+			}))
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/products/some-product-guid/dependencies"))
+		})
+
+		Context("failure cases", func() {
+			Context("when the request fails", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{}, errors.New("nope"))
+
+					service := api.NewStagedProductsService(client)
+
+					_, err := service.Dependencies("some-product-guid")
+					Expect(err).To(MatchError("could not make api request to staged product dependencies endpoint: nope"))
+				})
+			})
+
+			Context("when the server returns a non-200 status code", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{
+						StatusCode: http.StatusTeapot,
+						Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+					}, nil)
+
+					service := api.NewStagedProductsService(client)
+
+					_, err := service.Dependencies("some-product-guid")
+					Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
+				})
+			})
+
+			Context("when the server returns invalid JSON", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(bytes.NewBufferString("%%")),
+					}, nil)
+
+					service := api.NewStagedProductsService(client)
+
+					_, err := service.Dependencies("some-product-guid")
+					Expect(err).To(MatchError(ContainSubstring("could not unmarshal staged product dependencies response:")))
+				})
+			})
+		})
+	})
+
+	Describe("NetworksAndAZs", func() {
+		It("retrieves the network and availability zones of the staged product", func() {
+			client := &fakes.HttpClient{}
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(bytes.NewBufferString(`{
+					"networks_and_azs": {
+						"singleton_availability_zone": {"name": "az-one"},
+						"network": {"name": "some-network"}
+					}
+				}`)),
+			}, nil)
+
+			service := api.NewStagedProductsService(client)
+
+			networksAndAZs, err := service.NetworksAndAZs("some-product-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(networksAndAZs).To(Equal(map[string]interface{}{
+				"singleton_availability_zone": map[string]interface{}{"name": "az-one"},
+				"network":                     map[string]interface{}{"name": "some-network"},
+			}))
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/products/some-product-guid/networks_and_azs"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the request fails", func() {
+				client := &fakes.HttpClient{}
+				client.DoReturns(&http.Response{}, errors.New("nope"))
+
+				service := api.NewStagedProductsService(client)
+
+				_, err := service.NetworksAndAZs("some-product-guid")
+				Expect(err).To(MatchError("could not make api request to staged product networks and azs endpoint: nope"))
+			})
+
+			It("returns an error when the server returns invalid JSON", func() {
+				client := &fakes.HttpClient{}
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("%%")),
+				}, nil)
+
+				service := api.NewStagedProductsService(client)
+
+				_, err := service.NetworksAndAZs("some-product-guid")
+				Expect(err).To(MatchError(ContainSubstring("could not unmarshal staged product networks and azs response:")))
+			})
+		})
+	})
+
+	Describe("MaxInFlight", func() {
+		It("retrieves the max in flight of the jobs of the staged product", func() {
+			client := &fakes.HttpClient{}
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"max_in_flight": {"some-job-guid": 1, "other-job-guid": "20%"}}`)),
+			}, nil)
+
+			service := api.NewStagedProductsService(client)
+
+			maxInFlight, err := service.MaxInFlight("some-product-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(maxInFlight).To(Equal(map[string]interface{}{
+				"some-job-guid":  float64(1),
+				"other-job-guid": "20%",
+			}))
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/products/some-product-guid/max_in_flight"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the request fails", func() {
+				client := &fakes.HttpClient{}
+				client.DoReturns(&http.Response{}, errors.New("nope"))
+
+				service := api.NewStagedProductsService(client)
+
+				_, err := service.MaxInFlight("some-product-guid")
+				Expect(err).To(MatchError("could not make api request to staged product max in flight endpoint: nope"))
+			})
+
+			It("returns an error when the server returns a non-200 status code", func() {
+				client := &fakes.HttpClient{}
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusTeapot,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+				}, nil)
+
+				service := api.NewStagedProductsService(client)
+
+				_, err := service.MaxInFlight("some-product-guid")
+				Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
+			})
+
+			It("returns an error when the server returns invalid JSON", func() {
+				client := &fakes.HttpClient{}
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("%%")),
+				}, nil)
+
+				service := api.NewStagedProductsService(client)
+
+				_, err := service.MaxInFlight("some-product-guid")
+				Expect(err).To(MatchError(ContainSubstring("could not unmarshal staged product max in flight response:")))
+			})
+		})
+	})
+
+	Describe("ConfigureMaxInFlight", func() {
+		It("sets the max in flight of the jobs of the staged product", func() {
+			client := &fakes.HttpClient{}
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("{}")),
+			}, nil)
+
+			service := api.NewStagedProductsService(client)
+
+			err := service.ConfigureMaxInFlight("some-product-guid", map[string]interface{}{
+				"some-job-guid":  1,
+				"other-job-guid": "20%",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("PUT"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/products/some-product-guid/max_in_flight"))
+			Expect(req.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			body, err := ioutil.ReadAll(req.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"max_in_flight": {"some-job-guid": 1, "other-job-guid": "20%"}}`))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the request fails", func() {
+				client := &fakes.HttpClient{}
+				client.DoReturns(&http.Response{}, errors.New("nope"))
+
+				service := api.NewStagedProductsService(client)
+
+				err := service.ConfigureMaxInFlight("some-product-guid", map[string]interface{}{})
+				Expect(err).To(MatchError("could not make api request to staged product max in flight endpoint: nope"))
+			})
+
+			It("returns an error when the server returns a non-200 status code", func() {
+				client := &fakes.HttpClient{}
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusUnprocessableEntity,
+					Body:       ioutil.NopCloser(bytes.NewBufferString(`{"errors": ["invalid"]}`)),
+				}, nil)
+
+				service := api.NewStagedProductsService(client)
+
+				err := service.ConfigureMaxInFlight("some-product-guid", map[string]interface{}{})
+				Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
+			})
+		})
+	})
+
+	Describe("SyslogConfiguration", func() {
+		It("retrieves the syslog configuration of the staged product", func() {
+			client := &fakes.HttpClient{}
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(bytes.NewBufferString(`{
+					"syslog_configuration": {
+						"enabled": true,
+						"address": "example.com",
+						"port": 514,
+						"transport_protocol": "tcp"
+					}
+				}`)),
+			}, nil)
+
+			service := api.NewStagedProductsService(client)
+
+			syslog, err := service.SyslogConfiguration("some-product-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(syslog).To(Equal(map[string]interface{}{
+				"enabled":            true,
+				"address":            "example.com",
+				"port":               float64(514),
+				"transport_protocol": "tcp",
+			}))
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/products/some-product-guid/syslog_configuration"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the request fails", func() {
+				client := &fakes.HttpClient{}
+				client.DoReturns(&http.Response{}, errors.New("nope"))
+
+				service := api.NewStagedProductsService(client)
+
+				_, err := service.SyslogConfiguration("some-product-guid")
+				Expect(err).To(MatchError("could not make api request to staged product syslog configuration endpoint: nope"))
+			})
+		})
+	})
+
+	Describe("ConfigureSyslog", func() {
+		It("sets the syslog configuration of the staged product", func() {
+			client := &fakes.HttpClient{}
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("{}")),
+			}, nil)
+
+			service := api.NewStagedProductsService(client)
+
+			err := service.ConfigureSyslog("some-product-guid", map[string]interface{}{
+				"enabled": true,
+				"address": "example.com",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("PUT"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/products/some-product-guid/syslog_configuration"))
+
+			body, err := ioutil.ReadAll(req.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"syslog_configuration": {"enabled": true, "address": "example.com"}}`))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the request fails", func() {
+				client := &fakes.HttpClient{}
+				client.DoReturns(&http.Response{}, errors.New("nope"))
+
+				service := api.NewStagedProductsService(client)
+
+				err := service.ConfigureSyslog("some-product-guid", map[string]interface{}{})
+				Expect(err).To(MatchError("could not make api request to staged product syslog configuration endpoint: nope"))
+			})
+		})
+	})
+
 	Describe("Configure", func() {
 		var (
 			client *fakes.HttpClient
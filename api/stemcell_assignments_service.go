@@ -0,0 +1,108 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+const stemcellAssignmentsEndpoint = "/api/v0/stemcell_assignments"
+
+type StemcellAssignmentsOutput struct {
+	Products        []StemcellAssignment `json:"products"`
+	StemcellLibrary []LibraryStemcell    `json:"stemcell_library"`
+}
+
+// LibraryStemcell is a stemcell that has been uploaded to the Ops Manager.
+type LibraryStemcell struct {
+	Version string `json:"version"`
+	OS      string `json:"os"`
+}
+
+type StemcellAssignment struct {
+	GUID                    string   `json:"guid"`
+	ProductName             string   `json:"identifier"`
+	StagedProductVersion    string   `json:"staged_product_version"`
+	StagedForDeletion       bool     `json:"is_staged_for_deletion"`
+	StagedStemcellVersion   string   `json:"staged_stemcell_version"`
+	RequiredStemcellVersion string   `json:"required_stemcell_version"`
+	RequiredStemcellOS      string   `json:"required_stemcell_os"`
+	AvailableVersions       []string `json:"available_stemcell_versions"`
+}
+
+type AssignStemcellInput struct {
+	Products []StemcellAssignmentInput `json:"products"`
+}
+
+type StemcellAssignmentInput struct {
+	GUID                  string `json:"guid"`
+	StagedStemcellVersion string `json:"staged_stemcell_version"`
+}
+
+type StemcellAssignmentsService struct {
+	client httpClient
+}
+
+func NewStemcellAssignmentsService(client httpClient) StemcellAssignmentsService {
+	return StemcellAssignmentsService{
+		client: client,
+	}
+}
+
+func (s StemcellAssignmentsService) List() (StemcellAssignmentsOutput, error) {
+	req, err := http.NewRequest("GET", stemcellAssignmentsEndpoint, nil)
+	if err != nil {
+		return StemcellAssignmentsOutput{}, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return StemcellAssignmentsOutput{}, fmt.Errorf("could not make api request to stemcell_assignments endpoint: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if err = ValidateStatusOK(resp); err != nil {
+		return StemcellAssignmentsOutput{}, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return StemcellAssignmentsOutput{}, err
+	}
+
+	var stemcellAssignments StemcellAssignmentsOutput
+	err = json.Unmarshal(respBody, &stemcellAssignments)
+	if err != nil {
+		return StemcellAssignmentsOutput{}, fmt.Errorf("could not unmarshal stemcell_assignments response: %s", err)
+	}
+
+	return stemcellAssignments, nil
+}
+
+func (s StemcellAssignmentsService) Assign(input AssignStemcellInput) error {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return err // cannot be tested
+	}
+
+	req, err := http.NewRequest("PATCH", stemcellAssignmentsEndpoint, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not make api request to stemcell_assignments endpoint: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if err = ValidateStatusOK(resp); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/api/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StemcellAssignmentsService", func() {
+	var (
+		client  *fakes.HttpClient
+		service api.StemcellAssignmentsService
+	)
+
+	BeforeEach(func() {
+		client = &fakes.HttpClient{}
+
+		service = api.NewStemcellAssignmentsService(client)
+	})
+
+	Describe("List", func() {
+		It("lists the stemcell assignments of the staged products", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(strings.NewReader(`{
+					"products": [{
+						"guid": "cf-guid",
+						"identifier": "cf",
+						"label": "Pivotal Application Service",
+						"staged_product_version": "2.1.0",
+						"deployed_product_version": null,
+						"is_staged_for_deletion": false,
+						"staged_stemcell_version": "3541.10",
+						"deployed_stemcell_version": null,
+						"available_stemcell_versions": ["3541.10", "3541.12"],
+						"required_stemcell_version": "3541",
+						"required_stemcell_os": "ubuntu-trusty"
+					}],
+					"stemcell_library": [{
+						"version": "3541.10",
+						"os": "ubuntu-trusty",
+						"infrastructure": "vsphere",
+						"hypervisor": "esxi",
+						"light": false
+					}]
+				}`)),
+			}, nil)
+
+			output, err := service.List()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(output.Products).To(Equal([]api.StemcellAssignment{
+				{
+					GUID:                    "cf-guid",
+					ProductName:             "cf",
+					StagedProductVersion:    "2.1.0",
+					StagedStemcellVersion:   "3541.10",
+					RequiredStemcellVersion: "3541",
+					RequiredStemcellOS:      "ubuntu-trusty",
+					AvailableVersions:       []string{"3541.10", "3541.12"},
+				},
+			}))
+			Expect(output.StemcellLibrary).To(Equal([]api.LibraryStemcell{
+				{Version: "3541.10", OS: "ubuntu-trusty"},
+			}))
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/api/v0/stemcell_assignments"))
+		})
+
+		Context("failure cases", func() {
+			Context("when the request fails", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{}, errors.New("nope"))
+
+					_, err := service.List()
+					Expect(err).To(MatchError("could not make api request to stemcell_assignments endpoint: nope"))
+				})
+			})
+
+			Context("when the server returns a non-200 status code", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{
+						StatusCode: http.StatusNotFound,
+						Body:       ioutil.NopCloser(strings.NewReader("")),
+					}, nil)
+
+					_, err := service.List()
+					Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
+				})
+			})
+
+			Context("when the server returns invalid JSON", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader("%%")),
+					}, nil)
+
+					_, err := service.List()
+					Expect(err).To(MatchError(ContainSubstring("could not unmarshal stemcell_assignments response:")))
+				})
+			})
+		})
+	})
+
+	Describe("Assign", func() {
+		It("assigns a stemcell to a staged product", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader("{}")),
+			}, nil)
+
+			err := service.Assign(api.AssignStemcellInput{
+				Products: []api.StemcellAssignmentInput{
+					{GUID: "cf-guid", StagedStemcellVersion: "3541.12"},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("PATCH"))
+			Expect(req.URL.Path).To(Equal("/api/v0/stemcell_assignments"))
+			Expect(req.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			body, err := ioutil.ReadAll(req.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(`{
+				"products": [{"guid": "cf-guid", "staged_stemcell_version": "3541.12"}]
+			}`))
+		})
+
+		Context("failure cases", func() {
+			Context("when the request fails", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{}, errors.New("nope"))
+
+					err := service.Assign(api.AssignStemcellInput{})
+					Expect(err).To(MatchError("could not make api request to stemcell_assignments endpoint: nope"))
+				})
+			})
+
+			Context("when the server returns a non-200 status code", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{
+						StatusCode: http.StatusUnprocessableEntity,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": ["stemcell not found"]}`)),
+					}, nil)
+
+					err := service.Assign(api.AssignStemcellInput{})
+					Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+)
+
+type Verifier struct {
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
+
+// VerifierResult holds the errors one verifier found in a pre-deploy check.
+// Ignorable errors do not stop changes from being applied.
+type VerifierResult struct {
+	Type      string   `json:"type"`
+	Errors    []string `json:"errors"`
+	Ignorable bool     `json:"ignorable"`
+}
+
+// VerifiersService talks to the install time verifiers of the director and
+// of the staged products. An empty product guid refers to the director.
+type VerifiersService struct {
+	client httpClient
+}
+
+func NewVerifiersService(client httpClient) VerifiersService {
+	return VerifiersService{
+		client: client,
+	}
+}
+
+func (v VerifiersService) Verifiers(productGUID string) ([]Verifier, error) {
+	var response struct {
+		Verifiers []Verifier `json:"verifiers"`
+	}
+
+	err := doJSON(v.client, "GET", fmt.Sprintf("%s/verifiers/install_time", stagedPath(productGUID)), "verifiers", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Verifiers, nil
+}
+
+func (v VerifiersService) SetVerifierEnabled(productGUID, verifierType string, enabled bool) error {
+	path := fmt.Sprintf("%s/verifiers/install_time/%s", stagedPath(productGUID), url.PathEscape(verifierType))
+	return doJSON(v.client, "PUT", path, "verifiers", map[string]bool{"enabled": enabled}, nil)
+}
+
+// RunVerifiers runs the pre-deploy check, which runs the enabled verifiers
+// against the staged configuration. Only the verifiers that found errors are
+// returned.
+func (v VerifiersService) RunVerifiers(productGUID string) ([]VerifierResult, error) {
+	var response struct {
+		PreDeployCheck struct {
+			Verifiers []VerifierResult `json:"verifiers"`
+		} `json:"pre_deploy_check"`
+	}
+
+	err := doJSON(v.client, "GET", fmt.Sprintf("%s/pre_deploy_check", stagedPath(productGUID)), "pre deploy check", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.PreDeployCheck.Verifiers, nil
+}
+
+func stagedPath(productGUID string) string {
+	if productGUID == "" {
+		return "/api/v0/staged/director"
+	}
+
+	return fmt.Sprintf("/api/v0/staged/products/%s", productGUID)
+}
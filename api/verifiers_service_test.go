@@ -0,0 +1,172 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/api/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VerifiersService", func() {
+	var (
+		client  *fakes.HttpClient
+		service api.VerifiersService
+	)
+
+	BeforeEach(func() {
+		client = &fakes.HttpClient{}
+		client.DoReturns(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader("{}")),
+		}, nil)
+
+		service = api.NewVerifiersService(client)
+	})
+
+	Describe("Verifiers", func() {
+		It("lists the verifiers of the director", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(strings.NewReader(`{
+					"verifiers": [
+						{"type": "IaasConfigurationVerifier", "enabled": true},
+						{"type": "NetworksPingableVerifier", "enabled": false}
+					]
+				}`)),
+			}, nil)
+
+			verifiers, err := service.Verifiers("")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(verifiers).To(Equal([]api.Verifier{
+				{Type: "IaasConfigurationVerifier", Enabled: true},
+				{Type: "NetworksPingableVerifier", Enabled: false},
+			}))
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/director/verifiers/install_time"))
+		})
+
+		It("lists the verifiers of a product", func() {
+			_, err := service.Verifiers("some-product-guid")
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/products/some-product-guid/verifiers/install_time"))
+		})
+
+		Context("failure cases", func() {
+			Context("when the request fails", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{}, errors.New("nope"))
+
+					_, err := service.Verifiers("")
+					Expect(err).To(MatchError("could not make api request to verifiers endpoint: nope"))
+				})
+			})
+
+			Context("when the server returns a non-200 status code", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{
+						StatusCode: http.StatusTeapot,
+						Body:       ioutil.NopCloser(strings.NewReader("")),
+					}, nil)
+
+					_, err := service.Verifiers("")
+					Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
+				})
+			})
+
+			Context("when the server returns invalid JSON", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader("%%%")),
+					}, nil)
+
+					_, err := service.Verifiers("")
+					Expect(err).To(MatchError(ContainSubstring("could not unmarshal verifiers response")))
+				})
+			})
+		})
+	})
+
+	Describe("SetVerifierEnabled", func() {
+		It("enables or disables the verifier", func() {
+			err := service.SetVerifierEnabled("some-product-guid", "WildcardDomainVerifier", false)
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("PUT"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/products/some-product-guid/verifiers/install_time/WildcardDomainVerifier"))
+			Expect(req.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			body, err := ioutil.ReadAll(req.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"enabled": false}`))
+		})
+
+		Context("when the request fails", func() {
+			It("returns an error", func() {
+				client.DoReturns(&http.Response{}, errors.New("nope"))
+
+				err := service.SetVerifierEnabled("", "IaasConfigurationVerifier", true)
+				Expect(err).To(MatchError("could not make api request to verifiers endpoint: nope"))
+			})
+		})
+	})
+
+	Describe("RunVerifiers", func() {
+		It("returns the verifiers that found errors in the pre-deploy check", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(strings.NewReader(`{
+					"pre_deploy_check": {
+						"identifier": "p-bosh-guid",
+						"complete": false,
+						"verifiers": [
+							{"type": "IaasConfigurationVerifier", "errors": ["could not connect to vcenter"], "ignorable": false},
+							{"type": "NetworksPingableVerifier", "errors": ["gateway 10.0.0.1 is not pingable"], "ignorable": true}
+						]
+					}
+				}`)),
+			}, nil)
+
+			results, err := service.RunVerifiers("")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(results).To(Equal([]api.VerifierResult{
+				{Type: "IaasConfigurationVerifier", Errors: []string{"could not connect to vcenter"}},
+				{Type: "NetworksPingableVerifier", Errors: []string{"gateway 10.0.0.1 is not pingable"}, Ignorable: true},
+			}))
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/director/pre_deploy_check"))
+		})
+
+		It("runs the pre-deploy check of a product", func() {
+			_, err := service.RunVerifiers("some-product-guid")
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/products/some-product-guid/pre_deploy_check"))
+		})
+
+		Context("when the request fails", func() {
+			It("returns an error", func() {
+				client.DoReturns(&http.Response{}, errors.New("nope"))
+
+				_, err := service.RunVerifiers("")
+				Expect(err).To(MatchError("could not make api request to pre deploy check endpoint: nope"))
+			})
+		})
+	})
+})
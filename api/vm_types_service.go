@@ -0,0 +1,84 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+const (
+	vmTypesEndpoint      = "/api/v0/vm_types"
+	vmExtensionsEndpoint = "/api/v0/staged/vm_extensions"
+)
+
+type VMType struct {
+	Name          string `json:"name"`
+	CPU           int    `json:"cpu"`
+	RAM           int    `json:"ram"`
+	EphemeralDisk int    `json:"ephemeral_disk"`
+	BuiltIn       bool   `json:"builtin,omitempty"`
+}
+
+type VMExtension struct {
+	Name            string          `json:"name"`
+	CloudProperties json.RawMessage `json:"cloud_properties"`
+}
+
+type VMTypesService struct {
+	client httpClient
+}
+
+func NewVMTypesService(client httpClient) VMTypesService {
+	return VMTypesService{
+		client: client,
+	}
+}
+
+func (v VMTypesService) VMTypes() ([]VMType, error) {
+	var response struct {
+		VMTypes []VMType `json:"vm_types"`
+	}
+
+	err := doJSON(v.client, "GET", vmTypesEndpoint, "vm_types", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.VMTypes, nil
+}
+
+// ConfigureVMTypes replaces the whole list of VM types with the ones given.
+// The endpoint removes every VM type that is not in the list, built-in ones
+// included, so the built-in VM types to keep have to be given as well.
+func (v VMTypesService) ConfigureVMTypes(vmTypes []VMType) error {
+	if vmTypes == nil {
+		vmTypes = []VMType{}
+	}
+
+	return doJSON(v.client, "PUT", vmTypesEndpoint, "vm_types", map[string][]VMType{"vm_types": vmTypes}, nil)
+}
+
+func (v VMTypesService) VMExtensions() ([]VMExtension, error) {
+	var response struct {
+		VMExtensions []VMExtension `json:"vm_extensions"`
+	}
+
+	err := doJSON(v.client, "GET", vmExtensionsEndpoint, "vm_extensions", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.VMExtensions, nil
+}
+
+// CreateVMExtension creates the VM extension, replacing any existing VM
+// extension with the same name.
+func (v VMTypesService) CreateVMExtension(vmExtension VMExtension) error {
+	path := fmt.Sprintf("%s/%s", vmExtensionsEndpoint, url.PathEscape(vmExtension.Name))
+	return doJSON(v.client, "PUT", path, "vm_extensions", vmExtension, nil)
+}
+
+func (v VMTypesService) DeleteVMExtension(name string) error {
+	path := fmt.Sprintf("%s/%s", vmExtensionsEndpoint, url.PathEscape(name))
+	return doJSON(v.client, "DELETE", path, "vm_extensions", nil, nil)
+}
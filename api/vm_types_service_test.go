@@ -0,0 +1,230 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/api/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VMTypesService", func() {
+	var (
+		client  *fakes.HttpClient
+		service api.VMTypesService
+	)
+
+	BeforeEach(func() {
+		client = &fakes.HttpClient{}
+		client.DoReturns(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader("{}")),
+		}, nil)
+
+		service = api.NewVMTypesService(client)
+	})
+
+	Describe("VMTypes", func() {
+		It("lists the vm types", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(strings.NewReader(`{
+					"vm_types": [
+						{"name": "micro", "ram": 1024, "cpu": 1, "ephemeral_disk": 8192, "builtin": true},
+						{"name": "some-custom-type", "ram": 4096, "cpu": 2, "ephemeral_disk": 32768, "builtin": false}
+					]
+				}`)),
+			}, nil)
+
+			vmTypes, err := service.VMTypes()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(vmTypes).To(Equal([]api.VMType{
+				{Name: "micro", RAM: 1024, CPU: 1, EphemeralDisk: 8192, BuiltIn: true},
+				{Name: "some-custom-type", RAM: 4096, CPU: 2, EphemeralDisk: 32768},
+			}))
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/api/v0/vm_types"))
+		})
+
+		Context("failure cases", func() {
+			Context("when the request fails", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{}, errors.New("nope"))
+
+					_, err := service.VMTypes()
+					Expect(err).To(MatchError("could not make api request to vm_types endpoint: nope"))
+				})
+			})
+
+			Context("when the server returns a non-200 status code", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{
+						StatusCode: http.StatusTeapot,
+						Body:       ioutil.NopCloser(strings.NewReader("")),
+					}, nil)
+
+					_, err := service.VMTypes()
+					Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
+				})
+			})
+
+			Context("when the server returns invalid JSON", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader("%%")),
+					}, nil)
+
+					_, err := service.VMTypes()
+					Expect(err).To(MatchError(ContainSubstring("could not unmarshal vm_types response:")))
+				})
+			})
+		})
+	})
+
+	Describe("ConfigureVMTypes", func() {
+		It("replaces the vm types with the ones given", func() {
+			err := service.ConfigureVMTypes([]api.VMType{
+				{Name: "some-custom-type", RAM: 4096, CPU: 2, EphemeralDisk: 32768},
+				{Name: "some-other-type", RAM: 8192, CPU: 4, EphemeralDisk: 65536, BuiltIn: true},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("PUT"))
+			Expect(req.URL.Path).To(Equal("/api/v0/vm_types"))
+			Expect(req.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			body, err := ioutil.ReadAll(req.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(`{
+				"vm_types": [
+					{"name": "some-custom-type", "ram": 4096, "cpu": 2, "ephemeral_disk": 32768},
+					{"name": "some-other-type", "ram": 8192, "cpu": 4, "ephemeral_disk": 65536, "builtin": true}
+				]
+			}`))
+		})
+
+		It("sends an empty list when there are no vm types", func() {
+			err := service.ConfigureVMTypes(nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			body, err := ioutil.ReadAll(client.DoArgsForCall(0).Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"vm_types": []}`))
+		})
+
+		Context("failure cases", func() {
+			Context("when the server returns a non-200 status code", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{
+						StatusCode: http.StatusUnprocessableEntity,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": ["invalid"]}`)),
+					}, nil)
+
+					err := service.ConfigureVMTypes(nil)
+					Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
+				})
+			})
+		})
+	})
+
+	Describe("VMExtensions", func() {
+		It("lists the vm extensions", func() {
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(strings.NewReader(`{
+					"vm_extensions": [
+						{"name": "some-extension", "cloud_properties": {"elbs": ["some-elb"]}}
+					]
+				}`)),
+			}, nil)
+
+			vmExtensions, err := service.VMExtensions()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(vmExtensions).To(HaveLen(1))
+			Expect(vmExtensions[0].Name).To(Equal("some-extension"))
+			Expect(vmExtensions[0].CloudProperties).To(MatchJSON(`{"elbs": ["some-elb"]}`))
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/vm_extensions"))
+		})
+
+		Context("failure cases", func() {
+			Context("when the request fails", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{}, errors.New("nope"))
+
+					_, err := service.VMExtensions()
+					Expect(err).To(MatchError("could not make api request to vm_extensions endpoint: nope"))
+				})
+			})
+		})
+	})
+
+	Describe("CreateVMExtension", func() {
+		It("creates or replaces the vm extension", func() {
+			err := service.CreateVMExtension(api.VMExtension{
+				Name:            "some-extension",
+				CloudProperties: json.RawMessage(`{"elbs": ["some-elb"]}`),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("PUT"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/vm_extensions/some-extension"))
+			Expect(req.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			body, err := ioutil.ReadAll(req.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"name": "some-extension", "cloud_properties": {"elbs": ["some-elb"]}}`))
+		})
+
+		Context("failure cases", func() {
+			Context("when the request fails", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{}, errors.New("nope"))
+
+					err := service.CreateVMExtension(api.VMExtension{Name: "some-extension", CloudProperties: json.RawMessage("{}")})
+					Expect(err).To(MatchError("could not make api request to vm_extensions endpoint: nope"))
+				})
+			})
+		})
+	})
+
+	Describe("DeleteVMExtension", func() {
+		It("deletes the vm extension", func() {
+			err := service.DeleteVMExtension("some-extension")
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("DELETE"))
+			Expect(req.URL.Path).To(Equal("/api/v0/staged/vm_extensions/some-extension"))
+		})
+
+		Context("failure cases", func() {
+			Context("when the server returns a non-200 status code", func() {
+				It("returns an error", func() {
+					client.DoReturns(&http.Response{
+						StatusCode: http.StatusNotFound,
+						Body:       ioutil.NopCloser(strings.NewReader("")),
+					}, nil)
+
+					err := service.DeleteVMExtension("some-extension")
+					Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
+				})
+			})
+		})
+	})
+})
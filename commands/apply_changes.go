@@ -11,16 +11,26 @@ import (
 )
 
 type ApplyChanges struct {
-	installationsService installationsService
-	logger               logger
-	logWriter            logWriter
-	waitDuration         int
-	Options              struct {
-		IgnoreWarnings     bool `short:"i" long:"ignore-warnings" description:"ignore issues reported by Ops Manager when applying changes"`
-		SkipDeployProducts bool `short:"sdp" long:"skip-deploy-products" description:"skip deploying products when applying changes - just update the director"`
+	installationsService  installationsService
+	verifiersService      verifiersService
+	stagedProductsService stagedProductsLister
+	logger                logger
+	logWriter             logWriter
+	waitDuration          int
+	Options               struct {
+		IgnoreWarnings     bool              `short:"i" long:"ignore-warnings" description:"ignore issues reported by Ops Manager when applying changes"`
+		SkipDeployProducts bool              `short:"sdp" long:"skip-deploy-products" description:"skip deploying products when applying changes - just update the director"`
+		IgnoreVerifier     flags.StringSlice `long:"ignore-verifier" description:"type of a verifier to disable while the changes are applied, on the director and every staged product; may be repeated"`
 	}
 }
 
+// disabledVerifier is a verifier that apply-changes disabled, and enables
+// again once the installation is triggered.
+type disabledVerifier struct {
+	productGUID  string
+	verifierType string
+}
+
 //go:generate counterfeiter -o ./fakes/installations_service.go --fake-name InstallationsService . installationsService
 type installationsService interface {
 	Trigger(bool, bool) (api.InstallationsServiceOutput, error)
@@ -35,12 +45,14 @@ type logWriter interface {
 	Flush(logs string) error
 }
 
-func NewApplyChanges(installationsService installationsService, logWriter logWriter, logger logger, waitDuration int) ApplyChanges {
+func NewApplyChanges(installationsService installationsService, verifiersService verifiersService, stagedProductsService stagedProductsLister, logWriter logWriter, logger logger, waitDuration int) ApplyChanges {
 	return ApplyChanges{
-		installationsService: installationsService,
-		logger:               logger,
-		logWriter:            logWriter,
-		waitDuration:         waitDuration,
+		installationsService:  installationsService,
+		verifiersService:      verifiersService,
+		stagedProductsService: stagedProductsService,
+		logger:                logger,
+		logWriter:             logWriter,
+		waitDuration:          waitDuration,
 	}
 }
 
@@ -56,9 +68,15 @@ func (ac ApplyChanges) Execute(args []string) error {
 	}
 
 	if installation == (api.InstallationsServiceOutput{}) {
+		disabled, err := ac.disableVerifiers()
+		if err != nil {
+			return err
+		}
+
 		ac.logger.Printf("attempting to apply changes to the targeted Ops Manager")
 		deployProducts := !ac.Options.SkipDeployProducts
 		installation, err = ac.installationsService.Trigger(ac.Options.IgnoreWarnings, deployProducts)
+		ac.enableVerifiers(disabled)
 		if err != nil {
 			return fmt.Errorf("installation failed to trigger: %s", err)
 		}
@@ -93,6 +111,78 @@ func (ac ApplyChanges) Execute(args []string) error {
 	}
 }
 
+// disableVerifiers disables the enabled verifiers of the ignored types on the
+// director and the staged products. It returns the verifiers it disabled.
+func (ac ApplyChanges) disableVerifiers() ([]disabledVerifier, error) {
+	if len(ac.Options.IgnoreVerifier) == 0 {
+		return nil, nil
+	}
+
+	stagedProducts, err := ac.stagedProductsService.StagedProducts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch staged products: %s", err)
+	}
+
+	productGUIDs := []string{""}
+	for _, product := range stagedProducts.Products {
+		if product.Type != boshProductName {
+			productGUIDs = append(productGUIDs, product.GUID)
+		}
+	}
+
+	var toDisable []disabledVerifier
+	found := map[string]bool{}
+	for _, productGUID := range productGUIDs {
+		verifiers, err := ac.verifiersService.Verifiers(productGUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list verifiers: %s", err)
+		}
+
+		for _, verifier := range verifiers {
+			if !contains(ac.Options.IgnoreVerifier, verifier.Type) {
+				continue
+			}
+
+			found[verifier.Type] = true
+			if verifier.Enabled {
+				toDisable = append(toDisable, disabledVerifier{productGUID: productGUID, verifierType: verifier.Type})
+			}
+		}
+	}
+
+	for _, verifierType := range ac.Options.IgnoreVerifier {
+		if !found[verifierType] {
+			return nil, fmt.Errorf("could not find verifier %s on the director or any staged product", verifierType)
+		}
+	}
+
+	var disabled []disabledVerifier
+	for _, verifier := range toDisable {
+		ac.logger.Printf("disabling verifier %s", verifier.verifierType)
+
+		err = ac.verifiersService.SetVerifierEnabled(verifier.productGUID, verifier.verifierType, false)
+		if err != nil {
+			ac.enableVerifiers(disabled)
+			return nil, fmt.Errorf("failed to disable verifier %s: %s", verifier.verifierType, err)
+		}
+
+		disabled = append(disabled, verifier)
+	}
+
+	return disabled, nil
+}
+
+// enableVerifiers only logs the verifiers it fails to enable again, as the
+// installation may already be running.
+func (ac ApplyChanges) enableVerifiers(disabled []disabledVerifier) {
+	for _, verifier := range disabled {
+		err := ac.verifiersService.SetVerifierEnabled(verifier.productGUID, verifier.verifierType, true)
+		if err != nil {
+			ac.logger.Printf("could not enable verifier %s again: %s", verifier.verifierType, err)
+		}
+	}
+}
+
 func (ac ApplyChanges) Usage() commands.Usage {
 	return commands.Usage{
 		Description:      "This authenticated command kicks off an install of any staged changes on the Ops Manager.",
@@ -28,19 +28,23 @@ func (ne netError) Timeout() bool {
 
 var _ = Describe("ApplyChanges", func() {
 	var (
-		service       *fakes.InstallationsService
-		logger        *fakes.Logger
-		writer        *fakes.LogWriter
-		statusOutputs []api.InstallationsServiceOutput
-		statusErrors  []error
-		logsOutputs   []api.InstallationsServiceOutput
-		logsErrors    []error
-		statusCount   int
-		logsCount     int
+		service               *fakes.InstallationsService
+		verifiersService      *fakes.VerifiersService
+		stagedProductsService *fakes.StagedProductsLister
+		logger                *fakes.Logger
+		writer                *fakes.LogWriter
+		statusOutputs         []api.InstallationsServiceOutput
+		statusErrors          []error
+		logsOutputs           []api.InstallationsServiceOutput
+		logsErrors            []error
+		statusCount           int
+		logsCount             int
 	)
 
 	BeforeEach(func() {
 		service = &fakes.InstallationsService{}
+		verifiersService = &fakes.VerifiersService{}
+		stagedProductsService = &fakes.StagedProductsLister{}
 		logger = &fakes.Logger{}
 		writer = &fakes.LogWriter{}
 
@@ -83,7 +87,7 @@ var _ = Describe("ApplyChanges", func() {
 
 			logsErrors = []error{nil, nil, nil}
 
-			command := commands.NewApplyChanges(service, writer, logger, 1)
+			command := commands.NewApplyChanges(service, verifiersService, stagedProductsService, writer, logger, 1)
 
 			err := command.Execute([]string{})
 			Expect(err).NotTo(HaveOccurred())
@@ -129,7 +133,7 @@ var _ = Describe("ApplyChanges", func() {
 				}
 
 				logsErrors = []error{nil, nil, nil}
-				command := commands.NewApplyChanges(service, writer, logger, 1)
+				command := commands.NewApplyChanges(service, verifiersService, stagedProductsService, writer, logger, 1)
 
 				err := command.Execute([]string{"--ignore-warnings"})
 				Expect(err).NotTo(HaveOccurred())
@@ -159,7 +163,7 @@ var _ = Describe("ApplyChanges", func() {
 				}
 
 				logsErrors = []error{nil, nil, nil}
-				command := commands.NewApplyChanges(service, writer, logger, 1)
+				command := commands.NewApplyChanges(service, verifiersService, stagedProductsService, writer, logger, 1)
 
 				err := command.Execute([]string{"--skip-deploy-products"})
 				Expect(err).NotTo(HaveOccurred())
@@ -169,6 +173,133 @@ var _ = Describe("ApplyChanges", func() {
 			})
 		})
 
+		Context("when passed the ignore-verifier flag", func() {
+			BeforeEach(func() {
+				service.TriggerReturns(api.InstallationsServiceOutput{ID: 311}, nil)
+				service.RunningInstallationReturns(api.InstallationsServiceOutput{}, nil)
+
+				statusOutputs = []api.InstallationsServiceOutput{{Status: "succeeded"}}
+				statusErrors = []error{nil}
+				logsOutputs = []api.InstallationsServiceOutput{{Logs: "some logs"}}
+				logsErrors = []error{nil}
+
+				stagedProductsService.StagedProductsReturns(api.StagedProductsOutput{
+					Products: []api.StagedProduct{
+						{GUID: "p-bosh-guid", Type: "p-bosh"},
+						{GUID: "cf-guid", Type: "cf"},
+					},
+				}, nil)
+
+				verifiersService.VerifiersStub = func(productGUID string) ([]api.Verifier, error) {
+					if productGUID == "" {
+						return []api.Verifier{
+							{Type: "NetworksPingableVerifier", Enabled: true},
+							{Type: "IaasConfigurationVerifier", Enabled: true},
+						}, nil
+					}
+
+					return []api.Verifier{
+						{Type: "WildcardDomainVerifier", Enabled: true},
+						{Type: "NetworksPingableVerifier", Enabled: false},
+					}, nil
+				}
+			})
+
+			It("disables the enabled verifiers of those types until the installation is triggered", func() {
+				var enabledWhenTriggered []bool
+				service.TriggerStub = func(bool, bool) (api.InstallationsServiceOutput, error) {
+					enabledWhenTriggered = append(enabledWhenTriggered, verifiersService.SetVerifierEnabledCallCount() == 2)
+					return api.InstallationsServiceOutput{ID: 311}, nil
+				}
+
+				command := commands.NewApplyChanges(service, verifiersService, stagedProductsService, writer, logger, 1)
+
+				err := command.Execute([]string{
+					"--ignore-verifier", "NetworksPingableVerifier",
+					"--ignore-verifier", "WildcardDomainVerifier",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(verifiersService.VerifiersCallCount()).To(Equal(2))
+				Expect(verifiersService.VerifiersArgsForCall(0)).To(Equal(""))
+				Expect(verifiersService.VerifiersArgsForCall(1)).To(Equal("cf-guid"))
+
+				Expect(enabledWhenTriggered).To(Equal([]bool{true}))
+				Expect(verifiersService.SetVerifierEnabledCallCount()).To(Equal(4))
+
+				productGUID, verifierType, enabled := verifiersService.SetVerifierEnabledArgsForCall(0)
+				Expect([]interface{}{productGUID, verifierType, enabled}).To(Equal([]interface{}{"", "NetworksPingableVerifier", false}))
+
+				productGUID, verifierType, enabled = verifiersService.SetVerifierEnabledArgsForCall(1)
+				Expect([]interface{}{productGUID, verifierType, enabled}).To(Equal([]interface{}{"cf-guid", "WildcardDomainVerifier", false}))
+
+				productGUID, verifierType, enabled = verifiersService.SetVerifierEnabledArgsForCall(2)
+				Expect([]interface{}{productGUID, verifierType, enabled}).To(Equal([]interface{}{"", "NetworksPingableVerifier", true}))
+
+				productGUID, verifierType, enabled = verifiersService.SetVerifierEnabledArgsForCall(3)
+				Expect([]interface{}{productGUID, verifierType, enabled}).To(Equal([]interface{}{"cf-guid", "WildcardDomainVerifier", true}))
+
+				format, content := logger.PrintfArgsForCall(0)
+				Expect(fmt.Sprintf(format, content...)).To(Equal("disabling verifier NetworksPingableVerifier"))
+			})
+
+			It("enables the verifiers again when the installation fails to trigger", func() {
+				service.TriggerReturns(api.InstallationsServiceOutput{}, errors.New("some error"))
+
+				command := commands.NewApplyChanges(service, verifiersService, stagedProductsService, writer, logger, 1)
+
+				err := command.Execute([]string{"--ignore-verifier", "WildcardDomainVerifier"})
+				Expect(err).To(MatchError("installation failed to trigger: some error"))
+
+				Expect(verifiersService.SetVerifierEnabledCallCount()).To(Equal(2))
+				_, _, enabled := verifiersService.SetVerifierEnabledArgsForCall(1)
+				Expect(enabled).To(BeTrue())
+			})
+
+			Context("when no verifier has the type", func() {
+				It("returns an error without applying changes", func() {
+					command := commands.NewApplyChanges(service, verifiersService, stagedProductsService, writer, logger, 1)
+
+					err := command.Execute([]string{"--ignore-verifier", "UnknownVerifier"})
+					Expect(err).To(MatchError("could not find verifier UnknownVerifier on the director or any staged product"))
+
+					Expect(verifiersService.SetVerifierEnabledCallCount()).To(Equal(0))
+					Expect(service.TriggerCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the verifiers cannot be listed", func() {
+				It("returns an error", func() {
+					verifiersService.VerifiersStub = nil
+					verifiersService.VerifiersReturns(nil, errors.New("some error"))
+
+					command := commands.NewApplyChanges(service, verifiersService, stagedProductsService, writer, logger, 1)
+
+					err := command.Execute([]string{"--ignore-verifier", "WildcardDomainVerifier"})
+					Expect(err).To(MatchError("failed to list verifiers: some error"))
+				})
+			})
+
+			Context("when a verifier cannot be disabled", func() {
+				It("enables the ones already disabled and returns an error", func() {
+					verifiersService.SetVerifierEnabledReturnsOnCall(1, errors.New("some error"))
+
+					command := commands.NewApplyChanges(service, verifiersService, stagedProductsService, writer, logger, 1)
+
+					err := command.Execute([]string{
+						"--ignore-verifier", "NetworksPingableVerifier",
+						"--ignore-verifier", "WildcardDomainVerifier",
+					})
+					Expect(err).To(MatchError("failed to disable verifier WildcardDomainVerifier: some error"))
+
+					Expect(verifiersService.SetVerifierEnabledCallCount()).To(Equal(3))
+					productGUID, verifierType, enabled := verifiersService.SetVerifierEnabledArgsForCall(2)
+					Expect([]interface{}{productGUID, verifierType, enabled}).To(Equal([]interface{}{"", "NetworksPingableVerifier", true}))
+					Expect(service.TriggerCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		It("re-attaches to an ongoing installation", func() {
 			installationStartedAt := time.Date(2017, time.February, 25, 02, 31, 1, 0, time.UTC)
 
@@ -194,7 +325,7 @@ var _ = Describe("ApplyChanges", func() {
 
 			logsErrors = []error{nil, nil, nil}
 
-			command := commands.NewApplyChanges(service, writer, logger, 1)
+			command := commands.NewApplyChanges(service, verifiersService, stagedProductsService, writer, logger, 1)
 
 			err := command.Execute([]string{})
 			Expect(err).NotTo(HaveOccurred())
@@ -222,7 +353,7 @@ var _ = Describe("ApplyChanges", func() {
 
 			logsErrors = []error{nil}
 
-			command := commands.NewApplyChanges(service, writer, logger, 1)
+			command := commands.NewApplyChanges(service, verifiersService, stagedProductsService, writer, logger, 1)
 
 			err := command.Execute([]string{})
 			Expect(err).To(MatchError("installation was unsuccessful"))
@@ -233,7 +364,7 @@ var _ = Describe("ApplyChanges", func() {
 				It("returns an error", func() {
 					service.RunningInstallationReturns(api.InstallationsServiceOutput{}, errors.New("some error"))
 
-					command := commands.NewApplyChanges(service, writer, logger, 1)
+					command := commands.NewApplyChanges(service, verifiersService, stagedProductsService, writer, logger, 1)
 
 					err := command.Execute([]string{})
 					Expect(err).To(MatchError("could not check for any already running installation: some error"))
@@ -244,7 +375,7 @@ var _ = Describe("ApplyChanges", func() {
 				It("returns an error", func() {
 					service.TriggerReturns(api.InstallationsServiceOutput{}, errors.New("some error"))
 
-					command := commands.NewApplyChanges(service, writer, logger, 1)
+					command := commands.NewApplyChanges(service, verifiersService, stagedProductsService, writer, logger, 1)
 
 					err := command.Execute([]string{})
 					Expect(err).To(MatchError("installation failed to trigger: some error"))
@@ -259,7 +390,7 @@ var _ = Describe("ApplyChanges", func() {
 
 					statusErrors = []error{errors.New("another error")}
 
-					command := commands.NewApplyChanges(service, writer, logger, 1)
+					command := commands.NewApplyChanges(service, verifiersService, stagedProductsService, writer, logger, 1)
 
 					err := command.Execute([]string{})
 					Expect(err).To(MatchError("installation failed to get status: another error"))
@@ -280,7 +411,7 @@ var _ = Describe("ApplyChanges", func() {
 
 					logsErrors = []error{errors.New("no")}
 
-					command := commands.NewApplyChanges(service, writer, logger, 1)
+					command := commands.NewApplyChanges(service, verifiersService, stagedProductsService, writer, logger, 1)
 
 					err := command.Execute([]string{})
 					Expect(err).To(MatchError("installation failed to get logs: no"))
@@ -303,7 +434,7 @@ var _ = Describe("ApplyChanges", func() {
 
 					writer.FlushReturns(errors.New("yes"))
 
-					command := commands.NewApplyChanges(service, writer, logger, 1)
+					command := commands.NewApplyChanges(service, verifiersService, stagedProductsService, writer, logger, 1)
 
 					err := command.Execute([]string{})
 					Expect(err).To(MatchError("installation failed to flush logs: yes"))
@@ -314,7 +445,7 @@ var _ = Describe("ApplyChanges", func() {
 
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewApplyChanges(nil, nil, nil, 1)
+			command := commands.NewApplyChanges(nil, nil, nil, nil, nil, 1)
 			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
 				Description:      "This authenticated command kicks off an install of any staged changes on the Ops Manager.",
 				ShortDescription: "triggers an install on the Ops Manager targeted",
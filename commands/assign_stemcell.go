@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/jhanda/flags"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/semver"
+)
+
+type AssignStemcell struct {
+	service stemcellAssignmentsService
+	logger  logger
+	Options struct {
+		ProductName string `short:"p" long:"product-name" description:"name of the staged product"`
+		Stemcell    string `short:"s" long:"stemcell" description:"stemcell version to assign, \"latest\" or a version constraint such as \"~> 3541.0\"" default:"latest"`
+	}
+}
+
+func NewAssignStemcell(service stemcellAssignmentsService, logger logger) AssignStemcell {
+	return AssignStemcell{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (as AssignStemcell) Execute(args []string) error {
+	_, err := flags.Parse(&as.Options, args)
+	if err != nil {
+		return fmt.Errorf("could not parse assign-stemcell flags: %s", err)
+	}
+
+	if as.Options.ProductName == "" {
+		return errors.New("error: product-name is missing. Please see usage for more information.")
+	}
+
+	output, err := as.service.List()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve stemcell assignments: %s", err)
+	}
+
+	var assignment *api.StemcellAssignment
+	for i, product := range output.Products {
+		if product.ProductName == as.Options.ProductName {
+			assignment = &output.Products[i]
+			break
+		}
+	}
+
+	if assignment == nil {
+		return fmt.Errorf("could not find staged product %q", as.Options.ProductName)
+	}
+
+	if len(assignment.AvailableVersions) == 0 {
+		return fmt.Errorf("no stemcells are available for %s; upload a %s stemcell matching version %s", as.Options.ProductName, assignment.RequiredStemcellOS, assignment.RequiredStemcellVersion)
+	}
+
+	version := as.Options.Stemcell
+	if !contains(assignment.AvailableVersions, version) {
+		if !isVersionConstraint(version) {
+			return fmt.Errorf("stemcell version %s is not available for %s; available versions: %s", version, as.Options.ProductName, strings.Join(assignment.AvailableVersions, ", "))
+		}
+
+		latest, err := latestStemcellVersion(assignment.AvailableVersions, version)
+		if err != nil {
+			return err
+		}
+
+		if latest == "" {
+			return fmt.Errorf("no stemcell version matches %q for %s; available versions: %s", version, as.Options.ProductName, strings.Join(assignment.AvailableVersions, ", "))
+		}
+
+		version = latest
+	}
+
+	as.logger.Printf("assigning stemcell %s to %s", version, as.Options.ProductName)
+
+	err = as.service.Assign(api.AssignStemcellInput{
+		Products: []api.StemcellAssignmentInput{
+			{GUID: assignment.GUID, StagedStemcellVersion: version},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to assign stemcell: %s", err)
+	}
+
+	as.logger.Printf("finished assigning stemcell")
+
+	return nil
+}
+
+func (as AssignStemcell) Usage() commands.Usage {
+	return commands.Usage{
+		Description:      "This authenticated command assigns an uploaded stemcell to a staged product. Use latest to pick the newest stemcell available to the product.",
+		ShortDescription: "assigns a stemcell to a staged product",
+		Flags:            as.Options,
+	}
+}
+
+// latestStemcellVersion returns the highest of the versions that matches the
+// constraint, or the highest of all the versions when the constraint is
+// latest. Pre-release versions are only matched by a constraint that names a
+// pre-release. It returns an empty string when no version matches.
+func latestStemcellVersion(versions []string, versionConstraint string) (string, error) {
+	var constraint semver.Constraint
+	if versionConstraint != "latest" {
+		var err error
+		constraint, err = semver.NewConstraint(versionConstraint)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var latest *semver.Version
+	for _, available := range versions {
+		version, err := semver.Parse(available)
+		if err != nil {
+			continue
+		}
+
+		if versionConstraint != "latest" && !constraint.Check(version) {
+			continue
+		}
+
+		if len(version.PreRelease) > 0 && (versionConstraint == "latest" || !constraint.NamesPreRelease()) {
+			continue
+		}
+
+		if latest == nil || version.Compare(*latest) > 0 {
+			latest = &version
+		}
+	}
+
+	if latest == nil {
+		return "", nil
+	}
+
+	return latest.String(), nil
+}
@@ -0,0 +1,206 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+
+	jhandacommands "github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AssignStemcell", func() {
+	var (
+		service *fakes.StemcellAssignmentsService
+		logger  *fakes.Logger
+		command commands.AssignStemcell
+	)
+
+	BeforeEach(func() {
+		service = &fakes.StemcellAssignmentsService{}
+		logger = &fakes.Logger{}
+		command = commands.NewAssignStemcell(service, logger)
+
+		service.ListReturns(api.StemcellAssignmentsOutput{
+			Products: []api.StemcellAssignment{
+				{
+					GUID:                    "mysql-guid",
+					ProductName:             "p-mysql",
+					RequiredStemcellOS:      "ubuntu-xenial",
+					RequiredStemcellVersion: "97",
+				},
+				{
+					GUID:                    "cf-guid",
+					ProductName:             "cf",
+					StagedStemcellVersion:   "3541.9",
+					RequiredStemcellOS:      "ubuntu-trusty",
+					RequiredStemcellVersion: "3541",
+					AvailableVersions:       []string{"3541.9", "3541.12", "3541.10"},
+				},
+			},
+		}, nil)
+	})
+
+	It("assigns the latest available stemcell by default", func() {
+		err := command.Execute([]string{"--product-name", "cf"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(service.AssignArgsForCall(0)).To(Equal(api.AssignStemcellInput{
+			Products: []api.StemcellAssignmentInput{
+				{GUID: "cf-guid", StagedStemcellVersion: "3541.12"},
+			},
+		}))
+
+		format, content := logger.PrintfArgsForCall(0)
+		Expect(fmt.Sprintf(format, content...)).To(Equal("assigning stemcell 3541.12 to cf"))
+
+		format, content = logger.PrintfArgsForCall(1)
+		Expect(fmt.Sprintf(format, content...)).To(Equal("finished assigning stemcell"))
+	})
+
+	It("assigns the requested stemcell version", func() {
+		err := command.Execute([]string{"--product-name", "cf", "--stemcell", "3541.10"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(service.AssignArgsForCall(0)).To(Equal(api.AssignStemcellInput{
+			Products: []api.StemcellAssignmentInput{
+				{GUID: "cf-guid", StagedStemcellVersion: "3541.10"},
+			},
+		}))
+	})
+
+	It("assigns the latest stemcell version matching a constraint", func() {
+		err := command.Execute([]string{"--product-name", "cf", "--stemcell", "< 3541.12"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(service.AssignArgsForCall(0)).To(Equal(api.AssignStemcellInput{
+			Products: []api.StemcellAssignmentInput{
+				{GUID: "cf-guid", StagedStemcellVersion: "3541.10"},
+			},
+		}))
+	})
+
+	Context("when pre-release stemcells are available", func() {
+		BeforeEach(func() {
+			service.ListReturns(api.StemcellAssignmentsOutput{
+				Products: []api.StemcellAssignment{
+					{
+						GUID:                    "cf-guid",
+						ProductName:             "cf",
+						RequiredStemcellOS:      "ubuntu-trusty",
+						RequiredStemcellVersion: "3541",
+						AvailableVersions:       []string{"3541.9", "3541.10-rc.1", "3541.12-rc.2"},
+					},
+				},
+			}, nil)
+		})
+
+		It("leaves them out of constraints that do not name a pre-release", func() {
+			err := command.Execute([]string{"--product-name", "cf", "--stemcell", "~> 3541.0"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.AssignArgsForCall(0).Products[0].StagedStemcellVersion).To(Equal("3541.9"))
+		})
+
+		It("leaves them out of latest", func() {
+			err := command.Execute([]string{"--product-name", "cf", "--stemcell", "latest"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.AssignArgsForCall(0).Products[0].StagedStemcellVersion).To(Equal("3541.9"))
+		})
+
+		It("matches them when the constraint names a pre-release", func() {
+			err := command.Execute([]string{"--product-name", "cf", "--stemcell", ">= 3541.10-rc.1"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.AssignArgsForCall(0).Products[0].StagedStemcellVersion).To(Equal("3541.12-rc.2"))
+		})
+	})
+
+	Context("failure cases", func() {
+		Context("when an unknown flag is provided", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{"--badflag"})
+				Expect(err).To(MatchError("could not parse assign-stemcell flags: flag provided but not defined: -badflag"))
+			})
+		})
+
+		Context("when the product-name flag is missing", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("error: product-name is missing. Please see usage for more information."))
+			})
+		})
+
+		Context("when the stemcell assignments cannot be fetched", func() {
+			It("returns an error", func() {
+				service.ListReturns(api.StemcellAssignmentsOutput{}, errors.New("some error"))
+
+				err := command.Execute([]string{"--product-name", "cf"})
+				Expect(err).To(MatchError("failed to retrieve stemcell assignments: some error"))
+			})
+		})
+
+		Context("when the product is not staged", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{"--product-name", "unknown"})
+				Expect(err).To(MatchError(`could not find staged product "unknown"`))
+			})
+		})
+
+		Context("when no stemcells are available for the product", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{"--product-name", "p-mysql"})
+				Expect(err).To(MatchError("no stemcells are available for p-mysql; upload a ubuntu-xenial stemcell matching version 97"))
+				Expect(service.AssignCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the requested stemcell version is not available", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{"--product-name", "cf", "--stemcell", "3541.1"})
+				Expect(err).To(MatchError("stemcell version 3541.1 is not available for cf; available versions: 3541.9, 3541.12, 3541.10"))
+				Expect(service.AssignCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when no stemcell version matches the constraint", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{"--product-name", "cf", "--stemcell", "~> 3586.0"})
+				Expect(err).To(MatchError(`no stemcell version matches "~> 3586.0" for cf; available versions: 3541.9, 3541.12, 3541.10`))
+				Expect(service.AssignCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the constraint is invalid", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{"--product-name", "cf", "--stemcell", "~> banana"})
+				Expect(err).To(MatchError(`"~> banana" is not a valid version constraint: "banana" is not a valid version`))
+			})
+		})
+
+		Context("when the stemcell cannot be assigned", func() {
+			It("returns an error", func() {
+				service.AssignReturns(errors.New("some error"))
+
+				err := command.Execute([]string{"--product-name", "cf"})
+				Expect(err).To(MatchError("failed to assign stemcell: some error"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			command := commands.NewAssignStemcell(nil, nil)
+			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
+				Description:      "This authenticated command assigns an uploaded stemcell to a staged product. Use latest to pick the newest stemcell available to the product.",
+				ShortDescription: "assigns a stemcell to a staged product",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
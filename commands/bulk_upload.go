@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pivotal-cf/om/uploads"
+)
+
+//go:generate counterfeiter -o ./fakes/product_upload_pool.go --fake-name ProductUploadPool . productUploadPool
+type productUploadPool interface {
+	New(path string) uploads.ProductService
+	Start()
+	Stop()
+}
+
+//go:generate counterfeiter -o ./fakes/stemcell_upload_pool.go --fake-name StemcellUploadPool . stemcellUploadPool
+type stemcellUploadPool interface {
+	New(path string) uploads.StemcellService
+	Start()
+	Stop()
+}
+
+type uploadResult struct {
+	path    string
+	skipped string
+	err     error
+}
+
+func expandPaths(patterns []string) ([]string, error) {
+	var paths []string
+	seen := map[string]bool{}
+
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid file pattern %q: %s", pattern, err)
+		}
+
+		if len(matches) == 0 {
+			if strings.ContainsAny(pattern, "*?[") {
+				return nil, fmt.Errorf("no files match %q", pattern)
+			}
+			matches = []string{pattern}
+		}
+
+		for _, match := range matches {
+			if !seen[match] {
+				seen[match] = true
+				paths = append(paths, match)
+			}
+		}
+	}
+
+	return paths, nil
+}
+
+// uploadConcurrently calls upload for every index, running at most parallel
+// uploads at the same time.
+func uploadConcurrently(indexes []int, parallel int, upload func(int)) {
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, parallel)
+
+	for _, index := range indexes {
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func(index int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			upload(index)
+		}(index)
+	}
+
+	wg.Wait()
+}
+
+func summarizeUploads(logger logger, kind string, results []uploadResult) error {
+	var failed int
+
+	logger.Printf("upload summary:")
+	for _, result := range results {
+		switch {
+		case result.err != nil:
+			failed++
+			logger.Printf("  %s: failed: %s", result.path, result.err)
+		case result.skipped != "":
+			logger.Printf("  %s: skipped: %s", result.path, result.skipped)
+		default:
+			logger.Printf("  %s: uploaded", result.path)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to upload %d of %d %s", failed, len(results), kind)
+	}
+
+	return nil
+}
@@ -0,0 +1,306 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/jhanda/flags"
+	tile "github.com/pivotal-cf/om/extractor"
+	yaml "gopkg.in/yaml.v2"
+)
+
+type ConfigTemplate struct {
+	extractor metadataExtractor
+	logger    logger
+	Options   struct {
+		Product        string `short:"p"  long:"product"  description:"path to product"`
+		OutputFile     string `short:"o"  long:"output-file"  description:"path to write the config template to (default: stdout)"`
+		VarsOutputFile string `short:"vo" long:"vars-output-file"  description:"path to write a vars file listing every placeholder in the template"`
+	}
+}
+
+//go:generate counterfeiter -o ./fakes/metadata_extractor.go --fake-name MetadataExtractor . metadataExtractor
+type metadataExtractor interface {
+	ExtractProductMetadata(string) (tile.Metadata, error)
+}
+
+var credentialFields = map[string][]string{
+	"rsa_cert_credentials": {"cert_pem", "private_key_pem"},
+	"rsa_pkey_credentials": {"private_key_pem"},
+	"simple_credentials":   {"identity", "password"},
+	"salted_credentials":   {"identity", "password"},
+	"secret":               {"secret"},
+}
+
+func NewConfigTemplate(extractor metadataExtractor, logger logger) ConfigTemplate {
+	return ConfigTemplate{
+		extractor: extractor,
+		logger:    logger,
+	}
+}
+
+func (ct ConfigTemplate) Execute(args []string) error {
+	_, err := flags.Parse(&ct.Options, args)
+	if err != nil {
+		return fmt.Errorf("could not parse config-template flags: %s", err)
+	}
+
+	if ct.Options.Product == "" {
+		return errors.New("error: product is missing. Please see usage for more information.")
+	}
+
+	metadata, err := ct.extractor.ExtractProductMetadata(ct.Options.Product)
+	if err != nil {
+		return fmt.Errorf("failed to extract product metadata: %s", err)
+	}
+
+	template := &configTemplate{}
+	template.render(metadata)
+
+	if ct.Options.OutputFile == "" {
+		ct.logger.Printf("%s", template.contents.String())
+	} else {
+		err = ioutil.WriteFile(ct.Options.OutputFile, template.contents.Bytes(), 0644)
+		if err != nil {
+			return fmt.Errorf("could not write config template: %s", err)
+		}
+	}
+
+	if ct.Options.VarsOutputFile != "" {
+		err = ioutil.WriteFile(ct.Options.VarsOutputFile, template.vars(), 0644)
+		if err != nil {
+			return fmt.Errorf("could not write vars file: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func (ct ConfigTemplate) Usage() commands.Usage {
+	return commands.Usage{
+		Description:      "This command generates a configure-product config file from the metadata of a product file. Required properties are rendered as ((placeholders)) and defaults are filled in.",
+		ShortDescription: "generates a config template for a product",
+		Flags:            ct.Options,
+	}
+}
+
+type configTemplate struct {
+	contents             bytes.Buffer
+	placeholders         []string
+	optionalPlaceholders []string
+	inputs               map[string]formInput
+}
+
+// formInput is how the form of the installation dashboard presents a
+// property.
+type formInput struct {
+	form        string
+	label       string
+	description string
+}
+
+func (t *configTemplate) render(metadata tile.Metadata) {
+	fmt.Fprintf(&t.contents, "product-name: %s\n", metadata.Name)
+
+	t.contents.WriteString("product-properties:\n")
+
+	properties := metadata.Properties()
+
+	t.inputs = map[string]formInput{}
+	for _, form := range metadata.FormTypes {
+		label := form.Label
+		if label == "" {
+			label = form.Name
+		}
+		t.addFormInputs(label, form.PropertyInputs)
+	}
+
+	selected := map[string]string{}
+	for _, property := range properties {
+		if property.Blueprint.Type == "selector" && property.Blueprint.Default != nil {
+			selected[property.Reference] = fmt.Sprintf("%v", property.Blueprint.Default)
+		}
+	}
+
+	active := map[string]bool{}
+	var form string
+	for _, property := range properties {
+		isActive := property.Selector == "" || (active[property.Selector] && selected[property.Selector] == property.Option)
+		active[property.Reference] = isActive
+
+		input, ok := t.inputs[property.Reference]
+		if !ok && property.Selector != "" {
+			input.form = t.inputs[property.Selector].form
+			t.inputs[property.Reference] = input
+		}
+
+		if !property.Blueprint.Configurable {
+			continue
+		}
+
+		if input.form != form {
+			form = input.form
+			if form == "" {
+				t.writeLines("  ", false, []string{"# not on a form"})
+			} else {
+				t.writeLines("  ", false, []string{fmt.Sprintf("# form: %s", form)})
+			}
+		}
+
+		t.renderProperty(property, isActive)
+	}
+
+	t.contents.WriteString("network-properties:\n")
+	t.writeLines("  ", false, t.section(yaml.MapSlice{
+		{Key: "network", Value: yaml.MapSlice{{Key: "name", Value: t.placeholder("network_name", false)}}},
+		{Key: "other_availability_zones", Value: []yaml.MapSlice{{{Key: "name", Value: t.placeholder("availability_zone_name", false)}}}},
+		{Key: "singleton_availability_zone", Value: yaml.MapSlice{{Key: "name", Value: t.placeholder("singleton_availability_zone_name", false)}}},
+	}))
+
+	t.contents.WriteString("resource-config:\n")
+	for _, job := range metadata.JobTypes {
+		config := yaml.MapSlice{}
+
+		if job.InstanceDefinition == nil {
+			config = append(config, yaml.MapItem{Key: "instances", Value: "automatic"})
+		} else if job.InstanceDefinition.Configurable {
+			config = append(config, yaml.MapItem{Key: "instances", Value: job.InstanceDefinition.Default})
+		}
+
+		for _, definition := range job.ResourceDefinitions {
+			if definition.Name == "persistent_disk" && definition.Configurable {
+				config = append(config, yaml.MapItem{Key: "persistent_disk", Value: yaml.MapSlice{{Key: "size_mb", Value: "automatic"}}})
+			}
+		}
+
+		config = append(config, yaml.MapItem{Key: "instance_type", Value: yaml.MapSlice{{Key: "id", Value: "automatic"}}})
+
+		t.writeLines("  ", false, t.section(yaml.MapSlice{{Key: job.Name, Value: config}}))
+	}
+}
+
+func (t *configTemplate) renderProperty(property tile.ProductProperty, active bool) {
+	blueprint := property.Blueprint
+
+	var comments []string
+	input := t.inputs[property.Reference]
+	description := strings.Join(strings.Fields(input.description), " ")
+	switch {
+	case input.label != "" && description != "":
+		comments = append(comments, fmt.Sprintf("# %s: %s", input.label, description))
+	case input.label != "" || description != "":
+		comments = append(comments, fmt.Sprintf("# %s%s", input.label, description))
+	}
+
+	if options := blueprint.SelectValues(); len(options) > 0 {
+		comments = append(comments, fmt.Sprintf("# %s; options: %s", blueprint.Type, strings.Join(options, ", ")))
+	}
+
+	var value interface{}
+	switch {
+	case !active:
+		comments = append(comments, fmt.Sprintf("# only used when %s is set to %q", property.Selector, property.Option))
+		value = t.propertyPlaceholder(property.Reference, blueprint, true)
+	case blueprint.Default != nil:
+		value = blueprint.Default
+	case blueprint.Optional:
+		comments = append(comments, "# optional")
+		value = t.propertyPlaceholder(property.Reference, blueprint, true)
+	default:
+		value = t.propertyPlaceholder(property.Reference, blueprint, false)
+	}
+
+	t.writeLines("  ", false, comments)
+	t.writeLines("  ", !active || (blueprint.Default == nil && blueprint.Optional), t.section(yaml.MapSlice{
+		{Key: property.Reference, Value: yaml.MapSlice{{Key: "value", Value: value}}},
+	}))
+}
+
+// addFormInputs records the form, label and description of every input of
+// a form, including the inputs of selector options and collections.
+func (t *configTemplate) addFormInputs(form string, inputs []tile.PropertyInput) {
+	for _, input := range inputs {
+		t.inputs[input.Reference] = formInput{
+			form:        form,
+			label:       input.Label,
+			description: input.Description,
+		}
+
+		t.addFormInputs(form, input.SelectorPropertyInputs)
+		t.addFormInputs(form, input.PropertyInputs)
+	}
+}
+
+func (t *configTemplate) propertyPlaceholder(reference string, blueprint tile.PropertyBlueprint, optional bool) interface{} {
+	name := strings.Replace(strings.TrimPrefix(reference, "."), ".", "_", -1)
+
+	placeholder := func(name string) string {
+		return t.placeholder(name, optional)
+	}
+
+	if fields, ok := credentialFields[blueprint.Type]; ok {
+		value := yaml.MapSlice{}
+		for _, field := range fields {
+			value = append(value, yaml.MapItem{Key: field, Value: placeholder(fmt.Sprintf("%s_%s", name, field))})
+		}
+		return value
+	}
+
+	if blueprint.Type == "collection" && len(blueprint.PropertyBlueprints) > 0 {
+		item := yaml.MapSlice{}
+		for _, field := range blueprint.PropertyBlueprints {
+			switch {
+			case field.Default != nil:
+				item = append(item, yaml.MapItem{Key: field.Name, Value: field.Default})
+			case !field.Optional:
+				item = append(item, yaml.MapItem{Key: field.Name, Value: t.propertyPlaceholder(fmt.Sprintf("%s.%s", reference, field.Name), field, optional)})
+			}
+		}
+		return []yaml.MapSlice{item}
+	}
+
+	return placeholder(name)
+}
+
+func (t *configTemplate) placeholder(name string, optional bool) string {
+	if optional {
+		t.optionalPlaceholders = append(t.optionalPlaceholders, name)
+	} else {
+		t.placeholders = append(t.placeholders, name)
+	}
+	return fmt.Sprintf("((%s))", name)
+}
+
+func (t *configTemplate) section(value yaml.MapSlice) []string {
+	contents, _ := yaml.Marshal(value)
+	return strings.Split(strings.TrimSuffix(string(contents), "\n"), "\n")
+}
+
+func (t *configTemplate) writeLines(indent string, commented bool, lines []string) {
+	for _, line := range lines {
+		if commented {
+			line = "# " + line
+		}
+		fmt.Fprintf(&t.contents, "%s%s\n", indent, line)
+	}
+}
+
+func (t *configTemplate) vars() []byte {
+	var contents bytes.Buffer
+	for _, name := range t.placeholders {
+		fmt.Fprintf(&contents, "%s:\n", name)
+	}
+
+	if len(t.optionalPlaceholders) > 0 {
+		contents.WriteString("# optional, or only used when a selector is set to another option\n")
+		for _, name := range t.optionalPlaceholders {
+			fmt.Fprintf(&contents, "# %s:\n", name)
+		}
+	}
+
+	return contents.Bytes()
+}
@@ -0,0 +1,285 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	jhandacommands "github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/extractor"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const expectedConfigTemplate = `product-name: some-product
+product-properties:
+  # form: Some Form
+  # Some String: the string of the product
+  .properties.some-string:
+    value: ((properties_some-string))
+  # not on a form
+  .properties.some-default:
+    value: 8080
+  # optional
+  # .properties.some-optional:
+  #   value: ((properties_some-optional))
+  # form: Some Form
+  # Some Selector
+  # selector; options: internal, external
+  .properties.some-selector:
+    value: internal
+  # Size
+  .properties.some-selector.internal.some-size:
+    value: ((properties_some-selector_internal_some-size))
+  # only used when .properties.some-selector is set to "external"
+  # .properties.some-selector.external.some-address:
+  #   value: ((properties_some-selector_external_some-address))
+  # not on a form
+  # dropdown_select; options: small, large
+  .properties.some-dropdown:
+    value: ((properties_some-dropdown))
+  # form: other-form
+  # the certificate of the product
+  .properties.some-certificate:
+    value:
+      cert_pem: ((properties_some-certificate_cert_pem))
+      private_key_pem: ((properties_some-certificate_private_key_pem))
+  # not on a form
+  .some-job.some-job-property:
+    value: ((some-job_some-job-property))
+network-properties:
+  network:
+    name: ((network_name))
+  other_availability_zones:
+  - name: ((availability_zone_name))
+  singleton_availability_zone:
+    name: ((singleton_availability_zone_name))
+resource-config:
+  some-job:
+    instances: 3
+    persistent_disk:
+      size_mb: automatic
+    instance_type:
+      id: automatic
+  some-errand:
+    instance_type:
+      id: automatic
+`
+
+var _ = Describe("ConfigTemplate", func() {
+	var (
+		metadataExtractor *fakes.MetadataExtractor
+		logger            *fakes.Logger
+		metadata          extractor.Metadata
+	)
+
+	BeforeEach(func() {
+		metadataExtractor = &fakes.MetadataExtractor{}
+		logger = &fakes.Logger{}
+
+		metadata = extractor.Metadata{
+			Name:           "some-product",
+			ProductVersion: "1.2.3",
+			PropertyBlueprints: []extractor.PropertyBlueprint{
+				{Name: "some-string", Type: "string", Configurable: true},
+				{Name: "some-default", Type: "port", Configurable: true, Default: 8080},
+				{Name: "some-optional", Type: "string", Configurable: true, Optional: true},
+				{Name: "not-configurable", Type: "string"},
+				{
+					Name:         "some-selector",
+					Type:         "selector",
+					Configurable: true,
+					Default:      "internal",
+					OptionTemplates: []extractor.OptionTemplate{
+						{
+							Name:        "internal",
+							SelectValue: "internal",
+							PropertyBlueprints: []extractor.PropertyBlueprint{
+								{Name: "some-size", Type: "integer", Configurable: true},
+							},
+						},
+						{
+							Name:        "external",
+							SelectValue: "external",
+							PropertyBlueprints: []extractor.PropertyBlueprint{
+								{Name: "some-address", Type: "string", Configurable: true},
+							},
+						},
+					},
+				},
+				{
+					Name:         "some-dropdown",
+					Type:         "dropdown_select",
+					Configurable: true,
+					Options: []extractor.PropertyOption{
+						{Name: "small", Label: "Small"},
+						{Name: "large", Label: "Large"},
+					},
+				},
+				{Name: "some-certificate", Type: "rsa_cert_credentials", Configurable: true},
+			},
+			FormTypes: []extractor.FormType{
+				{
+					Name:  "some-form",
+					Label: "Some Form",
+					PropertyInputs: []extractor.PropertyInput{
+						{Reference: ".properties.some-string", Label: "Some String", Description: "the string\n  of the product"},
+						{
+							Reference: ".properties.some-selector",
+							Label:     "Some Selector",
+							SelectorPropertyInputs: []extractor.PropertyInput{
+								{
+									Reference: ".properties.some-selector.internal",
+									Label:     "Internal",
+									PropertyInputs: []extractor.PropertyInput{
+										{Reference: ".properties.some-selector.internal.some-size", Label: "Size"},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					Name: "other-form",
+					PropertyInputs: []extractor.PropertyInput{
+						{Reference: ".properties.some-certificate", Description: "the certificate of the product"},
+					},
+				},
+			},
+			JobTypes: []extractor.JobType{
+				{
+					Name:               "some-job",
+					InstanceDefinition: &extractor.ResourceDefinition{Name: "instances", Configurable: true, Default: 3},
+					ResourceDefinitions: []extractor.ResourceDefinition{
+						{Name: "persistent_disk", Configurable: true, Default: 1024},
+					},
+					PropertyBlueprints: []extractor.PropertyBlueprint{
+						{Name: "some-job-property", Type: "string", Configurable: true},
+					},
+				},
+				{
+					Name:               "some-errand",
+					Errand:             true,
+					InstanceDefinition: &extractor.ResourceDefinition{Name: "instances", Default: 1},
+				},
+			},
+		}
+
+		metadataExtractor.ExtractProductMetadataReturns(metadata, nil)
+	})
+
+	Describe("Execute", func() {
+		It("prints a config template for the product", func() {
+			command := commands.NewConfigTemplate(metadataExtractor, logger)
+
+			err := command.Execute([]string{"--product", "/path/to/some-product.pivotal"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(metadataExtractor.ExtractProductMetadataArgsForCall(0)).To(Equal("/path/to/some-product.pivotal"))
+
+			format, content := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, content...)).To(Equal(expectedConfigTemplate))
+		})
+
+		Context("when output files are provided", func() {
+			var outputDir string
+
+			BeforeEach(func() {
+				var err error
+				outputDir, err = ioutil.TempDir("", "")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(outputDir)
+			})
+
+			It("writes the template and a vars file with every placeholder, with the optional ones commented out", func() {
+				command := commands.NewConfigTemplate(metadataExtractor, logger)
+
+				err := command.Execute([]string{
+					"--product", "/path/to/some-product.pivotal",
+					"--output-file", outputDir + "/product.yml",
+					"--vars-output-file", outputDir + "/vars.yml",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintfCallCount()).To(Equal(0))
+
+				template, err := ioutil.ReadFile(outputDir + "/product.yml")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(template)).To(Equal(expectedConfigTemplate))
+
+				vars, err := ioutil.ReadFile(outputDir + "/vars.yml")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(vars)).To(Equal(`properties_some-string:
+properties_some-selector_internal_some-size:
+properties_some-dropdown:
+properties_some-certificate_cert_pem:
+properties_some-certificate_private_key_pem:
+some-job_some-job-property:
+network_name:
+availability_zone_name:
+singleton_availability_zone_name:
+# optional, or only used when a selector is set to another option
+# properties_some-optional:
+# properties_some-selector_external_some-address:
+`))
+			})
+		})
+
+		Context("failure cases", func() {
+			Context("when an unknown flag is provided", func() {
+				It("returns an error", func() {
+					command := commands.NewConfigTemplate(metadataExtractor, logger)
+					err := command.Execute([]string{"--badflag"})
+					Expect(err).To(MatchError("could not parse config-template flags: flag provided but not defined: -badflag"))
+				})
+			})
+
+			Context("when the product flag is not provided", func() {
+				It("returns an error", func() {
+					command := commands.NewConfigTemplate(metadataExtractor, logger)
+					err := command.Execute([]string{})
+					Expect(err).To(MatchError("error: product is missing. Please see usage for more information."))
+				})
+			})
+
+			Context("when the metadata cannot be extracted", func() {
+				It("returns an error", func() {
+					metadataExtractor.ExtractProductMetadataReturns(extractor.Metadata{}, errors.New("some error"))
+
+					command := commands.NewConfigTemplate(metadataExtractor, logger)
+					err := command.Execute([]string{"--product", "/path/to/some-product.pivotal"})
+					Expect(err).To(MatchError("failed to extract product metadata: some error"))
+				})
+			})
+
+			Context("when the output file cannot be written", func() {
+				It("returns an error", func() {
+					command := commands.NewConfigTemplate(metadataExtractor, logger)
+					err := command.Execute([]string{
+						"--product", "/path/to/some-product.pivotal",
+						"--output-file", "/not/a/real/dir/product.yml",
+					})
+					Expect(err).To(MatchError(ContainSubstring("could not write config template:")))
+				})
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			command := commands.NewConfigTemplate(nil, nil)
+			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
+				Description:      "This command generates a configure-product config file from the metadata of a product file. Required properties are rendered as ((placeholders)) and defaults are filled in.",
+				ShortDescription: "generates a config template for a product",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
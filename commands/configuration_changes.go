@@ -0,0 +1,348 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/pivotal-cf/jhanda/flags"
+	"github.com/pivotal-cf/om/api"
+)
+
+// ConfigurationChanged reports whether executing configure-product with the
+// given args would change the staged configuration of the product. Anything
+// that cannot be compared, such as a credential, or that configure-product
+// would reject, counts as a change.
+func (cp ConfigureProduct) ConfigurationChanged(args []string) (bool, error) {
+	_, err := flags.Parse(&cp.Options, args)
+	if err != nil {
+		return false, fmt.Errorf("could not parse configure-product flags: %s", err)
+	}
+
+	if cp.Options.ConfigFile != "" {
+		err = cp.applyConfigFile()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	productGUID, err := cp.stagedProductGUID()
+	if err != nil {
+		return false, err
+	}
+
+	if productGUID == "" {
+		return true, nil
+	}
+
+	for _, changed := range []func(string) (bool, error){
+		cp.propertiesChanged,
+		cp.networkChanged,
+		cp.resourcesChanged,
+		cp.maxInFlightChanged,
+		cp.syslogChanged,
+		cp.errandsChanged,
+	} {
+		result, err := changed(productGUID)
+		if err != nil || result {
+			return result, err
+		}
+	}
+
+	return false, nil
+}
+
+func (cp ConfigureProduct) propertiesChanged(productGUID string) (bool, error) {
+	if cp.Options.ProductProperties == "" {
+		return false, nil
+	}
+
+	properties, err := cp.decodeProductProperties()
+	if err != nil {
+		return true, nil
+	}
+
+	stagedProperties, err := cp.productsService.Properties(productGUID)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch product properties: %s", err)
+	}
+
+	for name, value := range properties {
+		property, ok := stagedProperties[name]
+		if !ok || property.IsCredential || !propertyMatches(property, value) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (cp ConfigureProduct) networkChanged(productGUID string) (bool, error) {
+	if cp.Options.NetworkProperties == "" {
+		return false, nil
+	}
+
+	var network map[string]interface{}
+	err := json.Unmarshal([]byte(cp.Options.NetworkProperties), &network)
+	if err != nil {
+		return true, nil
+	}
+
+	stagedNetwork, err := cp.productsService.NetworksAndAZs(productGUID)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch network properties: %s", err)
+	}
+
+	return !jsonContains(stagedNetwork, network), nil
+}
+
+func (cp ConfigureProduct) resourcesChanged(productGUID string) (bool, error) {
+	if cp.Options.ProductResources == "{}" {
+		return false, nil
+	}
+
+	var resources map[string]interface{}
+	err := json.Unmarshal([]byte(cp.Options.ProductResources), &resources)
+	if err != nil {
+		return true, nil
+	}
+
+	jobs, err := cp.jobsService.Jobs(productGUID)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch jobs: %s", err)
+	}
+
+	for name, resource := range resources {
+		jobGUID, ok := jobs[name]
+		if !ok {
+			return true, nil
+		}
+
+		jobProperties, err := cp.jobsService.GetExistingJobConfig(productGUID, jobGUID)
+		if err != nil {
+			return false, fmt.Errorf("could not fetch existing job configuration: %s", err)
+		}
+
+		if !jsonContains(jobProperties, resource) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (cp ConfigureProduct) maxInFlightChanged(productGUID string) (bool, error) {
+	if cp.Options.MaxInFlight == "" {
+		return false, nil
+	}
+
+	var maxInFlight map[string]interface{}
+	err := json.Unmarshal([]byte(cp.Options.MaxInFlight), &maxInFlight)
+	if err != nil {
+		return true, nil
+	}
+
+	jobs, err := cp.jobsService.Jobs(productGUID)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch jobs: %s", err)
+	}
+
+	jobMaxInFlight := map[string]interface{}{}
+	for name, value := range maxInFlight {
+		jobGUID, ok := jobs[name]
+		if !ok {
+			return true, nil
+		}
+
+		jobMaxInFlight[jobGUID], ok = maxInFlightValue(value)
+		if !ok {
+			return true, nil
+		}
+	}
+
+	stagedMaxInFlight, err := cp.productsService.MaxInFlight(productGUID)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch max in flight: %s", err)
+	}
+
+	return !jsonContains(stagedMaxInFlight, jobMaxInFlight), nil
+}
+
+func (cp ConfigureProduct) syslogChanged(productGUID string) (bool, error) {
+	if cp.Options.SyslogProperties == "" {
+		return false, nil
+	}
+
+	var syslogProperties map[string]interface{}
+	err := json.Unmarshal([]byte(cp.Options.SyslogProperties), &syslogProperties)
+	if err != nil {
+		return true, nil
+	}
+
+	stagedSyslogProperties, err := cp.productsService.SyslogConfiguration(productGUID)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch syslog properties: %s", err)
+	}
+
+	return !jsonContains(stagedSyslogProperties, syslogProperties), nil
+}
+
+func (cp ConfigureProduct) errandsChanged(productGUID string) (bool, error) {
+	if cp.Options.ErrandConfig == "" {
+		return false, nil
+	}
+
+	var errandConfig map[string]map[string]interface{}
+	err := json.Unmarshal([]byte(cp.Options.ErrandConfig), &errandConfig)
+	if err != nil {
+		return true, nil
+	}
+
+	errandsOutput, err := cp.errandsService.List(productGUID)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch errands: %s", err)
+	}
+
+	stagedErrands := map[string]api.Errand{}
+	for _, errand := range errandsOutput.Errands {
+		stagedErrands[errand.Name] = errand
+	}
+
+	for name, states := range errandConfig {
+		errand, ok := stagedErrands[name]
+		if !ok {
+			return true, nil
+		}
+
+		for field, value := range states {
+			state, ok := errandStateValue(value)
+			if !ok {
+				return true, nil
+			}
+
+			var stagedState interface{}
+			switch field {
+			case "post-deploy-state":
+				stagedState = errand.PostDeploy
+			case "pre-delete-state":
+				stagedState = errand.PreDelete
+			default:
+				return true, nil
+			}
+
+			if !jsonContains(stagedState, state) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// propertyMatches reports whether a property of the product-properties, such
+// as {"value": "example.com"}, is already staged. Every field of a staged
+// collection is returned as a property of its own, so the staged fields are
+// reduced to their values before they are compared.
+func propertyMatches(property api.ResponseProperty, value interface{}) bool {
+	stagedValue := property.Value
+	if property.Type == "collection" {
+		stagedValue = collectionValues(property.Value)
+	}
+
+	return jsonContains(map[string]interface{}{
+		"value":           stagedValue,
+		"selected_option": property.SelectedOption,
+	}, value)
+}
+
+func collectionValues(value interface{}) interface{} {
+	items, ok := value.([]interface{})
+	if !ok {
+		return value
+	}
+
+	var values []interface{}
+	for _, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			return value
+		}
+
+		itemValues := map[string]interface{}{}
+		for name, field := range fields {
+			if property, ok := field.(map[string]interface{}); ok {
+				itemValues[name] = property["value"]
+			}
+		}
+		values = append(values, itemValues)
+	}
+
+	return values
+}
+
+// jsonContains reports whether wanted is already part of staged. Every key of
+// a map in wanted must have the same value in staged, while a list must have
+// the same items. Values are compared by their json encoding, so that the
+// number 5 matches the 5.0 that the api returns.
+func jsonContains(staged, wanted interface{}) bool {
+	stagedValue, err := jsonValue(staged)
+	if err != nil {
+		return false
+	}
+
+	wantedValue, err := jsonValue(wanted)
+	if err != nil {
+		return false
+	}
+
+	return containsValue(stagedValue, wantedValue)
+}
+
+func containsValue(staged, wanted interface{}) bool {
+	switch w := wanted.(type) {
+	case map[string]interface{}:
+		s, ok := staged.(map[string]interface{})
+		if !ok {
+			return false
+		}
+
+		for key, value := range w {
+			stagedValue, ok := s[key]
+			if !ok || !containsValue(stagedValue, value) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		s, ok := staged.([]interface{})
+		if !ok || len(s) != len(w) {
+			return false
+		}
+
+		for i := range w {
+			if !containsValue(s[i], w[i]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return reflect.DeepEqual(staged, wanted)
+}
+
+func jsonValue(value interface{}) (interface{}, error) {
+	contents, err := json.Marshal(jsonCompatible(value))
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded interface{}
+	err = json.Unmarshal(contents, &decoded)
+	if err != nil {
+		return nil, err // cannot be tested
+	}
+
+	return decoded, nil
+}
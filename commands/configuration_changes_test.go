@@ -0,0 +1,169 @@
+package commands_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConfigurationChanged", func() {
+	var (
+		productsService   *fakes.ProductConfigurer
+		jobsService       *fakes.JobsConfigurer
+		errandsService    *fakes.ErrandsService
+		metadataExtractor *fakes.MetadataExtractor
+		logger            *fakes.Logger
+		command           commands.ConfigureProduct
+	)
+
+	BeforeEach(func() {
+		productsService = &fakes.ProductConfigurer{}
+		jobsService = &fakes.JobsConfigurer{}
+		errandsService = &fakes.ErrandsService{}
+		metadataExtractor = &fakes.MetadataExtractor{}
+		logger = &fakes.Logger{}
+
+		productsService.StagedProductsReturns(api.StagedProductsOutput{
+			Products: []api.StagedProduct{
+				{GUID: "some-product-guid", Type: "cf"},
+			},
+		}, nil)
+		productsService.PropertiesReturns(map[string]api.ResponseProperty{
+			".properties.domain": {Value: "example.com", Type: "string", Configurable: true},
+			".properties.password": {
+				Value:        map[string]interface{}{"secret": "***"},
+				Type:         "secret",
+				Configurable: true,
+				IsCredential: true,
+			},
+			".properties.routes": {
+				Type:         "collection",
+				Configurable: true,
+				Value: []interface{}{
+					map[string]interface{}{
+						"guid": map[string]interface{}{"value": "some-route-guid", "type": "uuid"},
+						"name": map[string]interface{}{"value": "some-route", "type": "string"},
+						"port": map[string]interface{}{"value": float64(8080), "type": "port"},
+					},
+				},
+			},
+		}, nil)
+		productsService.NetworksAndAZsReturns(map[string]interface{}{
+			"singleton_availability_zone": map[string]interface{}{"name": "az-one", "guid": "some-az-guid"},
+			"network":                     map[string]interface{}{"name": "some-network", "guid": "some-network-guid"},
+		}, nil)
+		productsService.MaxInFlightReturns(map[string]interface{}{"some-job-guid": "20%"}, nil)
+		productsService.SyslogConfigurationReturns(map[string]interface{}{"enabled": true, "address": "example.com"}, nil)
+		jobsService.JobsReturns(map[string]string{"some-job": "some-job-guid"}, nil)
+		jobsService.GetExistingJobConfigReturns(api.JobProperties{Instances: float64(2), PersistentDisk: &api.Disk{Size: "20480"}}, nil)
+		errandsService.ListReturns(api.ErrandsListOutput{
+			Errands: []api.Errand{
+				{Name: "smoke_tests", PostDeploy: false, PreDelete: nil},
+			},
+		}, nil)
+
+		command = commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+	})
+
+	It("reports no change when everything that is given is already staged", func() {
+		changed, err := command.ConfigurationChanged([]string{
+			"--product-name", "cf",
+			"--product-properties", `{".properties.domain": {"value": "example.com"}, ".properties.routes": {"value": [{"name": "some-route", "port": 8080}]}}`,
+			"--product-network", `{"singleton_availability_zone": {"name": "az-one"}, "network": {"name": "some-network"}}`,
+			"--product-resources", `{"some-job": {"instances": 2}}`,
+			"--max-in-flight", `{"some-job": "20%"}`,
+			"--syslog-properties", `{"enabled": true}`,
+			"--errand-config", `{"smoke_tests": {"post-deploy-state": "disabled"}}`,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeFalse())
+
+		Expect(productsService.PropertiesArgsForCall(0)).To(Equal("some-product-guid"))
+		Expect(productsService.NetworksAndAZsArgsForCall(0)).To(Equal("some-product-guid"))
+		productGUID, jobGUID := jobsService.GetExistingJobConfigArgsForCall(0)
+		Expect(productGUID).To(Equal("some-product-guid"))
+		Expect(jobGUID).To(Equal("some-job-guid"))
+		Expect(productsService.ConfigureCallCount()).To(Equal(0))
+	})
+
+	It("reads the configuration from the config file", func() {
+		configFile, err := ioutil.TempFile("", "config.yml")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.Remove(configFile.Name())
+
+		_, err = configFile.WriteString("product-name: cf\nproduct-properties:\n  .properties.domain:\n    value: other.example.com\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configFile.Close()).To(Succeed())
+
+		changed, err := command.ConfigurationChanged([]string{"--config", configFile.Name()})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeTrue())
+	})
+
+	DescribeTable("reports a change",
+		func(args ...string) {
+			changed, err := command.ConfigurationChanged(append([]string{"--product-name", "cf"}, args...))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+		},
+		Entry("when a property has a different value", "--product-properties", `{".properties.domain": {"value": "other.example.com"}}`),
+		Entry("when a property is a credential", "--product-properties", `{".properties.password": {"value": {"secret": "***"}}}`),
+		Entry("when a property is not staged", "--product-properties", `{".properties.unknown": {"value": "example.com"}}`),
+		Entry("when a collection has a different item", "--product-properties", `{".properties.routes": {"value": [{"name": "some-route", "port": 8081}]}}`),
+		Entry("when a collection has more items", "--product-properties", `{".properties.routes": {"value": [{"name": "some-route"}, {"name": "other-route"}]}}`),
+		Entry("when the network is different", "--product-network", `{"network": {"name": "other-network"}}`),
+		Entry("when a resource is different", "--product-resources", `{"some-job": {"instances": 3}}`),
+		Entry("when a resource is for an unknown job", "--product-resources", `{"unknown-job": {"instances": 3}}`),
+		Entry("when the max in flight is different", "--max-in-flight", `{"some-job": 1}`),
+		Entry("when the max in flight is not valid", "--max-in-flight", `{"some-job": "all"}`),
+		Entry("when the syslog properties are different", "--syslog-properties", `{"enabled": false}`),
+		Entry("when an errand state is different", "--errand-config", `{"smoke_tests": {"pre-delete-state": "enabled"}}`),
+		Entry("when an errand is unknown", "--errand-config", `{"unknown": {"post-deploy-state": "enabled"}}`),
+		Entry("when the properties cannot be decoded", "--product-properties", "%%%"),
+	)
+
+	Context("when the product is not staged", func() {
+		It("reports a change", func() {
+			changed, err := command.ConfigurationChanged([]string{"--product-name", "p-mysql", "--syslog-properties", `{"enabled": true}`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+
+			Expect(productsService.SyslogConfigurationCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("failure cases", func() {
+		Context("when an unknown flag is provided", func() {
+			It("returns an error", func() {
+				_, err := command.ConfigurationChanged([]string{"--badflag"})
+				Expect(err).To(MatchError("could not parse configure-product flags: flag provided but not defined: -badflag"))
+			})
+		})
+
+		Context("when the staged products cannot be fetched", func() {
+			It("returns an error", func() {
+				productsService.StagedProductsReturns(api.StagedProductsOutput{}, errors.New("some error"))
+
+				_, err := command.ConfigurationChanged([]string{"--product-name", "cf"})
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+
+		Context("when the staged configuration cannot be fetched", func() {
+			It("returns an error", func() {
+				productsService.NetworksAndAZsReturns(nil, errors.New("some error"))
+
+				_, err := command.ConfigurationChanged([]string{"--product-name", "cf", "--product-network", `{"network": {"name": "some-network"}}`})
+				Expect(err).To(MatchError("failed to fetch network properties: some error"))
+			})
+		})
+	})
+})
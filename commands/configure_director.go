@@ -2,24 +2,50 @@ package commands
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/pivotal-cf/jhanda/commands"
 	"github.com/pivotal-cf/jhanda/flags"
 	"github.com/pivotal-cf/om/api"
 )
 
+// directorConfigurationSections are the sections of a configure-director
+// config file, in the order in which they are applied.
+var directorConfigurationSections = []string{
+	"iaas-configurations",
+	"az-configuration",
+	"networks-configuration",
+	"network-assignment",
+	"director-configuration",
+	"iaas-configuration",
+	"security-configuration",
+	"syslog-configuration",
+	"vmextensions-configuration",
+	"resource-configuration",
+}
+
 type ConfigureDirector struct {
-	service directorService
-	logger  logger
-	Options struct {
-		AZConfiguration       string `short:"a" long:"az-configuration" description:"configures network availability zones"`
-		NetworksConfiguration string `short:"n" long:"networks-configuration" description:"configures networks for the bosh director"`
-		NetworkAssignment     string `short:"na" long:"network-assignment" description:"assigns networks and AZs"`
-		DirectorConfiguration string `short:"d" long:"director-configuration" description:"properties for director configuration"`
-		IAASConfiguration     string `short:"i" long:"iaas-configuration" description:"iaas specific JSON configuration for the bosh director"`
-		SecurityConfiguration string `short:"s" long:"security-configuration" decription:"security configuration properties for directory"`
-		SyslogConfiguration   string `short:"l" long:"syslog-configuration" decription:"syslog configuration properties for directory"`
+	service               directorService
+	jobsService           jobsConfigurer
+	stagedProductsService stagedProductsLister
+	vmExtensionsService   vmExtensionCreator
+	logger                logger
+	Options               struct {
+		AZConfiguration           string `short:"a" long:"az-configuration" description:"configures network availability zones"`
+		NetworksConfiguration     string `short:"n" long:"networks-configuration" description:"configures networks for the bosh director"`
+		NetworkAssignment         string `short:"na" long:"network-assignment" description:"assigns networks and AZs"`
+		DirectorConfiguration     string `short:"d" long:"director-configuration" description:"properties for director configuration"`
+		IAASConfiguration         string `short:"i" long:"iaas-configuration" description:"iaas specific JSON configuration for the bosh director"`
+		IAASConfigurations        string `long:"iaas-configurations" description:"list of named iaas specific JSON configurations, for a director that deploys to several IaaSes"`
+		SecurityConfiguration     string `short:"s" long:"security-configuration" decription:"security configuration properties for directory"`
+		SyslogConfiguration       string `short:"l" long:"syslog-configuration" decription:"syslog configuration properties for directory"`
+		VMExtensionsConfiguration string `long:"vmextensions-configuration" description:"list of VM extensions to create or replace, in JSON format"`
+		ResourceConfiguration     string `short:"r" long:"resource-configuration" description:"resource configurations of the director jobs in JSON format"`
+		ConfigFile                string `short:"c" long:"config" description:"path to yml file containing any of the configuration sections, keyed by flag name"`
+		VarsFile                  string `long:"vars-file" description:"path to yml file containing values for ((placeholders)) in the config file"`
 	}
 }
 
@@ -30,10 +56,21 @@ type directorService interface {
 	NetworksConfiguration(json.RawMessage) error
 	NetworkAndAZ(api.NetworkAndAZConfiguration) error
 	Properties(api.DirectorProperties) error
+	IAASConfigurations() ([]api.IAASConfiguration, error)
+	CreateIAASConfiguration(json.RawMessage) error
+	UpdateIAASConfiguration(guid string, properties json.RawMessage) error
+	DeleteIAASConfiguration(guid string) error
+	AvailabilityZones() ([]api.AvailabilityZone, error)
 }
 
-func NewConfigureDirector(service directorService, logger logger) ConfigureDirector {
-	return ConfigureDirector{service: service, logger: logger}
+func NewConfigureDirector(service directorService, jobsService jobsConfigurer, stagedProductsService stagedProductsLister, vmExtensionsService vmExtensionCreator, logger logger) ConfigureDirector {
+	return ConfigureDirector{
+		service:               service,
+		jobsService:           jobsService,
+		stagedProductsService: stagedProductsService,
+		vmExtensionsService:   vmExtensionsService,
+		logger:                logger,
+	}
 }
 
 func (c ConfigureDirector) Execute(args []string) error {
@@ -42,11 +79,42 @@ func (c ConfigureDirector) Execute(args []string) error {
 		return fmt.Errorf("could not parse configure-director flags: %s", err)
 	}
 
+	if c.Options.ConfigFile != "" {
+		err = c.applyConfigFile()
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.Options.IAASConfiguration != "" && c.Options.IAASConfigurations != "" {
+		return errors.New("iaas-configuration and iaas-configurations cannot be given together")
+	}
+
+	if c.Options.NetworksConfiguration != "" {
+		err = validateNetworks(c.Options.NetworksConfiguration, c.Options.AZConfiguration, c.stagedAZNames)
+		if err != nil {
+			return err
+		}
+	}
+
+	var unlistedIAASConfigurations []api.IAASConfiguration
+	if c.Options.IAASConfigurations != "" {
+		unlistedIAASConfigurations, err = c.configureIAASConfigurations()
+		if err != nil {
+			return err
+		}
+	}
+
 	if c.Options.AZConfiguration != "" {
+		availabilityZones, err := c.resolveIAASConfigurationNames(json.RawMessage(c.Options.AZConfiguration))
+		if err != nil {
+			return err
+		}
+
 		c.logger.Printf("started configuring availability zone options for bosh tile")
 
 		err = c.service.AZConfiguration(api.AZConfiguration{
-			AvailabilityZones: json.RawMessage(c.Options.AZConfiguration),
+			AvailabilityZones: availabilityZones,
 		})
 		if err != nil {
 			return fmt.Errorf("availability zones configuration could not be applied: %s", err)
@@ -55,6 +123,11 @@ func (c ConfigureDirector) Execute(args []string) error {
 		c.logger.Printf("finished configuring availability zone options for bosh tile")
 	}
 
+	err = c.deleteIAASConfigurations(unlistedIAASConfigurations)
+	if err != nil {
+		return err
+	}
+
 	if c.Options.NetworksConfiguration != "" {
 		c.logger.Printf("started configuring network options for bosh tile")
 
@@ -93,9 +166,315 @@ func (c ConfigureDirector) Execute(args []string) error {
 
 	c.logger.Printf("finished configuring director options for bosh tile")
 
+	if c.Options.VMExtensionsConfiguration != "" {
+		err = c.configureVMExtensions()
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.Options.ResourceConfiguration != "" {
+		err = c.configureResources()
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// configureIAASConfigurations creates or updates the staged iaas
+// configurations to match the given list by name. It returns the staged
+// configurations that are not in the list, which are only deleted once the
+// availability zones no longer refer to them.
+func (c ConfigureDirector) configureIAASConfigurations() ([]api.IAASConfiguration, error) {
+	var configurations []json.RawMessage
+	err := json.Unmarshal([]byte(c.Options.IAASConfigurations), &configurations)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode iaas-configurations json: %s", err)
+	}
+
+	var names []string
+	for i, configuration := range configurations {
+		var named struct {
+			Name string `json:"name"`
+		}
+		err = json.Unmarshal(configuration, &named)
+		if err != nil {
+			return nil, fmt.Errorf("iaas-configurations[%d] must be an object", i)
+		}
+
+		if named.Name == "" {
+			return nil, fmt.Errorf("iaas-configurations[%d]: name is missing", i)
+		}
+
+		if contains(names, named.Name) {
+			return nil, fmt.Errorf("iaas-configurations[%d]: name %s is used more than once", i, named.Name)
+		}
+		names = append(names, named.Name)
+	}
+
+	existing, err := c.service.IAASConfigurations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch iaas configurations: %s", err)
+	}
+
+	guids := map[string]string{}
+	var unlisted []api.IAASConfiguration
+	for _, configuration := range existing {
+		guids[configuration.Name] = configuration.GUID
+
+		if !contains(names, configuration.Name) {
+			unlisted = append(unlisted, configuration)
+		}
+	}
+
+	c.logger.Printf("started configuring iaas configurations for bosh tile")
+
+	for i, configuration := range configurations {
+		guid, ok := guids[names[i]]
+		if ok {
+			err = c.service.UpdateIAASConfiguration(guid, configuration)
+		} else {
+			err = c.service.CreateIAASConfiguration(configuration)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("iaas configuration %s could not be applied: %s", names[i], err)
+		}
+	}
+
+	c.logger.Printf("finished configuring iaas configurations for bosh tile")
+
+	return unlisted, nil
+}
+
+func (c ConfigureDirector) deleteIAASConfigurations(configurations []api.IAASConfiguration) error {
+	for _, configuration := range configurations {
+		c.logger.Printf("deleting iaas configuration %s", configuration.Name)
+
+		err := c.service.DeleteIAASConfiguration(configuration.GUID)
+		if err != nil {
+			return fmt.Errorf("iaas configuration %s could not be deleted: %s", configuration.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// resolveIAASConfigurationNames replaces the iaas_configuration_name of each
+// availability zone with the guid of the staged iaas configuration of that
+// name. Availability zones without a name are sent as they are.
+func (c ConfigureDirector) resolveIAASConfigurationNames(availabilityZones json.RawMessage) (json.RawMessage, error) {
+	var azs []map[string]interface{}
+	err := json.Unmarshal(availabilityZones, &azs)
+	if err != nil {
+		return availabilityZones, nil
+	}
+
+	var named bool
+	for _, az := range azs {
+		if _, ok := az["iaas_configuration_name"]; ok {
+			named = true
+		}
+	}
+
+	if !named {
+		return availabilityZones, nil
+	}
+
+	configurations, err := c.service.IAASConfigurations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch iaas configurations: %s", err)
+	}
+
+	guids := map[string]string{}
+	for _, configuration := range configurations {
+		guids[configuration.Name] = configuration.GUID
+	}
+
+	for i, az := range azs {
+		name, ok := az["iaas_configuration_name"]
+		if !ok {
+			continue
+		}
+
+		guid, ok := guids[fmt.Sprintf("%v", name)]
+		if !ok {
+			return nil, fmt.Errorf("az-configuration[%d]: iaas configuration %v does not exist", i, name)
+		}
+
+		delete(az, "iaas_configuration_name")
+		az["iaas_configuration_guid"] = guid
+	}
+
+	contents, err := json.Marshal(azs)
+	if err != nil {
+		return nil, err // cannot be tested
+	}
+
+	return contents, nil
+}
+
+// configureVMExtensions runs before the resource configuration, which may
+// refer to the VM extensions by name.
+func (c ConfigureDirector) configureVMExtensions() error {
+	var vmExtensions []api.VMExtension
+	err := json.Unmarshal([]byte(c.Options.VMExtensionsConfiguration), &vmExtensions)
+	if err != nil {
+		return fmt.Errorf("could not decode vmextensions-configuration json: %s", err)
+	}
+
+	for i, vmExtension := range vmExtensions {
+		if vmExtension.Name == "" {
+			return fmt.Errorf("vmextensions-configuration[%d]: name is missing", i)
+		}
+	}
+
+	c.logger.Printf("started configuring vm extensions for bosh tile")
+
+	for _, vmExtension := range vmExtensions {
+		if len(vmExtension.CloudProperties) == 0 {
+			vmExtension.CloudProperties = json.RawMessage("{}")
+		}
+
+		err = c.vmExtensionsService.CreateVMExtension(vmExtension)
+		if err != nil {
+			return fmt.Errorf("vm extension %s could not be applied: %s", vmExtension.Name, err)
+		}
+	}
+
+	c.logger.Printf("finished configuring vm extensions for bosh tile")
+
+	return nil
+}
+
+func (c ConfigureDirector) configureResources() error {
+	var userProvidedConfig map[string]json.RawMessage
+	err := json.Unmarshal([]byte(c.Options.ResourceConfiguration), &userProvidedConfig)
+	if err != nil {
+		return fmt.Errorf("could not decode resource-configuration json: %s", err)
+	}
+
+	stagedProducts, err := c.stagedProductsService.StagedProducts()
+	if err != nil {
+		return fmt.Errorf("failed to fetch staged products: %s", err)
+	}
+
+	var directorGUID string
+	for _, product := range stagedProducts.Products {
+		if product.Type == boshProductName {
+			directorGUID = product.GUID
+		}
+	}
+
+	if directorGUID == "" {
+		return errors.New("could not find the director among the staged products")
+	}
+
+	jobs, err := c.jobsService.Jobs(directorGUID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch jobs: %s", err)
+	}
+
+	var names []string
+	for name := range userProvidedConfig {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, ok := jobs[name]; !ok {
+			return fmt.Errorf("resource-configuration.%s: unknown job", name)
+		}
+	}
+
+	c.logger.Printf("started configuring resource options for bosh tile")
+
+	for _, name := range names {
+		jobProperties, err := c.jobsService.GetExistingJobConfig(directorGUID, jobs[name])
+		if err != nil {
+			return fmt.Errorf("could not fetch existing job configuration: %s", err)
+		}
+
+		err = json.Unmarshal(userProvidedConfig[name], &jobProperties)
+		if err != nil {
+			return fmt.Errorf("could not decode resource-configuration json: %s", err)
+		}
+
+		err = c.jobsService.ConfigureJob(directorGUID, jobs[name], jobProperties)
+		if err != nil {
+			return fmt.Errorf("failed to configure resources: %s", err)
+		}
+	}
+
+	c.logger.Printf("finished configuring resource options for bosh tile")
+
+	return nil
+}
+
+// applyConfigFile fills in the options that were not given as flags from the
+// sections of the config file.
+func (c *ConfigureDirector) applyConfigFile() error {
+	var config map[string]interface{}
+	err := loadConfigFile(c.Options.ConfigFile, c.Options.VarsFile, &config)
+	if err != nil {
+		return err
+	}
+
+	options := map[string]*string{
+		"az-configuration":           &c.Options.AZConfiguration,
+		"networks-configuration":     &c.Options.NetworksConfiguration,
+		"network-assignment":         &c.Options.NetworkAssignment,
+		"director-configuration":     &c.Options.DirectorConfiguration,
+		"iaas-configuration":         &c.Options.IAASConfiguration,
+		"iaas-configurations":        &c.Options.IAASConfigurations,
+		"security-configuration":     &c.Options.SecurityConfiguration,
+		"syslog-configuration":       &c.Options.SyslogConfiguration,
+		"vmextensions-configuration": &c.Options.VMExtensionsConfiguration,
+		"resource-configuration":     &c.Options.ResourceConfiguration,
+	}
+
+	var problems []string
+	for _, section := range sortedKeys(config) {
+		option, ok := options[section]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: unknown section, expected one of %s", section, strings.Join(directorConfigurationSections, ", ")))
+			continue
+		}
+
+		if *option != "" {
+			continue
+		}
+
+		contents, err := json.Marshal(jsonCompatible(config[section]))
+		if err != nil {
+			return fmt.Errorf("could not convert config file section to json: %s", err)
+		}
+
+		*option = string(contents)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("config file is invalid:\n%s", strings.Join(problems, "\n"))
+	}
+
+	return nil
+}
+
+func (c ConfigureDirector) stagedAZNames() ([]string, error) {
+	availabilityZones, err := c.service.AvailabilityZones()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, az := range availabilityZones {
+		names = append(names, az.Name)
+	}
+
+	return names, nil
+}
+
 func (c ConfigureDirector) Usage() commands.Usage {
 	return commands.Usage{
 		Description:      "This authenticated command configures the director.",
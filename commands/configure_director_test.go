@@ -3,6 +3,9 @@ package commands_test
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -13,15 +16,38 @@ import (
 
 var _ = Describe("ConfigureDirector", func() {
 	var (
-		directorService *fakes.DirectorService
-		command         commands.ConfigureDirector
-		logger          *fakes.Logger
+		directorService       *fakes.DirectorService
+		jobsService           *fakes.JobsConfigurer
+		stagedProductsService *fakes.StagedProductsLister
+		vmExtensionsService   *fakes.VMExtensionCreator
+		command               commands.ConfigureDirector
+		logger                *fakes.Logger
 	)
 
 	BeforeEach(func() {
 		directorService = &fakes.DirectorService{}
+		jobsService = &fakes.JobsConfigurer{}
+		stagedProductsService = &fakes.StagedProductsLister{}
+		vmExtensionsService = &fakes.VMExtensionCreator{}
 		logger = &fakes.Logger{}
-		command = commands.NewConfigureDirector(directorService, logger)
+		command = commands.NewConfigureDirector(directorService, jobsService, stagedProductsService, vmExtensionsService, logger)
+
+		stagedProductsService.StagedProductsReturns(api.StagedProductsOutput{
+			Products: []api.StagedProduct{
+				{GUID: "some-cf-guid", Type: "cf"},
+				{GUID: "some-director-guid", Type: "p-bosh"},
+			},
+		}, nil)
+
+		jobsService.JobsReturns(map[string]string{
+			"director":    "some-director-job-guid",
+			"compilation": "some-compilation-job-guid",
+		}, nil)
+
+		jobsService.GetExistingJobConfigReturns(api.JobProperties{
+			Instances:    float64(1),
+			InstanceType: api.InstanceType{ID: "automatic"},
+		}, nil)
 	})
 
 	Describe("Execute", func() {
@@ -74,6 +100,270 @@ var _ = Describe("ConfigureDirector", func() {
 			Expect(logger.PrintfArgsForCall(7)).To(Equal("finished configuring director options for bosh tile"))
 		})
 
+		It("configures the vm extensions and the resources of the director jobs", func() {
+			err := command.Execute([]string{
+				"--vmextensions-configuration", `[{"name": "some-extension", "cloud_properties": {"some": "property"}}, {"name": "other-extension"}]`,
+				"--resource-configuration", `{"director": {"instance_type": {"id": "m1.large"}, "additional_vm_extensions": ["some-extension"]}, "compilation": {"instances": 4}}`,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(vmExtensionsService.CreateVMExtensionCallCount()).To(Equal(2))
+			Expect(vmExtensionsService.CreateVMExtensionArgsForCall(0)).To(Equal(api.VMExtension{
+				Name:            "some-extension",
+				CloudProperties: json.RawMessage(`{"some": "property"}`),
+			}))
+			Expect(vmExtensionsService.CreateVMExtensionArgsForCall(1)).To(Equal(api.VMExtension{
+				Name:            "other-extension",
+				CloudProperties: json.RawMessage(`{}`),
+			}))
+
+			Expect(jobsService.JobsArgsForCall(0)).To(Equal("some-director-guid"))
+			Expect(jobsService.ConfigureJobCallCount()).To(Equal(2))
+
+			productGUID, jobGUID, jobProperties := jobsService.ConfigureJobArgsForCall(0)
+			Expect(productGUID).To(Equal("some-director-guid"))
+			Expect(jobGUID).To(Equal("some-compilation-job-guid"))
+			Expect(jobProperties.Instances).To(Equal(float64(4)))
+			Expect(jobProperties.InstanceType).To(Equal(api.InstanceType{ID: "automatic"}))
+
+			productGUID, jobGUID, jobProperties = jobsService.ConfigureJobArgsForCall(1)
+			Expect(productGUID).To(Equal("some-director-guid"))
+			Expect(jobGUID).To(Equal("some-director-job-guid"))
+			Expect(jobProperties.Instances).To(Equal(float64(1)))
+			Expect(jobProperties.InstanceType).To(Equal(api.InstanceType{ID: "m1.large"}))
+			Expect(jobProperties.Extra).To(Equal(map[string]json.RawMessage{
+				"additional_vm_extensions": json.RawMessage(`["some-extension"]`),
+			}))
+
+			Expect(logger.PrintfCallCount()).To(Equal(6))
+			Expect(logger.PrintfArgsForCall(2)).To(Equal("started configuring vm extensions for bosh tile"))
+			Expect(logger.PrintfArgsForCall(3)).To(Equal("finished configuring vm extensions for bosh tile"))
+			Expect(logger.PrintfArgsForCall(4)).To(Equal("started configuring resource options for bosh tile"))
+			Expect(logger.PrintfArgsForCall(5)).To(Equal("finished configuring resource options for bosh tile"))
+		})
+
+		Context("when a config file is provided", func() {
+			var (
+				configFile *os.File
+				varsFile   *os.File
+			)
+
+			BeforeEach(func() {
+				var err error
+				configFile, err = ioutil.TempFile("", "director.yml")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = configFile.WriteString(`---
+az-configuration:
+- name: ((az_name))
+director-configuration:
+  ntp_servers_string: us.pool.ntp.org
+vmextensions-configuration:
+- name: some-extension
+  cloud_properties:
+    some: property
+resource-configuration:
+  director:
+    instances: 1
+    persistent_disk:
+      size_mb: "102400"
+`)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(configFile.Close()).To(Succeed())
+
+				varsFile, err = ioutil.TempFile("", "vars.yml")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = varsFile.WriteString("az_name: us-east-1a\n")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(varsFile.Close()).To(Succeed())
+			})
+
+			AfterEach(func() {
+				os.Remove(configFile.Name())
+				os.Remove(varsFile.Name())
+			})
+
+			It("configures the director from the interpolated config file", func() {
+				err := command.Execute([]string{
+					"--config", configFile.Name(),
+					"--vars-file", varsFile.Name(),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(directorService.AZConfigurationArgsForCall(0).AvailabilityZones).To(MatchJSON(`[{"name": "us-east-1a"}]`))
+				Expect(directorService.PropertiesArgsForCall(0).DirectorConfiguration).To(MatchJSON(`{"ntp_servers_string": "us.pool.ntp.org"}`))
+
+				Expect(vmExtensionsService.CreateVMExtensionArgsForCall(0).CloudProperties).To(MatchJSON(`{"some": "property"}`))
+
+				_, jobGUID, jobProperties := jobsService.ConfigureJobArgsForCall(0)
+				Expect(jobGUID).To(Equal("some-director-job-guid"))
+				Expect(jobProperties.PersistentDisk).To(Equal(&api.Disk{Size: "102400"}))
+			})
+
+			It("prefers values provided as flags", func() {
+				err := command.Execute([]string{
+					"--config", configFile.Name(),
+					"--vars-file", varsFile.Name(),
+					"--director-configuration", `{"some-director-assignment": "director"}`,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(directorService.PropertiesArgsForCall(0).DirectorConfiguration).To(Equal(json.RawMessage(`{"some-director-assignment": "director"}`)))
+			})
+
+			Context("when the config file has an unknown section", func() {
+				It("returns an error", func() {
+					err := ioutil.WriteFile(configFile.Name(), []byte("resource-config: {}\n"), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError(`config file is invalid:
+resource-config: unknown section, expected one of iaas-configurations, az-configuration, networks-configuration, network-assignment, director-configuration, iaas-configuration, security-configuration, syslog-configuration, vmextensions-configuration, resource-configuration`))
+					Expect(directorService.PropertiesCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the config file does not exist", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--config", "/not/a/real/file.yml"})
+					Expect(err).To(MatchError(ContainSubstring("could not read config file:")))
+				})
+			})
+		})
+
+		Context("when iaas configurations are provided", func() {
+			BeforeEach(func() {
+				directorService.IAASConfigurationsReturns([]api.IAASConfiguration{
+					{GUID: "vcenter-1-guid", Name: "vcenter-1"},
+					{GUID: "old-vcenter-guid", Name: "old-vcenter"},
+				}, nil)
+			})
+
+			It("creates, updates and deletes the iaas configurations by name", func() {
+				err := command.Execute([]string{
+					"--iaas-configurations", `[
+						{"name": "vcenter-1", "vcenter_host": "vcenter-1.example.com"},
+						{"name": "vcenter-2", "vcenter_host": "vcenter-2.example.com"}
+					]`,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(directorService.UpdateIAASConfigurationCallCount()).To(Equal(1))
+				guid, properties := directorService.UpdateIAASConfigurationArgsForCall(0)
+				Expect(guid).To(Equal("vcenter-1-guid"))
+				Expect(properties).To(MatchJSON(`{"name": "vcenter-1", "vcenter_host": "vcenter-1.example.com"}`))
+
+				Expect(directorService.CreateIAASConfigurationCallCount()).To(Equal(1))
+				Expect(directorService.CreateIAASConfigurationArgsForCall(0)).To(MatchJSON(`{"name": "vcenter-2", "vcenter_host": "vcenter-2.example.com"}`))
+
+				Expect(directorService.DeleteIAASConfigurationCallCount()).To(Equal(1))
+				Expect(directorService.DeleteIAASConfigurationArgsForCall(0)).To(Equal("old-vcenter-guid"))
+
+				Expect(directorService.PropertiesArgsForCall(0).IAASConfiguration).To(BeEmpty())
+
+				Expect(logger.PrintfArgsForCall(0)).To(Equal("started configuring iaas configurations for bosh tile"))
+				Expect(logger.PrintfArgsForCall(1)).To(Equal("finished configuring iaas configurations for bosh tile"))
+
+				format, content := logger.PrintfArgsForCall(2)
+				Expect(fmt.Sprintf(format, content...)).To(Equal("deleting iaas configuration old-vcenter"))
+			})
+
+			It("deletes the unlisted iaas configurations after the availability zones stop referring to them", func() {
+				var calls []string
+				directorService.AZConfigurationStub = func(api.AZConfiguration) error {
+					calls = append(calls, "az-configuration")
+					return nil
+				}
+				directorService.DeleteIAASConfigurationStub = func(guid string) error {
+					calls = append(calls, "delete "+guid)
+					return nil
+				}
+
+				err := command.Execute([]string{
+					"--iaas-configurations", `[{"name": "vcenter-1"}]`,
+					"--az-configuration", `[{"name": "az-1", "iaas_configuration_name": "vcenter-1"}]`,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(calls).To(Equal([]string{"az-configuration", "delete old-vcenter-guid"}))
+			})
+
+			It("refers availability zones to the iaas configurations by name", func() {
+				err := command.Execute([]string{
+					"--az-configuration", `[
+						{"name": "az-1", "iaas_configuration_name": "vcenter-1", "cluster": "cluster-1"},
+						{"name": "az-2", "cluster": "cluster-2"}
+					]`,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(directorService.AZConfigurationArgsForCall(0).AvailabilityZones).To(MatchJSON(`[
+					{"name": "az-1", "iaas_configuration_guid": "vcenter-1-guid", "cluster": "cluster-1"},
+					{"name": "az-2", "cluster": "cluster-2"}
+				]`))
+			})
+
+			Context("when an availability zone refers to an unknown iaas configuration", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{
+						"--az-configuration", `[{"name": "az-1", "iaas_configuration_name": "vcenter-3"}]`,
+					})
+					Expect(err).To(MatchError("az-configuration[0]: iaas configuration vcenter-3 does not exist"))
+					Expect(directorService.AZConfigurationCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the iaas-configuration is also provided", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{
+						"--iaas-configuration", `{"vcenter_host": "vcenter-1.example.com"}`,
+						"--iaas-configurations", `[{"name": "vcenter-1"}]`,
+					})
+					Expect(err).To(MatchError("iaas-configuration and iaas-configurations cannot be given together"))
+				})
+			})
+
+			Context("when an iaas configuration has no name", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--iaas-configurations", `[{"vcenter_host": "vcenter-1.example.com"}]`})
+					Expect(err).To(MatchError("iaas-configurations[0]: name is missing"))
+					Expect(directorService.CreateIAASConfigurationCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when a name is used more than once", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--iaas-configurations", `[{"name": "vcenter-1"}, {"name": "vcenter-1"}]`})
+					Expect(err).To(MatchError("iaas-configurations[1]: name vcenter-1 is used more than once"))
+				})
+			})
+
+			Context("when the iaas configurations cannot be fetched", func() {
+				It("returns an error", func() {
+					directorService.IAASConfigurationsReturns(nil, errors.New("iaas configurations endpoint failed"))
+					err := command.Execute([]string{"--iaas-configurations", `[{"name": "vcenter-1"}]`})
+					Expect(err).To(MatchError("failed to fetch iaas configurations: iaas configurations endpoint failed"))
+				})
+			})
+
+			Context("when creating an iaas configuration fails", func() {
+				It("returns an error", func() {
+					directorService.CreateIAASConfigurationReturns(errors.New("iaas configurations endpoint failed"))
+					err := command.Execute([]string{"--iaas-configurations", `[{"name": "vcenter-2"}]`})
+					Expect(err).To(MatchError("iaas configuration vcenter-2 could not be applied: iaas configurations endpoint failed"))
+				})
+			})
+
+			Context("when deleting an iaas configuration fails", func() {
+				It("returns an error", func() {
+					directorService.DeleteIAASConfigurationReturns(errors.New("iaas configurations endpoint failed"))
+					err := command.Execute([]string{"--iaas-configurations", `[{"name": "vcenter-1"}]`})
+					Expect(err).To(MatchError("iaas configuration old-vcenter could not be deleted: iaas configurations endpoint failed"))
+				})
+			})
+		})
+
 		Context("when no director configuration flags are provided", func() {
 			It("only calls the properties function once", func() {
 				err := command.Execute([]string{})
@@ -115,6 +405,87 @@ var _ = Describe("ConfigureDirector", func() {
 				})
 			})
 
+			Context("when the networks configuration is invalid", func() {
+				It("returns every problem without applying anything", func() {
+					err := command.Execute([]string{
+						"--az-configuration", `[{"name": "az-1"}]`,
+						"--networks-configuration", `{
+							"networks": [
+								{
+									"name": "network-1",
+									"subnets": [
+										{
+											"cidr": "10.0.0.0/24",
+											"gateway": "10.0.1.1",
+											"reserved_ip_ranges": "10.0.0.1-10.0.0.9,10.0.0.20-10.0.0.10,10.0.2.1",
+											"availability_zone_names": ["az-1", "az-2"]
+										},
+										{"cidr": "10.0.0.128/25"},
+										{"cidr": "not-a-cidr"}
+									]
+								},
+								{"name": "network-1"},
+								{}
+							]
+						}`,
+					})
+					Expect(err).To(MatchError(`networks-configuration is invalid:
+networks-configuration.networks[0].subnets[0]: gateway 10.0.1.1 is not in cidr 10.0.0.0/24
+networks-configuration.networks[0].subnets[0]: reserved ip range 10.0.0.20-10.0.0.10 ends before it starts
+networks-configuration.networks[0].subnets[0]: reserved ip range 10.0.2.1 is not in cidr 10.0.0.0/24
+networks-configuration.networks[0].subnets[1]: cidr 10.0.0.128/25 overlaps with 10.0.0.0/24 of networks-configuration.networks[0].subnets[0]
+networks-configuration.networks[0].subnets[2]: cidr "not-a-cidr" is not a valid cidr
+networks-configuration.networks[1]: name network-1 is used more than once
+networks-configuration.networks[2]: name is missing
+networks-configuration.networks[0].subnets[0]: availability zone az-2 is neither in the az-configuration nor staged`))
+
+					Expect(directorService.AvailabilityZonesCallCount()).To(Equal(1))
+					Expect(directorService.AZConfigurationCallCount()).To(Equal(0))
+					Expect(directorService.NetworksConfigurationCallCount()).To(Equal(0))
+					Expect(directorService.PropertiesCallCount()).To(Equal(0))
+				})
+
+				It("accepts availability zones that are already staged", func() {
+					directorService.AvailabilityZonesReturns([]api.AvailabilityZone{
+						{GUID: "some-az-guid", Name: "staged-az"},
+					}, nil)
+
+					err := command.Execute([]string{
+						"--networks-configuration", `{
+							"networks": [{
+								"name": "network-1",
+								"subnets": [{
+									"cidr": "10.0.0.0/24",
+									"gateway": "10.0.0.1",
+									"reserved_ip_ranges": "10.0.0.1-10.0.0.9",
+									"availability_zone_names": ["staged-az"]
+								}]
+							}]
+						}`,
+					})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(directorService.NetworksConfigurationCallCount()).To(Equal(1))
+				})
+
+				It("does not fetch the staged availability zones when it does not need to", func() {
+					err := command.Execute([]string{
+						"--az-configuration", `[{"name": "az-1"}]`,
+						"--networks-configuration", `{"networks": [{"name": "network-1", "subnets": [{"cidr": "10.0.0.0/24", "availability_zone_names": ["az-1"]}]}]}`,
+					})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(directorService.AvailabilityZonesCallCount()).To(Equal(0))
+				})
+
+				It("returns an error when the staged availability zones cannot be fetched", func() {
+					directorService.AvailabilityZonesReturns(nil, errors.New("availability zones endpoint failed"))
+
+					err := command.Execute([]string{
+						"--networks-configuration", `{"networks": [{"name": "network-1", "subnets": [{"cidr": "10.0.0.0/24", "availability_zone_names": ["az-1"]}]}]}`,
+					})
+					Expect(err).To(MatchError("failed to fetch availability zones: availability zones endpoint failed"))
+				})
+			})
+
 			Context("when configuring networks fails", func() {
 				It("returns an error", func() {
 					directorService.NetworkAndAZReturns(errors.New("director service failed"))
@@ -130,6 +501,60 @@ var _ = Describe("ConfigureDirector", func() {
 					Expect(err).To(MatchError("properties could not be applied: properties end point failed"))
 				})
 			})
+
+			Context("when the vmextensions-configuration is not valid JSON", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--vmextensions-configuration", `{`})
+					Expect(err).To(MatchError(ContainSubstring("could not decode vmextensions-configuration json:")))
+				})
+			})
+
+			Context("when a vm extension has no name", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--vmextensions-configuration", `[{"cloud_properties": {}}]`})
+					Expect(err).To(MatchError("vmextensions-configuration[0]: name is missing"))
+					Expect(vmExtensionsService.CreateVMExtensionCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when creating a vm extension fails", func() {
+				It("returns an error", func() {
+					vmExtensionsService.CreateVMExtensionReturns(errors.New("vm extensions endpoint failed"))
+					err := command.Execute([]string{"--vmextensions-configuration", `[{"name": "some-extension"}]`})
+					Expect(err).To(MatchError("vm extension some-extension could not be applied: vm extensions endpoint failed"))
+				})
+			})
+
+			Context("when the resource-configuration is not valid JSON", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--resource-configuration", `{`})
+					Expect(err).To(MatchError(ContainSubstring("could not decode resource-configuration json:")))
+				})
+			})
+
+			Context("when the director is not staged", func() {
+				It("returns an error", func() {
+					stagedProductsService.StagedProductsReturns(api.StagedProductsOutput{}, nil)
+					err := command.Execute([]string{"--resource-configuration", `{"director": {}}`})
+					Expect(err).To(MatchError("could not find the director among the staged products"))
+				})
+			})
+
+			Context("when the resource-configuration names an unknown job", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--resource-configuration", `{"director": {}, "router": {}}`})
+					Expect(err).To(MatchError("resource-configuration.router: unknown job"))
+					Expect(jobsService.ConfigureJobCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when configuring a job fails", func() {
+				It("returns an error", func() {
+					jobsService.ConfigureJobReturns(errors.New("resource config endpoint failed"))
+					err := command.Execute([]string{"--resource-configuration", `{"director": {"instances": 1}}`})
+					Expect(err).To(MatchError("failed to configure resources: resource config endpoint failed"))
+				})
+			})
 		})
 	})
 
@@ -3,30 +3,58 @@ package commands
 import (
 	"encoding/json"
 	"fmt"
-	"reflect"
+	"io/ioutil"
+	"regexp"
 	"sort"
 
 	"github.com/pivotal-cf/jhanda/commands"
 	"github.com/pivotal-cf/jhanda/flags"
 	"github.com/pivotal-cf/om/api"
+	yaml "gopkg.in/yaml.v2"
 )
 
 type ConfigureProduct struct {
 	productsService productConfigurer
 	jobsService     jobsConfigurer
+	errandsService  errandsService
+	extractor       metadataExtractor
 	logger          logger
 	Options         struct {
 		ProductName       string `short:"n"  long:"product-name" description:"name of the product being configured"`
 		ProductProperties string `short:"p" long:"product-properties" description:"properties to be configured in JSON format" default:""`
 		NetworkProperties string `short:"pn" long:"product-network" description:"network properties in JSON format" default:""`
 		ProductResources  string `short:"pr" long:"product-resources" description:"resource configurations in JSON format" default:"{}"`
+		MaxInFlight       string `long:"max-in-flight" description:"max in flight per job in JSON format, as a number of instances, a percentage or \"default\""`
+		SyslogProperties  string `long:"syslog-properties" description:"syslog forwarding properties in JSON format"`
+		ErrandConfig      string `long:"errand-config" description:"post-deploy-state and pre-delete-state of errands, by errand name, in JSON format"`
+		ConfigFile        string `short:"c" long:"config" description:"path to yml file containing product-name, product-properties, network-properties, resource-config, max-in-flight, syslog-properties and errand-config"`
+		VarsFile          string `long:"vars-file" description:"path to yml file containing values for ((placeholders)) in the config file"`
+		ProductFile       string `long:"product-file" description:"path to the product file, used to validate the configuration before it is applied"`
 	}
 }
 
+type productConfiguration struct {
+	ProductName       string                 `yaml:"product-name"`
+	ProductProperties map[string]interface{} `yaml:"product-properties,omitempty"`
+	NetworkProperties map[string]interface{} `yaml:"network-properties,omitempty"`
+	ResourceConfig    map[string]interface{} `yaml:"resource-config,omitempty"`
+	MaxInFlight       map[string]interface{} `yaml:"max-in-flight,omitempty"`
+	SyslogProperties  map[string]interface{} `yaml:"syslog-properties,omitempty"`
+	ErrandConfig      map[string]interface{} `yaml:"errand-config,omitempty"`
+}
+
+var maxInFlightPercentageRegexp = regexp.MustCompile(`^[1-9][0-9]?%$|^100%$`)
+
 //go:generate counterfeiter -o ./fakes/product_configurer.go --fake-name ProductConfigurer . productConfigurer
 type productConfigurer interface {
 	StagedProducts() (api.StagedProductsOutput, error)
+	Properties(productGUID string) (map[string]api.ResponseProperty, error)
+	NetworksAndAZs(productGUID string) (map[string]interface{}, error)
 	Configure(api.ProductsConfigurationInput) error
+	MaxInFlight(productGUID string) (map[string]interface{}, error)
+	ConfigureMaxInFlight(productGUID string, maxInFlight map[string]interface{}) error
+	SyslogConfiguration(productGUID string) (map[string]interface{}, error)
+	ConfigureSyslog(productGUID string, syslogConfiguration map[string]interface{}) error
 }
 
 //go:generate counterfeiter -o ./fakes/jobs_configurer.go --fake-name JobsConfigurer . jobsConfigurer
@@ -36,10 +64,12 @@ type jobsConfigurer interface {
 	ConfigureJob(productGUID, jobGUID string, jobProperties api.JobProperties) error
 }
 
-func NewConfigureProduct(productConfigurer productConfigurer, jobsConfigurer jobsConfigurer, logger logger) ConfigureProduct {
+func NewConfigureProduct(productConfigurer productConfigurer, jobsConfigurer jobsConfigurer, errandsService errandsService, metadataExtractor metadataExtractor, logger logger) ConfigureProduct {
 	return ConfigureProduct{
 		productsService: productConfigurer,
 		jobsService:     jobsConfigurer,
+		errandsService:  errandsService,
+		extractor:       metadataExtractor,
 		logger:          logger,
 	}
 }
@@ -50,28 +80,35 @@ func (cp ConfigureProduct) Execute(args []string) error {
 		return fmt.Errorf("could not parse configure-product flags: %s", err)
 	}
 
+	if cp.Options.ConfigFile != "" {
+		err = cp.applyConfigFile()
+		if err != nil {
+			return err
+		}
+	}
+
 	if cp.Options.ProductName == "" {
 		return fmt.Errorf("error: product-name is missing. Please see usage for more information.")
 	}
 
 	cp.logger.Printf("configuring product...")
 
-	if cp.Options.ProductProperties == "" && cp.Options.NetworkProperties == "" && cp.Options.ProductResources == "{}" {
+	if cp.Options.ProductProperties == "" && cp.Options.NetworkProperties == "" && cp.Options.ProductResources == "{}" &&
+		cp.Options.MaxInFlight == "" && cp.Options.SyslogProperties == "" && cp.Options.ErrandConfig == "" {
 		cp.logger.Printf("Provided properties are empty, nothing to do here")
 		return nil
 	}
 
-	stagedProducts, err := cp.productsService.StagedProducts()
-	if err != nil {
-		return err
+	if cp.Options.ProductFile != "" {
+		err = cp.validateAgainstProductFile()
+		if err != nil {
+			return err
+		}
 	}
 
-	var productGUID string
-	for _, sp := range stagedProducts.Products {
-		if sp.Type == cp.Options.ProductName {
-			productGUID = sp.GUID
-			break
-		}
+	productGUID, err := cp.stagedProductGUID()
+	if err != nil {
+		return err
 	}
 
 	if productGUID == "" {
@@ -79,6 +116,11 @@ func (cp ConfigureProduct) Execute(args []string) error {
 	}
 
 	if cp.Options.ProductProperties != "" {
+		err = cp.validateAgainstStagedProperties(productGUID)
+		if err != nil {
+			return err
+		}
+
 		cp.logger.Printf("setting properties")
 		err = cp.productsService.Configure(api.ProductsConfigurationInput{
 			GUID:          productGUID,
@@ -134,7 +176,7 @@ func (cp ConfigureProduct) Execute(args []string) error {
 				return err
 			}
 
-			if !reflect.DeepEqual(jobProperties, api.JobProperties{}) {
+			if !jobProperties.IsZero() {
 				err = cp.jobsService.ConfigureJob(productGUID, jobs[name], jobProperties)
 				if err != nil {
 					return fmt.Errorf("failed to configure resources: %s", err)
@@ -143,11 +185,397 @@ func (cp ConfigureProduct) Execute(args []string) error {
 		}
 	}
 
+	if cp.Options.MaxInFlight != "" {
+		err = cp.configureMaxInFlight(productGUID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cp.Options.SyslogProperties != "" {
+		err = cp.configureSyslog(productGUID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cp.Options.ErrandConfig != "" {
+		err = cp.configureErrands(productGUID)
+		if err != nil {
+			return err
+		}
+	}
+
 	cp.logger.Printf("finished configuring product")
 
 	return nil
 }
 
+// stagedProductGUID returns the GUID of the staged product, or an empty string
+// when the product is not staged.
+func (cp ConfigureProduct) stagedProductGUID() (string, error) {
+	stagedProducts, err := cp.productsService.StagedProducts()
+	if err != nil {
+		return "", err
+	}
+
+	for _, sp := range stagedProducts.Products {
+		if sp.Type == cp.Options.ProductName {
+			return sp.GUID, nil
+		}
+	}
+
+	return "", nil
+}
+
+func (cp ConfigureProduct) configureMaxInFlight(productGUID string) error {
+	var maxInFlight map[string]interface{}
+	err := json.Unmarshal([]byte(cp.Options.MaxInFlight), &maxInFlight)
+	if err != nil {
+		return fmt.Errorf("could not decode max-in-flight json: %s", err)
+	}
+
+	jobs, err := cp.jobsService.Jobs(productGUID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch jobs: %s", err)
+	}
+
+	var problems []string
+	jobMaxInFlight := map[string]interface{}{}
+	for _, name := range sortedKeys(maxInFlight) {
+		jobGUID, ok := jobs[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("max-in-flight.%s: unknown job", name))
+			continue
+		}
+
+		value, ok := maxInFlightValue(maxInFlight[name])
+		if !ok {
+			problems = append(problems, fmt.Sprintf(`max-in-flight.%s: expected a number of instances, a percentage or "default", got %s`, name, describeValue(maxInFlight[name])))
+			continue
+		}
+
+		jobMaxInFlight[jobGUID] = value
+	}
+
+	err = validationError(problems)
+	if err != nil {
+		return err
+	}
+
+	stagedMaxInFlight, err := cp.productsService.MaxInFlight(productGUID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch max in flight: %s", err)
+	}
+
+	if jsonContains(stagedMaxInFlight, jobMaxInFlight) {
+		cp.logger.Printf("max in flight has not changed, skipping")
+		return nil
+	}
+
+	cp.logger.Printf("setting max in flight")
+	err = cp.productsService.ConfigureMaxInFlight(productGUID, jobMaxInFlight)
+	if err != nil {
+		return fmt.Errorf("failed to configure max in flight: %s", err)
+	}
+	cp.logger.Printf("finished setting max in flight")
+
+	return nil
+}
+
+func (cp ConfigureProduct) configureSyslog(productGUID string) error {
+	var syslogProperties map[string]interface{}
+	err := json.Unmarshal([]byte(cp.Options.SyslogProperties), &syslogProperties)
+	if err != nil {
+		return fmt.Errorf("could not decode syslog-properties json: %s", err)
+	}
+
+	stagedSyslogProperties, err := cp.productsService.SyslogConfiguration(productGUID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch syslog properties: %s", err)
+	}
+
+	if jsonContains(stagedSyslogProperties, syslogProperties) {
+		cp.logger.Printf("syslog properties have not changed, skipping")
+		return nil
+	}
+
+	cp.logger.Printf("setting syslog properties")
+	err = cp.productsService.ConfigureSyslog(productGUID, syslogProperties)
+	if err != nil {
+		return fmt.Errorf("failed to configure syslog properties: %s", err)
+	}
+	cp.logger.Printf("finished setting syslog properties")
+
+	return nil
+}
+
+// configureErrands sets the state of every errand in the errand config with a
+// single request, so that errands that are not mentioned keep their state.
+func (cp ConfigureProduct) configureErrands(productGUID string) error {
+	var errandConfig map[string]interface{}
+	err := json.Unmarshal([]byte(cp.Options.ErrandConfig), &errandConfig)
+	if err != nil {
+		return fmt.Errorf("could not decode errand-config json: %s", err)
+	}
+
+	errandsOutput, err := cp.errandsService.List(productGUID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch errands: %s", err)
+	}
+
+	var names []string
+	for _, errand := range errandsOutput.Errands {
+		names = append(names, errand.Name)
+	}
+
+	var problems []string
+	var errands []api.Errand
+	for _, name := range sortedKeys(errandConfig) {
+		if !contains(names, name) {
+			problems = append(problems, fmt.Sprintf("errand-config.%s: unknown errand", name))
+			continue
+		}
+
+		states, ok := errandConfig[name].(map[string]interface{})
+		if !ok {
+			problems = append(problems, fmt.Sprintf("errand-config.%s: expected a map of post-deploy-state and pre-delete-state, got %s", name, describeValue(errandConfig[name])))
+			continue
+		}
+
+		errand := api.Errand{Name: name}
+		for _, field := range sortedKeys(states) {
+			state, ok := errandStateValue(states[field])
+			if !ok {
+				problems = append(problems, fmt.Sprintf(`errand-config.%s.%s: expected true, false, "enabled", "disabled", "when-changed" or "default", got %s`, name, field, describeValue(states[field])))
+				continue
+			}
+
+			switch field {
+			case "post-deploy-state":
+				errand.PostDeploy = state
+			case "pre-delete-state":
+				errand.PreDelete = state
+			default:
+				problems = append(problems, fmt.Sprintf("errand-config.%s.%s: unknown field, expected post-deploy-state or pre-delete-state", name, field))
+			}
+		}
+
+		errands = append(errands, errand)
+	}
+
+	err = validationError(problems)
+	if err != nil {
+		return err
+	}
+
+	cp.logger.Printf("setting errand states")
+	err = cp.errandsService.SetStates(productGUID, errands)
+	if err != nil {
+		return fmt.Errorf("failed to set errand states: %s", err)
+	}
+	cp.logger.Printf("finished setting errand states")
+
+	return nil
+}
+
+// errandStateValue normalizes an errand state, which is either a boolean or
+// one of the states that set-errand-state accepts.
+func errandStateValue(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		state, ok := userToOMInputs[v]
+		return state, ok
+	}
+
+	return nil, false
+}
+
+// maxInFlightValue normalizes a max in flight value, which is either a
+// positive whole number of instances, a percentage such as "20%" or "default".
+func maxInFlightValue(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case float64:
+		if v >= 1 && v == float64(int(v)) {
+			return int(v), true
+		}
+	case string:
+		if v == "default" || maxInFlightPercentageRegexp.MatchString(v) {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
+
+func (cp *ConfigureProduct) applyConfigFile() error {
+	config, err := loadProductConfiguration(cp.Options.ConfigFile, cp.Options.VarsFile)
+	if err != nil {
+		return err
+	}
+
+	if cp.Options.ProductName == "" {
+		cp.Options.ProductName = config.ProductName
+	}
+
+	if cp.Options.ProductProperties == "" && len(config.ProductProperties) > 0 {
+		cp.Options.ProductProperties, err = encodeJSON(config.ProductProperties)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cp.Options.NetworkProperties == "" && len(config.NetworkProperties) > 0 {
+		cp.Options.NetworkProperties, err = encodeJSON(config.NetworkProperties)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cp.Options.ProductResources == "{}" && len(config.ResourceConfig) > 0 {
+		cp.Options.ProductResources, err = encodeJSON(config.ResourceConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cp.Options.MaxInFlight == "" && len(config.MaxInFlight) > 0 {
+		cp.Options.MaxInFlight, err = encodeJSON(config.MaxInFlight)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cp.Options.SyslogProperties == "" && len(config.SyslogProperties) > 0 {
+		cp.Options.SyslogProperties, err = encodeJSON(config.SyslogProperties)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cp.Options.ErrandConfig == "" && len(config.ErrandConfig) > 0 {
+		cp.Options.ErrandConfig, err = encodeJSON(config.ErrandConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cp ConfigureProduct) validateAgainstProductFile() error {
+	metadata, err := cp.extractor.ExtractProductMetadata(cp.Options.ProductFile)
+	if err != nil {
+		return fmt.Errorf("failed to extract product metadata: %s", err)
+	}
+
+	config := productConfiguration{ProductName: cp.Options.ProductName}
+
+	if cp.Options.ProductProperties != "" {
+		config.ProductProperties, err = cp.decodeProductProperties()
+		if err != nil {
+			return err
+		}
+	}
+
+	err = json.Unmarshal([]byte(cp.Options.ProductResources), &config.ResourceConfig)
+	if err != nil {
+		return fmt.Errorf("could not decode product-resource json: %s", err)
+	}
+
+	return validateProductConfiguration(metadata, config, false)
+}
+
+func (cp ConfigureProduct) validateAgainstStagedProperties(productGUID string) error {
+	properties, err := cp.decodeProductProperties()
+	if err != nil {
+		return err
+	}
+
+	stagedProperties, err := cp.productsService.Properties(productGUID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch product properties: %s", err)
+	}
+
+	return validationError(schemaFromProperties(stagedProperties).validate(properties))
+}
+
+func (cp ConfigureProduct) decodeProductProperties() (map[string]interface{}, error) {
+	var properties map[string]interface{}
+	err := json.Unmarshal([]byte(cp.Options.ProductProperties), &properties)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode product-properties json: %s", err)
+	}
+
+	return properties, nil
+}
+
+func loadProductConfiguration(configFile, varsFile string) (productConfiguration, error) {
+	var productConfig productConfiguration
+	err := loadConfigFile(configFile, varsFile, &productConfig)
+	if err != nil {
+		return productConfiguration{}, err
+	}
+
+	return productConfig, nil
+}
+
+// loadConfigFile reads a yml config file, fills in its ((placeholders)) from
+// the vars file and decodes the result into config.
+func loadConfigFile(configFile, varsFile string, config interface{}) error {
+	contents, err := ioutil.ReadFile(configFile)
+	if err != nil {
+		return fmt.Errorf("could not read config file: %s", err)
+	}
+
+	var document interface{}
+	err = yaml.Unmarshal(contents, &document)
+	if err != nil {
+		return fmt.Errorf("could not parse config file: %s", err)
+	}
+
+	vars := map[string]interface{}{}
+	if varsFile != "" {
+		contents, err = ioutil.ReadFile(varsFile)
+		if err != nil {
+			return fmt.Errorf("could not read vars file: %s", err)
+		}
+
+		err = yaml.Unmarshal(contents, &vars)
+		if err != nil {
+			return fmt.Errorf("could not parse vars file: %s", err)
+		}
+	}
+
+	document, err = interpolate(document, vars)
+	if err != nil {
+		return fmt.Errorf("could not interpolate config file: %s", err)
+	}
+
+	contents, err = yaml.Marshal(document)
+	if err != nil {
+		return err // cannot be tested
+	}
+
+	err = yaml.Unmarshal(contents, config)
+	if err != nil {
+		return fmt.Errorf("could not parse config file: %s", err)
+	}
+
+	return nil
+}
+
+func encodeJSON(section map[string]interface{}) (string, error) {
+	contents, err := json.Marshal(jsonCompatible(section))
+	if err != nil {
+		return "", fmt.Errorf("could not convert config file section to json: %s", err)
+	}
+
+	return string(contents), nil
+}
+
 func (cp ConfigureProduct) Usage() commands.Usage {
 	return commands.Usage{
 		Description:      "This authenticated command configures a staged product",
@@ -1,13 +1,17 @@
 package commands_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
 
 	jhandacommands "github.com/pivotal-cf/jhanda/commands"
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/commands"
 	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/extractor"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -51,19 +55,29 @@ const automaticResourceConfig = `{
 var _ = Describe("ConfigureProduct", func() {
 	Describe("Execute", func() {
 		var (
-			productsService *fakes.ProductConfigurer
-			jobsService     *fakes.JobsConfigurer
-			logger          *fakes.Logger
+			productsService   *fakes.ProductConfigurer
+			jobsService       *fakes.JobsConfigurer
+			errandsService    *fakes.ErrandsService
+			metadataExtractor *fakes.MetadataExtractor
+			logger            *fakes.Logger
 		)
 
 		BeforeEach(func() {
 			productsService = &fakes.ProductConfigurer{}
 			jobsService = &fakes.JobsConfigurer{}
+			errandsService = &fakes.ErrandsService{}
+			metadataExtractor = &fakes.MetadataExtractor{}
 			logger = &fakes.Logger{}
+
+			productsService.PropertiesReturns(map[string]api.ResponseProperty{
+				".properties.something":   {Type: "string", Configurable: true, Value: "something"},
+				".properties.credentials": {Type: "simple_credentials", Configurable: true, IsCredential: true, Value: map[string]interface{}{"identity": "***", "password": "***"}},
+				".a-job.job-property":     {Type: "simple_credentials", Configurable: true, IsCredential: true, Value: map[string]interface{}{"identity": "***", "password": "***"}},
+			}, nil)
 		})
 
 		It("configures a product's properties", func() {
-			client := commands.NewConfigureProduct(productsService, jobsService, logger)
+			client := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
 
 			productsService.StagedProductsReturns(api.StagedProductsOutput{
 				Products: []api.StagedProduct{
@@ -98,7 +112,7 @@ var _ = Describe("ConfigureProduct", func() {
 		})
 
 		It("configures a product's network", func() {
-			client := commands.NewConfigureProduct(productsService, jobsService, logger)
+			client := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
 
 			productsService.StagedProductsReturns(api.StagedProductsOutput{
 				Products: []api.StagedProduct{
@@ -132,8 +146,267 @@ var _ = Describe("ConfigureProduct", func() {
 			Expect(fmt.Sprintf(format, content...)).To(Equal("finished configuring product"))
 		})
 
+		It("configures the max in flight of the jobs that are provided", func() {
+			client := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+
+			productsService.StagedProductsReturns(api.StagedProductsOutput{
+				Products: []api.StagedProduct{
+					{GUID: "some-product-guid", Type: "cf"},
+				},
+			}, nil)
+			jobsService.JobsReturns(map[string]string{
+				"some-job":       "some-job-guid",
+				"some-other-job": "some-other-job-guid",
+				"some-errand":    "some-errand-guid",
+			}, nil)
+
+			err := client.Execute([]string{
+				"--product-name", "cf",
+				"--max-in-flight", `{"some-job": 2, "some-other-job": "20%", "some-errand": "default"}`,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(jobsService.JobsArgsForCall(0)).To(Equal("some-product-guid"))
+			Expect(productsService.ConfigureMaxInFlightCallCount()).To(Equal(1))
+			productGUID, maxInFlight := productsService.ConfigureMaxInFlightArgsForCall(0)
+			Expect(productGUID).To(Equal("some-product-guid"))
+			Expect(maxInFlight).To(Equal(map[string]interface{}{
+				"some-job-guid":       2,
+				"some-other-job-guid": "20%",
+				"some-errand-guid":    "default",
+			}))
+
+			format, content := logger.PrintfArgsForCall(1)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("setting max in flight"))
+
+			format, content = logger.PrintfArgsForCall(2)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("finished setting max in flight"))
+		})
+
+		It("configures the syslog properties that are provided", func() {
+			client := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+
+			productsService.StagedProductsReturns(api.StagedProductsOutput{
+				Products: []api.StagedProduct{
+					{GUID: "some-product-guid", Type: "cf"},
+				},
+			}, nil)
+
+			err := client.Execute([]string{
+				"--product-name", "cf",
+				"--syslog-properties", `{"enabled": true, "address": "example.com", "port": 514, "transport_protocol": "tcp"}`,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(productsService.ConfigureSyslogCallCount()).To(Equal(1))
+			productGUID, syslogProperties := productsService.ConfigureSyslogArgsForCall(0)
+			Expect(productGUID).To(Equal("some-product-guid"))
+			Expect(syslogProperties).To(Equal(map[string]interface{}{
+				"enabled":            true,
+				"address":            "example.com",
+				"port":               float64(514),
+				"transport_protocol": "tcp",
+			}))
+
+			format, content := logger.PrintfArgsForCall(1)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("setting syslog properties"))
+
+			format, content = logger.PrintfArgsForCall(2)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("finished setting syslog properties"))
+		})
+
+		It("skips the max in flight and syslog properties that are already staged", func() {
+			client := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+
+			productsService.StagedProductsReturns(api.StagedProductsOutput{
+				Products: []api.StagedProduct{
+					{GUID: "some-product-guid", Type: "cf"},
+				},
+			}, nil)
+			jobsService.JobsReturns(map[string]string{
+				"some-job":       "some-job-guid",
+				"some-other-job": "some-other-job-guid",
+			}, nil)
+			productsService.MaxInFlightReturns(map[string]interface{}{
+				"some-job-guid":       float64(2),
+				"some-other-job-guid": "20%",
+			}, nil)
+			productsService.SyslogConfigurationReturns(map[string]interface{}{
+				"enabled": true,
+				"address": "example.com",
+				"port":    "514",
+			}, nil)
+
+			err := client.Execute([]string{
+				"--product-name", "cf",
+				"--max-in-flight", `{"some-job": 2}`,
+				"--syslog-properties", `{"enabled": true, "address": "example.com"}`,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(productsService.MaxInFlightArgsForCall(0)).To(Equal("some-product-guid"))
+			Expect(productsService.ConfigureMaxInFlightCallCount()).To(Equal(0))
+			Expect(productsService.SyslogConfigurationArgsForCall(0)).To(Equal("some-product-guid"))
+			Expect(productsService.ConfigureSyslogCallCount()).To(Equal(0))
+
+			format, content := logger.PrintfArgsForCall(1)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("max in flight has not changed, skipping"))
+
+			format, content = logger.PrintfArgsForCall(2)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("syslog properties have not changed, skipping"))
+		})
+
+		It("configures the syslog properties when a staged value differs", func() {
+			client := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+
+			productsService.StagedProductsReturns(api.StagedProductsOutput{
+				Products: []api.StagedProduct{
+					{GUID: "some-product-guid", Type: "cf"},
+				},
+			}, nil)
+			productsService.SyslogConfigurationReturns(map[string]interface{}{
+				"enabled": true,
+				"address": "example.com",
+				"port":    "514",
+			}, nil)
+
+			err := client.Execute([]string{
+				"--product-name", "cf",
+				"--syslog-properties", `{"enabled": true, "port": "515"}`,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(productsService.ConfigureSyslogCallCount()).To(Equal(1))
+		})
+
+		It("configures the errand states that are provided in a single request", func() {
+			client := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+
+			productsService.StagedProductsReturns(api.StagedProductsOutput{
+				Products: []api.StagedProduct{
+					{GUID: "some-product-guid", Type: "cf"},
+				},
+			}, nil)
+			errandsService.ListReturns(api.ErrandsListOutput{
+				Errands: []api.Errand{
+					{Name: "smoke-tests", PostDeploy: true},
+					{Name: "push-apps-manager", PostDeploy: true},
+					{Name: "delete-all-apps", PreDelete: true},
+				},
+			}, nil)
+
+			err := client.Execute([]string{
+				"--product-name", "cf",
+				"--errand-config", `{
+					"smoke-tests": {"post-deploy-state": "when-changed"},
+					"delete-all-apps": {"pre-delete-state": false},
+					"push-apps-manager": {"post-deploy-state": "disabled", "pre-delete-state": "default"}
+				}`,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(errandsService.ListArgsForCall(0)).To(Equal("some-product-guid"))
+			Expect(errandsService.SetStateCallCount()).To(Equal(0))
+			Expect(errandsService.SetStatesCallCount()).To(Equal(1))
+
+			productGUID, errands := errandsService.SetStatesArgsForCall(0)
+			Expect(productGUID).To(Equal("some-product-guid"))
+			Expect(errands).To(Equal([]api.Errand{
+				{Name: "delete-all-apps", PreDelete: false},
+				{Name: "push-apps-manager", PostDeploy: false, PreDelete: "default"},
+				{Name: "smoke-tests", PostDeploy: "when-changed"},
+			}))
+
+			format, content := logger.PrintfArgsForCall(1)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("setting errand states"))
+
+			format, content = logger.PrintfArgsForCall(2)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("finished setting errand states"))
+		})
+
+		Context("when the errand config is not valid", func() {
+			It("returns every error without setting any errand state", func() {
+				client := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+
+				productsService.StagedProductsReturns(api.StagedProductsOutput{
+					Products: []api.StagedProduct{
+						{GUID: "some-product-guid", Type: "cf"},
+					},
+				}, nil)
+				errandsService.ListReturns(api.ErrandsListOutput{
+					Errands: []api.Errand{
+						{Name: "smoke-tests", PostDeploy: true},
+						{Name: "delete-all-apps", PreDelete: true},
+					},
+				}, nil)
+
+				err := client.Execute([]string{
+					"--product-name", "cf",
+					"--errand-config", `{
+						"smoke-tests": {"post-deploy-state": "sometimes", "run-on": "never"},
+						"delete-all-apps": "enabled",
+						"missing-errand": {"post-deploy-state": true}
+					}`,
+				})
+				Expect(err).To(MatchError(`product configuration is invalid:
+errand-config.delete-all-apps: expected a map of post-deploy-state and pre-delete-state, got "enabled"
+errand-config.missing-errand: unknown errand
+errand-config.smoke-tests.post-deploy-state: expected true, false, "enabled", "disabled", "when-changed" or "default", got "sometimes"
+errand-config.smoke-tests.run-on: expected true, false, "enabled", "disabled", "when-changed" or "default", got "never"`))
+
+				Expect(errandsService.SetStatesCallCount()).To(Equal(0))
+			})
+
+			It("reports fields other than the errand states", func() {
+				client := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+
+				productsService.StagedProductsReturns(api.StagedProductsOutput{
+					Products: []api.StagedProduct{
+						{GUID: "some-product-guid", Type: "cf"},
+					},
+				}, nil)
+				errandsService.ListReturns(api.ErrandsListOutput{
+					Errands: []api.Errand{{Name: "smoke-tests", PostDeploy: true}},
+				}, nil)
+
+				err := client.Execute([]string{
+					"--product-name", "cf",
+					"--errand-config", `{"smoke-tests": {"post_deploy": true}}`,
+				})
+				Expect(err).To(MatchError(`product configuration is invalid:
+errand-config.smoke-tests.post_deploy: unknown field, expected post-deploy-state or pre-delete-state`))
+			})
+		})
+
+		Context("when the max in flight is not valid", func() {
+			It("returns every error without configuring the max in flight", func() {
+				client := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+
+				productsService.StagedProductsReturns(api.StagedProductsOutput{
+					Products: []api.StagedProduct{
+						{GUID: "some-product-guid", Type: "cf"},
+					},
+				}, nil)
+				jobsService.JobsReturns(map[string]string{
+					"some-job":       "some-job-guid",
+					"some-other-job": "some-other-job-guid",
+				}, nil)
+
+				err := client.Execute([]string{
+					"--product-name", "cf",
+					"--max-in-flight", `{"some-job": 0, "some-other-job": "120%", "some-missing-job": 1}`,
+				})
+				Expect(err).To(MatchError(`product configuration is invalid:
+max-in-flight.some-job: expected a number of instances, a percentage or "default", got 0
+max-in-flight.some-missing-job: unknown job
+max-in-flight.some-other-job: expected a number of instances, a percentage or "default", got "120%"`))
+
+				Expect(productsService.ConfigureMaxInFlightCallCount()).To(Equal(0))
+			})
+		})
+
 		It("configures the resource that is provided", func() {
-			client := commands.NewConfigureProduct(productsService, jobsService, logger)
+			client := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
 			productsService.StagedProductsReturns(api.StagedProductsOutput{
 				Products: []api.StagedProduct{
 					{GUID: "some-product-guid", Type: "cf"},
@@ -235,9 +508,71 @@ var _ = Describe("ConfigureProduct", func() {
 			Expect(fmt.Sprintf(format, content...)).To(Equal("finished configuring product"))
 		})
 
+		Context("when the resource config has fields that have no typed field", func() {
+			It("merges them into the existing resource config", func() {
+				productsService.StagedProductsReturns(api.StagedProductsOutput{
+					Products: []api.StagedProduct{
+						{GUID: "some-product-guid", Type: "cf"},
+					},
+				}, nil)
+				jobsService.JobsReturns(map[string]string{"some-job": "a-guid"}, nil)
+				jobsService.GetExistingJobConfigReturns(api.JobProperties{
+					Instances:    float64(1),
+					InstanceType: api.InstanceType{ID: "m1.medium"},
+					Extra: map[string]json.RawMessage{
+						"additional_vm_extensions":       json.RawMessage(`["pre-existing"]`),
+						"swap_as_percent_of_memory_size": json.RawMessage(`50`),
+					},
+				}, nil)
+
+				command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+				err := command.Execute([]string{
+					"--product-name", "cf",
+					"--product-resources", `{"some-job": {"instances": 2, "additional_vm_extensions": ["some-extension"], "some_iaas_key": "some-value"}}`,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(jobsService.ConfigureJobCallCount()).To(Equal(1))
+				_, _, properties := jobsService.ConfigureJobArgsForCall(0)
+				Expect(properties).To(Equal(api.JobProperties{
+					Instances:    float64(2),
+					InstanceType: api.InstanceType{ID: "m1.medium"},
+					Extra: map[string]json.RawMessage{
+						"additional_vm_extensions":       json.RawMessage(`["some-extension"]`),
+						"swap_as_percent_of_memory_size": json.RawMessage(`50`),
+						"some_iaas_key":                  json.RawMessage(`"some-value"`),
+					},
+				}))
+			})
+
+			It("configures a resource config that only has such fields", func() {
+				productsService.StagedProductsReturns(api.StagedProductsOutput{
+					Products: []api.StagedProduct{
+						{GUID: "some-product-guid", Type: "cf"},
+					},
+				}, nil)
+				jobsService.JobsReturns(map[string]string{"some-job": "a-guid"}, nil)
+
+				command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+				err := command.Execute([]string{
+					"--product-name", "cf",
+					"--product-resources", `{"some-job": {"additional_vm_extensions": ["some-extension"]}}`,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(jobsService.ConfigureJobCallCount()).To(Equal(1))
+				_, _, properties := jobsService.ConfigureJobArgsForCall(0)
+				Expect(properties).To(Equal(api.JobProperties{
+					Extra: map[string]json.RawMessage{
+						"additional_vm_extensions": json.RawMessage(`["some-extension"]`),
+					},
+				}))
+			})
+		})
+
 		Context("when the instance count is not an int", func() {
 			It("configures the resource that is provided", func() {
-				client := commands.NewConfigureProduct(productsService, jobsService, logger)
+				client := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
 				productsService.StagedProductsReturns(api.StagedProductsOutput{
 					Products: []api.StagedProduct{
 						{GUID: "some-product-guid", Type: "cf"},
@@ -292,7 +627,7 @@ var _ = Describe("ConfigureProduct", func() {
 
 		Context("when GetExistingJobConfig returns an error", func() {
 			It("returns an error", func() {
-				client := commands.NewConfigureProduct(productsService, jobsService, logger)
+				client := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
 				productsService.StagedProductsReturns(api.StagedProductsOutput{
 					Products: []api.StagedProduct{
 						{GUID: "some-product-guid", Type: "cf"},
@@ -316,9 +651,324 @@ var _ = Describe("ConfigureProduct", func() {
 			})
 		})
 
+		Context("when a config file is provided", func() {
+			var (
+				configFile *os.File
+				varsFile   *os.File
+			)
+
+			BeforeEach(func() {
+				var err error
+				configFile, err = ioutil.TempFile("", "config.yml")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = configFile.WriteString(`---
+product-name: cf
+product-properties:
+  .properties.something:
+    value: ((something))
+  .properties.credentials:
+    value:
+      identity: admin
+      password: ((password))
+network-properties:
+  network:
+    name: ((network_name))
+resource-config:
+  some-job:
+    instances: 3
+max-in-flight:
+  some-job: 25%
+syslog-properties:
+  enabled: true
+  address: ((syslog_address))
+errand-config:
+  smoke-tests:
+    post-deploy-state: ((smoke_tests_state))
+    pre-delete-state: false
+`)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(configFile.Close()).To(Succeed())
+
+				varsFile, err = ioutil.TempFile("", "vars.yml")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = varsFile.WriteString(`---
+something: configure-me
+password: example-password
+network_name: network-one
+syslog_address: example.com
+smoke_tests_state: when-changed
+`)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(varsFile.Close()).To(Succeed())
+
+				productsService.StagedProductsReturns(api.StagedProductsOutput{
+					Products: []api.StagedProduct{
+						{GUID: "some-product-guid", Type: "cf"},
+					},
+				}, nil)
+
+				jobsService.JobsReturns(map[string]string{"some-job": "a-guid"}, nil)
+				errandsService.ListReturns(api.ErrandsListOutput{
+					Errands: []api.Errand{{Name: "smoke-tests", PostDeploy: true}},
+				}, nil)
+			})
+
+			AfterEach(func() {
+				os.Remove(configFile.Name())
+				os.Remove(varsFile.Name())
+			})
+
+			It("configures the product from the interpolated config file", func() {
+				command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+
+				err := command.Execute([]string{
+					"--config", configFile.Name(),
+					"--vars-file", varsFile.Name(),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(productsService.ConfigureCallCount()).To(Equal(2))
+
+				input := productsService.ConfigureArgsForCall(0)
+				Expect(input.GUID).To(Equal("some-product-guid"))
+				Expect(input.Configuration).To(MatchJSON(`{
+					".properties.something": {"value": "configure-me"},
+					".properties.credentials": {"value": {"identity": "admin", "password": "example-password"}}
+				}`))
+
+				input = productsService.ConfigureArgsForCall(1)
+				Expect(input.Network).To(MatchJSON(`{"network": {"name": "network-one"}}`))
+
+				productGUID, jobGUID, jobProperties := jobsService.ConfigureJobArgsForCall(0)
+				Expect(productGUID).To(Equal("some-product-guid"))
+				Expect(jobGUID).To(Equal("a-guid"))
+				Expect(jobProperties.Instances).To(Equal(float64(3)))
+
+				_, maxInFlight := productsService.ConfigureMaxInFlightArgsForCall(0)
+				Expect(maxInFlight).To(Equal(map[string]interface{}{"a-guid": "25%"}))
+
+				_, syslogProperties := productsService.ConfigureSyslogArgsForCall(0)
+				Expect(syslogProperties).To(Equal(map[string]interface{}{"enabled": true, "address": "example.com"}))
+
+				_, errands := errandsService.SetStatesArgsForCall(0)
+				Expect(errands).To(Equal([]api.Errand{
+					{Name: "smoke-tests", PostDeploy: "when-changed", PreDelete: false},
+				}))
+			})
+
+			It("prefers values provided as flags", func() {
+				command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+
+				err := command.Execute([]string{
+					"--config", configFile.Name(),
+					"--vars-file", varsFile.Name(),
+					"--product-properties", productProperties,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(productsService.ConfigureArgsForCall(0).Configuration).To(Equal(productProperties))
+			})
+
+			Context("when a placeholder has no value", func() {
+				It("returns an error listing the missing placeholders", func() {
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError("could not interpolate config file: could not find values for the following placeholders: network_name, password, smoke_tests_state, something, syslog_address"))
+					Expect(productsService.ConfigureCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the config file does not exist", func() {
+				It("returns an error", func() {
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+
+					err := command.Execute([]string{"--config", "/not/a/real/file.yml"})
+					Expect(err).To(MatchError(ContainSubstring("could not read config file:")))
+				})
+			})
+
+			Context("when the config file is not valid YAML", func() {
+				It("returns an error", func() {
+					err := ioutil.WriteFile(configFile.Name(), []byte("%%%"), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+
+					err = command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError(ContainSubstring("could not parse config file:")))
+				})
+			})
+		})
+
+		Context("when the product properties do not match the staged property schema", func() {
+			BeforeEach(func() {
+				productsService.StagedProductsReturns(api.StagedProductsOutput{
+					Products: []api.StagedProduct{
+						{GUID: "some-product-guid", Type: "cf"},
+					},
+				}, nil)
+
+				productsService.PropertiesReturns(map[string]api.ResponseProperty{
+					".properties.some-string":                      {Type: "string", Configurable: true},
+					".properties.some-port":                        {Type: "port", Configurable: true, Value: 80},
+					".properties.not-configurable":                 {Type: "string", Value: "fixed"},
+					".properties.some-dropdown":                    {Type: "dropdown_select", Configurable: true, Value: "small", Options: []api.ResponsePropertyOption{{Label: "Small", Value: "small"}, {Label: "Large", Value: "large"}}},
+					".properties.some-selector":                    {Type: "selector", Configurable: true, Value: "Internal", SelectedOption: "internal"},
+					".properties.some-selector.internal.some-size": {Type: "integer", Configurable: true, Value: 3},
+					".properties.some-selector.external.some-host": {Type: "string", Configurable: true},
+				}, nil)
+			})
+
+			It("returns every error without configuring the product", func() {
+				command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+
+				err := command.Execute([]string{
+					"--product-name", "cf",
+					"--product-properties", `{
+						".properties.some-string": {"value": "foo"},
+						".properties.unknown": {"value": "foo"},
+						".properties.not-configurable": {"value": "foo"},
+						".properties.some-port": {"value": true},
+						".properties.some-dropdown": {"value": "medium"},
+						".properties.some-selector": {"value": "external"}
+					}`,
+				})
+				Expect(err).To(MatchError(`product configuration is invalid:
+.properties.not-configurable: property is not configurable
+.properties.some-dropdown: expected one of [small large], got "medium"
+.properties.some-port: expected an integer, got true
+.properties.unknown: unknown property`))
+
+				Expect(productsService.PropertiesArgsForCall(0)).To(Equal("some-product-guid"))
+				Expect(productsService.ConfigureCallCount()).To(Equal(0))
+			})
+
+			It("does not require properties that are not in the product properties", func() {
+				command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+
+				err := command.Execute([]string{
+					"--product-name", "cf",
+					"--product-properties", `{".properties.some-string": {"value": "foo"}}`,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(productsService.ConfigureCallCount()).To(Equal(1))
+			})
+
+			Context("when the property schema cannot be fetched", func() {
+				It("returns an error", func() {
+					productsService.PropertiesReturns(nil, errors.New("some error"))
+
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+
+					err := command.Execute([]string{
+						"--product-name", "cf",
+						"--product-properties", productProperties,
+					})
+					Expect(err).To(MatchError("failed to fetch product properties: some error"))
+					Expect(productsService.ConfigureCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the product properties are not valid JSON", func() {
+				It("returns an error", func() {
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+
+					err := command.Execute([]string{
+						"--product-name", "cf",
+						"--product-properties", "%%%",
+					})
+					Expect(err).To(MatchError(ContainSubstring("could not decode product-properties json:")))
+				})
+			})
+		})
+
+		Context("when a product file is provided", func() {
+			BeforeEach(func() {
+				metadataExtractor.ExtractProductMetadataReturns(extractor.Metadata{
+					Name:           "cf",
+					ProductVersion: "1.2.3",
+					PropertyBlueprints: []extractor.PropertyBlueprint{
+						{Name: "something", Type: "string", Configurable: true},
+						{Name: "some-port", Type: "port", Configurable: true, Default: 80},
+					},
+					JobTypes: []extractor.JobType{
+						{
+							Name: "a-job",
+							PropertyBlueprints: []extractor.PropertyBlueprint{
+								{Name: "job-property", Type: "simple_credentials", Configurable: true},
+							},
+						},
+						{Name: "some-job"},
+					},
+				}, nil)
+
+				productsService.StagedProductsReturns(api.StagedProductsOutput{
+					Products: []api.StagedProduct{
+						{GUID: "some-product-guid", Type: "cf"},
+					},
+				}, nil)
+			})
+
+			It("validates the configuration before configuring the product", func() {
+				command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+
+				err := command.Execute([]string{
+					"--product-name", "cf",
+					"--product-properties", productProperties,
+					"--product-file", "/path/to/cf.pivotal",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(metadataExtractor.ExtractProductMetadataArgsForCall(0)).To(Equal("/path/to/cf.pivotal"))
+				Expect(productsService.ConfigureCallCount()).To(Equal(1))
+			})
+
+			Context("when the configuration does not match the product", func() {
+				It("returns every error without configuring the product", func() {
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+
+					err := command.Execute([]string{
+						"--product-name", "cf",
+						"--product-properties", `{
+							".properties.some-port": {"value": "not-a-port"},
+							".properties.unknown": {"value": "foo"}
+						}`,
+						"--product-resources", `{"unknown-job": {"instances": 1}}`,
+						"--product-file", "/path/to/cf.pivotal",
+					})
+					Expect(err).To(MatchError(`product configuration is invalid:
+.properties.some-port: expected an integer, got "not-a-port"
+.properties.unknown: unknown property
+resource-config.unknown-job: unknown job`))
+
+					Expect(productsService.StagedProductsCallCount()).To(Equal(0))
+					Expect(productsService.ConfigureCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the metadata cannot be extracted", func() {
+				It("returns an error", func() {
+					metadataExtractor.ExtractProductMetadataReturns(extractor.Metadata{}, errors.New("some error"))
+
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+
+					err := command.Execute([]string{
+						"--product-name", "cf",
+						"--product-properties", productProperties,
+						"--product-file", "/path/to/cf.pivotal",
+					})
+					Expect(err).To(MatchError("failed to extract product metadata: some error"))
+				})
+			})
+		})
+
 		Context("when neither the product-properties, product-network or product-resources flag is provided", func() {
 			It("logs and then does nothing", func() {
-				command := commands.NewConfigureProduct(productsService, jobsService, logger)
+				command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
 				err := command.Execute([]string{"--product-name", "cf"})
 				Expect(err).NotTo(HaveOccurred())
 
@@ -332,7 +982,7 @@ var _ = Describe("ConfigureProduct", func() {
 		Context("when an error occurs", func() {
 			Context("when the product does not exist", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureProduct(productsService, jobsService, logger)
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
 
 					productsService.StagedProductsReturns(api.StagedProductsOutput{
 						Products: []api.StagedProduct{
@@ -350,7 +1000,7 @@ var _ = Describe("ConfigureProduct", func() {
 
 			Context("when the product resources cannot be decoded", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureProduct(productsService, jobsService, logger)
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
 					productsService.StagedProductsReturns(api.StagedProductsOutput{
 						Products: []api.StagedProduct{
 							{GUID: "some-product-guid", Type: "cf"},
@@ -364,7 +1014,7 @@ var _ = Describe("ConfigureProduct", func() {
 
 			Context("when the jobs cannot be fetched", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureProduct(productsService, jobsService, logger)
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
 					productsService.StagedProductsReturns(api.StagedProductsOutput{
 						Products: []api.StagedProduct{
 							{GUID: "some-product-guid", Type: "cf"},
@@ -383,7 +1033,7 @@ var _ = Describe("ConfigureProduct", func() {
 
 			Context("when resources fail to configure", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureProduct(productsService, jobsService, logger)
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
 					productsService.StagedProductsReturns(api.StagedProductsOutput{
 						Products: []api.StagedProduct{
 							{GUID: "some-product-guid", Type: "cf"},
@@ -402,9 +1052,146 @@ var _ = Describe("ConfigureProduct", func() {
 				})
 			})
 
+			Context("when the max in flight cannot be decoded", func() {
+				It("returns an error", func() {
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+					productsService.StagedProductsReturns(api.StagedProductsOutput{
+						Products: []api.StagedProduct{
+							{GUID: "some-product-guid", Type: "cf"},
+						},
+					}, nil)
+
+					err := command.Execute([]string{"--product-name", "cf", "--max-in-flight", "%%%%%"})
+					Expect(err).To(MatchError(ContainSubstring("could not decode max-in-flight json")))
+				})
+			})
+
+			Context("when the max in flight fails to configure", func() {
+				It("returns an error", func() {
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+					productsService.StagedProductsReturns(api.StagedProductsOutput{
+						Products: []api.StagedProduct{
+							{GUID: "some-product-guid", Type: "cf"},
+						},
+					}, nil)
+					jobsService.JobsReturns(map[string]string{"some-job": "a-guid"}, nil)
+					productsService.ConfigureMaxInFlightReturns(errors.New("bad things happened"))
+
+					err := command.Execute([]string{"--product-name", "cf", "--max-in-flight", `{"some-job": 1}`})
+					Expect(err).To(MatchError("failed to configure max in flight: bad things happened"))
+				})
+			})
+
+			Context("when the max in flight cannot be fetched", func() {
+				It("returns an error", func() {
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+					productsService.StagedProductsReturns(api.StagedProductsOutput{
+						Products: []api.StagedProduct{
+							{GUID: "some-product-guid", Type: "cf"},
+						},
+					}, nil)
+					jobsService.JobsReturns(map[string]string{"some-job": "a-guid"}, nil)
+					productsService.MaxInFlightReturns(nil, errors.New("bad things happened"))
+
+					err := command.Execute([]string{"--product-name", "cf", "--max-in-flight", `{"some-job": 1}`})
+					Expect(err).To(MatchError("failed to fetch max in flight: bad things happened"))
+				})
+			})
+
+			Context("when the syslog properties cannot be decoded", func() {
+				It("returns an error", func() {
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+					productsService.StagedProductsReturns(api.StagedProductsOutput{
+						Products: []api.StagedProduct{
+							{GUID: "some-product-guid", Type: "cf"},
+						},
+					}, nil)
+
+					err := command.Execute([]string{"--product-name", "cf", "--syslog-properties", "%%%%%"})
+					Expect(err).To(MatchError(ContainSubstring("could not decode syslog-properties json")))
+				})
+			})
+
+			Context("when the syslog properties cannot be fetched", func() {
+				It("returns an error", func() {
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+					productsService.StagedProductsReturns(api.StagedProductsOutput{
+						Products: []api.StagedProduct{
+							{GUID: "some-product-guid", Type: "cf"},
+						},
+					}, nil)
+					productsService.SyslogConfigurationReturns(nil, errors.New("bad things happened"))
+
+					err := command.Execute([]string{"--product-name", "cf", "--syslog-properties", `{"enabled": false}`})
+					Expect(err).To(MatchError("failed to fetch syslog properties: bad things happened"))
+				})
+			})
+
+			Context("when the syslog properties fail to configure", func() {
+				It("returns an error", func() {
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+					productsService.StagedProductsReturns(api.StagedProductsOutput{
+						Products: []api.StagedProduct{
+							{GUID: "some-product-guid", Type: "cf"},
+						},
+					}, nil)
+					productsService.ConfigureSyslogReturns(errors.New("bad things happened"))
+
+					err := command.Execute([]string{"--product-name", "cf", "--syslog-properties", `{"enabled": false}`})
+					Expect(err).To(MatchError("failed to configure syslog properties: bad things happened"))
+				})
+			})
+
+			Context("when the errand config cannot be decoded", func() {
+				It("returns an error", func() {
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+					productsService.StagedProductsReturns(api.StagedProductsOutput{
+						Products: []api.StagedProduct{
+							{GUID: "some-product-guid", Type: "cf"},
+						},
+					}, nil)
+
+					err := command.Execute([]string{"--product-name", "cf", "--errand-config", "%%%%%"})
+					Expect(err).To(MatchError(ContainSubstring("could not decode errand-config json")))
+				})
+			})
+
+			Context("when the errands cannot be fetched", func() {
+				It("returns an error", func() {
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+					productsService.StagedProductsReturns(api.StagedProductsOutput{
+						Products: []api.StagedProduct{
+							{GUID: "some-product-guid", Type: "cf"},
+						},
+					}, nil)
+					errandsService.ListReturns(api.ErrandsListOutput{}, errors.New("bad things happened"))
+
+					err := command.Execute([]string{"--product-name", "cf", "--errand-config", `{"smoke-tests": {"post-deploy-state": true}}`})
+					Expect(err).To(MatchError("failed to fetch errands: bad things happened"))
+				})
+			})
+
+			Context("when the errand states fail to be set", func() {
+				It("returns an error", func() {
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
+					productsService.StagedProductsReturns(api.StagedProductsOutput{
+						Products: []api.StagedProduct{
+							{GUID: "some-product-guid", Type: "cf"},
+						},
+					}, nil)
+					errandsService.ListReturns(api.ErrandsListOutput{
+						Errands: []api.Errand{{Name: "smoke-tests", PostDeploy: true}},
+					}, nil)
+					errandsService.SetStatesReturns(errors.New("bad things happened"))
+
+					err := command.Execute([]string{"--product-name", "cf", "--errand-config", `{"smoke-tests": {"post-deploy-state": true}}`})
+					Expect(err).To(MatchError("failed to set errand states: bad things happened"))
+				})
+			})
+
 			Context("when an unknown flag is provided", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureProduct(productsService, jobsService, logger)
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
 					err := command.Execute([]string{"--badflag"})
 					Expect(err).To(MatchError("could not parse configure-product flags: flag provided but not defined: -badflag"))
 				})
@@ -412,7 +1199,7 @@ var _ = Describe("ConfigureProduct", func() {
 
 			Context("when the product cannot be configured", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureProduct(productsService, jobsService, logger)
+					command := commands.NewConfigureProduct(productsService, jobsService, errandsService, metadataExtractor, logger)
 					productsService.ConfigureReturns(errors.New("some product error"))
 
 					productsService.StagedProductsReturns(api.StagedProductsOutput{
@@ -430,7 +1217,7 @@ var _ = Describe("ConfigureProduct", func() {
 
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewConfigureProduct(nil, nil, nil)
+			command := commands.NewConfigureProduct(nil, nil, nil, nil, nil)
 			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
 				Description:      "This authenticated command configures a staged product",
 				ShortDescription: "configures a staged product",
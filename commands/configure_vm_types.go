@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/jhanda/flags"
+	"github.com/pivotal-cf/om/api"
+	yaml "gopkg.in/yaml.v2"
+)
+
+type ConfigureVMTypes struct {
+	service vmTypesConfigurer
+	logger  logger
+	Options struct {
+		ConfigFile string `short:"c" long:"config" description:"path to yml file containing the custom vm-types"`
+	}
+}
+
+type vmTypesConfiguration struct {
+	VMTypes []struct {
+		Name          string `yaml:"name"`
+		CPU           int    `yaml:"cpu"`
+		RAM           int    `yaml:"ram"`
+		EphemeralDisk int    `yaml:"ephemeral_disk"`
+	} `yaml:"vm-types"`
+}
+
+//go:generate counterfeiter -o ./fakes/vm_types_configurer.go --fake-name VMTypesConfigurer . vmTypesConfigurer
+type vmTypesConfigurer interface {
+	VMTypes() ([]api.VMType, error)
+	ConfigureVMTypes([]api.VMType) error
+}
+
+func NewConfigureVMTypes(service vmTypesConfigurer, logger logger) ConfigureVMTypes {
+	return ConfigureVMTypes{service: service, logger: logger}
+}
+
+func (c ConfigureVMTypes) Execute(args []string) error {
+	_, err := flags.Parse(&c.Options, args)
+	if err != nil {
+		return fmt.Errorf("could not parse configure-vm-types flags: %s", err)
+	}
+
+	if c.Options.ConfigFile == "" {
+		return errors.New("error: config is missing. Please see usage for more information.")
+	}
+
+	contents, err := ioutil.ReadFile(c.Options.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("could not read config file: %s", err)
+	}
+
+	var config vmTypesConfiguration
+	err = yaml.UnmarshalStrict(contents, &config)
+	if err != nil {
+		return fmt.Errorf("could not parse config file: %s", err)
+	}
+
+	existingVMTypes, err := c.service.VMTypes()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve vm types: %s", err)
+	}
+
+	// The vm types endpoint replaces the whole list, so the built-in vm types
+	// are sent back along with the custom ones to keep them.
+	var vmTypes []api.VMType
+	builtIn := map[string]bool{}
+	custom := map[string]bool{}
+	for _, vmType := range existingVMTypes {
+		if vmType.BuiltIn {
+			builtIn[vmType.Name] = true
+			vmTypes = append(vmTypes, vmType)
+		} else {
+			custom[vmType.Name] = true
+		}
+	}
+
+	var (
+		problems []string
+		names    []string
+	)
+	seen := map[string]bool{}
+	for i, vmType := range config.VMTypes {
+		switch {
+		case vmType.Name == "":
+			problems = append(problems, fmt.Sprintf("vm-types[%d]: name is missing", i))
+			continue
+		case seen[vmType.Name]:
+			problems = append(problems, fmt.Sprintf("%s: is given more than once", vmType.Name))
+		case builtIn[vmType.Name]:
+			problems = append(problems, fmt.Sprintf("%s: is a built-in vm type and cannot be replaced", vmType.Name))
+		}
+		seen[vmType.Name] = true
+
+		if vmType.CPU <= 0 {
+			problems = append(problems, fmt.Sprintf("%s: cpu must be greater than 0", vmType.Name))
+		}
+		if vmType.RAM <= 0 {
+			problems = append(problems, fmt.Sprintf("%s: ram must be greater than 0", vmType.Name))
+		}
+		if vmType.EphemeralDisk <= 0 {
+			problems = append(problems, fmt.Sprintf("%s: ephemeral_disk must be greater than 0", vmType.Name))
+		}
+
+		names = append(names, vmType.Name)
+		vmTypes = append(vmTypes, api.VMType{
+			Name:          vmType.Name,
+			CPU:           vmType.CPU,
+			RAM:           vmType.RAM,
+			EphemeralDisk: vmType.EphemeralDisk,
+		})
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("vm types configuration is invalid:\n%s", strings.Join(problems, "\n"))
+	}
+
+	var removed []string
+	for name := range custom {
+		if !seen[name] {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+
+	c.logger.Printf("configuring custom vm types...")
+	if len(names) > 0 {
+		c.logger.Printf("setting custom vm types: %s", strings.Join(names, ", "))
+	}
+	if len(removed) > 0 {
+		c.logger.Printf("removing custom vm types that are not in the config: %s", strings.Join(removed, ", "))
+	}
+
+	err = c.service.ConfigureVMTypes(vmTypes)
+	if err != nil {
+		return fmt.Errorf("failed to configure vm types: %s", err)
+	}
+
+	c.logger.Printf("finished configuring custom vm types")
+
+	return nil
+}
+
+func (c ConfigureVMTypes) Usage() commands.Usage {
+	return commands.Usage{
+		Description:      "This authenticated command replaces the custom VM types with the ones in the config file. Custom VM types that are not in the file are removed, and the built-in VM types are left alone.",
+		ShortDescription: "configures custom VM types",
+		Flags:            c.Options,
+	}
+}
@@ -0,0 +1,199 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	jhandacommands "github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConfigureVMTypes", func() {
+	var (
+		service    *fakes.VMTypesConfigurer
+		logger     *fakes.Logger
+		configFile *os.File
+	)
+
+	writeConfig := func(contents string) {
+		var err error
+		configFile, err = ioutil.TempFile("", "vm-types.yml")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = configFile.WriteString(contents)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configFile.Close()).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		service = &fakes.VMTypesConfigurer{}
+		service.VMTypesReturns([]api.VMType{
+			{Name: "micro", CPU: 1, RAM: 1024, EphemeralDisk: 8192, BuiltIn: true},
+			{Name: "some-custom-type", CPU: 2, RAM: 4096, EphemeralDisk: 32768},
+			{Name: "some-old-type", CPU: 1, RAM: 2048, EphemeralDisk: 16384},
+		}, nil)
+		logger = &fakes.Logger{}
+
+		writeConfig(`---
+vm-types:
+- name: some-custom-type
+  cpu: 4
+  ram: 8192
+  ephemeral_disk: 65536
+- name: some-new-type
+  cpu: 8
+  ram: 16384
+  ephemeral_disk: 65536
+`)
+	})
+
+	AfterEach(func() {
+		os.Remove(configFile.Name())
+	})
+
+	Describe("Execute", func() {
+		It("replaces the custom vm types with the ones in the config file", func() {
+			command := commands.NewConfigureVMTypes(service, logger)
+			err := command.Execute([]string{"--config", configFile.Name()})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.ConfigureVMTypesCallCount()).To(Equal(1))
+			Expect(service.ConfigureVMTypesArgsForCall(0)).To(Equal([]api.VMType{
+				{Name: "micro", CPU: 1, RAM: 1024, EphemeralDisk: 8192, BuiltIn: true},
+				{Name: "some-custom-type", CPU: 4, RAM: 8192, EphemeralDisk: 65536},
+				{Name: "some-new-type", CPU: 8, RAM: 16384, EphemeralDisk: 65536},
+			}))
+
+			var lines []string
+			for i := 0; i < logger.PrintfCallCount(); i++ {
+				format, content := logger.PrintfArgsForCall(i)
+				lines = append(lines, fmt.Sprintf(format, content...))
+			}
+			Expect(lines).To(Equal([]string{
+				"configuring custom vm types...",
+				"setting custom vm types: some-custom-type, some-new-type",
+				"removing custom vm types that are not in the config: some-old-type",
+				"finished configuring custom vm types",
+			}))
+		})
+
+		It("removes every custom vm type when the config file has none", func() {
+			writeConfig("vm-types: []")
+
+			command := commands.NewConfigureVMTypes(service, logger)
+			err := command.Execute([]string{"--config", configFile.Name()})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.ConfigureVMTypesArgsForCall(0)).To(Equal([]api.VMType{
+				{Name: "micro", CPU: 1, RAM: 1024, EphemeralDisk: 8192, BuiltIn: true},
+			}))
+		})
+
+		Context("failure cases", func() {
+			Context("when an unknown flag is provided", func() {
+				It("returns an error", func() {
+					command := commands.NewConfigureVMTypes(service, logger)
+					err := command.Execute([]string{"--badflag"})
+					Expect(err).To(MatchError("could not parse configure-vm-types flags: flag provided but not defined: -badflag"))
+				})
+			})
+
+			Context("when the config flag is not provided", func() {
+				It("returns an error", func() {
+					command := commands.NewConfigureVMTypes(service, logger)
+					err := command.Execute([]string{})
+					Expect(err).To(MatchError("error: config is missing. Please see usage for more information."))
+				})
+			})
+
+			Context("when the config file does not exist", func() {
+				It("returns an error", func() {
+					command := commands.NewConfigureVMTypes(service, logger)
+					err := command.Execute([]string{"--config", "/not/a/real/file.yml"})
+					Expect(err).To(MatchError(ContainSubstring("could not read config file:")))
+				})
+			})
+
+			Context("when the config file has unknown keys", func() {
+				It("returns an error", func() {
+					writeConfig(`vm-types:
+- name: some-type
+  cpus: 2
+`)
+
+					command := commands.NewConfigureVMTypes(service, logger)
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError(ContainSubstring("could not parse config file:")))
+				})
+			})
+
+			Context("when the vm types are not valid", func() {
+				It("returns every error without configuring the vm types", func() {
+					writeConfig(`vm-types:
+- name: micro
+  cpu: 1
+  ram: 1024
+  ephemeral_disk: 8192
+- name: some-type
+  cpu: 0
+  ram: 1024
+  ephemeral_disk: 8192
+- name: some-type
+  cpu: 1
+  ram: 1024
+  ephemeral_disk: 8192
+- cpu: 1
+`)
+
+					command := commands.NewConfigureVMTypes(service, logger)
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError(`vm types configuration is invalid:
+micro: is a built-in vm type and cannot be replaced
+some-type: cpu must be greater than 0
+some-type: is given more than once
+vm-types[3]: name is missing`))
+
+					Expect(service.ConfigureVMTypesCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the vm types cannot be retrieved", func() {
+				It("returns an error", func() {
+					service.VMTypesReturns(nil, errors.New("some error"))
+
+					command := commands.NewConfigureVMTypes(service, logger)
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError("failed to retrieve vm types: some error"))
+				})
+			})
+
+			Context("when the vm types cannot be configured", func() {
+				It("returns an error", func() {
+					service.ConfigureVMTypesReturns(errors.New("some error"))
+
+					command := commands.NewConfigureVMTypes(service, logger)
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError("failed to configure vm types: some error"))
+				})
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			command := commands.NewConfigureVMTypes(nil, nil)
+			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
+				Description:      "This authenticated command replaces the custom VM types with the ones in the config file. Custom VM types that are not in the file are removed, and the built-in VM types are left alone.",
+				ShortDescription: "configures custom VM types",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
@@ -0,0 +1,532 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	jhandacommands "github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/jhanda/flags"
+	"github.com/pivotal-cf/om/api"
+	yaml "gopkg.in/yaml.v2"
+)
+
+type Converge struct {
+	commands          jhandacommands.Set
+	diagnosticService diagnosticService
+	stagedProducts    productDependenciesLister
+	stemcellLibrary   stemcellAssignmentsService
+	availableProducts availableProductChecker
+	extractor         metadataExtractor
+	stemcellExtractor stemcellExtractor
+	logger            logger
+	Options           struct {
+		Manifest       string `short:"m" long:"manifest" description:"path to yml file describing the director and every product of the foundation"`
+		DryRun         bool   `long:"dry-run" description:"print the plan without executing it"`
+		IgnoreWarnings bool   `short:"i" long:"ignore-warnings" description:"ignore issues reported by Ops Manager when applying changes"`
+	}
+}
+
+type foundationManifest struct {
+	Director map[string]interface{} `yaml:"director"`
+	Products []productManifest      `yaml:"products"`
+}
+
+type productManifest struct {
+	Name     string                    `yaml:"name"`
+	Version  string                    `yaml:"version"`
+	File     string                    `yaml:"file"`
+	Config   string                    `yaml:"config"`
+	VarsFile string                    `yaml:"vars-file"`
+	Stemcell string                    `yaml:"stemcell"`
+	Errands  map[string]errandManifest `yaml:"errands"`
+}
+
+type errandManifest struct {
+	PostDeploy string `yaml:"post-deploy"`
+	PreDelete  string `yaml:"pre-delete"`
+}
+
+//go:generate counterfeiter -o ./fakes/configuration_checker.go --fake-name ConfigurationChecker . configurationChecker
+type configurationChecker interface {
+	jhandacommands.Command
+	ConfigurationChanged(args []string) (bool, error)
+}
+
+//go:generate counterfeiter -o ./fakes/product_dependencies_lister.go --fake-name ProductDependenciesLister . productDependenciesLister
+type productDependenciesLister interface {
+	StagedProducts() (api.StagedProductsOutput, error)
+	Dependencies(productGUID string) ([]api.ProductDependency, error)
+}
+
+type convergeStep struct {
+	description string
+	command     string
+	args        []string
+}
+
+func NewConverge(commands jhandacommands.Set, diagnosticService diagnosticService, stagedProducts productDependenciesLister, stemcellLibrary stemcellAssignmentsService, availableProducts availableProductChecker, extractor metadataExtractor, stemcellExtractor stemcellExtractor, logger logger) Converge {
+	return Converge{
+		commands:          commands,
+		diagnosticService: diagnosticService,
+		stagedProducts:    stagedProducts,
+		stemcellLibrary:   stemcellLibrary,
+		availableProducts: availableProducts,
+		extractor:         extractor,
+		stemcellExtractor: stemcellExtractor,
+		logger:            logger,
+	}
+}
+
+func (c Converge) Execute(args []string) error {
+	_, err := flags.Parse(&c.Options, args)
+	if err != nil {
+		return fmt.Errorf("could not parse converge flags: %s", err)
+	}
+
+	if c.Options.Manifest == "" {
+		return errors.New("error: manifest is missing. Please see usage for more information.")
+	}
+
+	manifest, err := c.loadManifest()
+	if err != nil {
+		return err
+	}
+
+	steps, err := c.plan(manifest)
+	if err != nil {
+		return err
+	}
+
+	c.logger.Printf("converge plan:")
+	for i, step := range steps {
+		c.logger.Printf("  %d. %s", i+1, step.description)
+	}
+
+	if c.Options.DryRun {
+		return nil
+	}
+
+	for i, step := range steps {
+		c.logger.Printf("step %d/%d: %s", i+1, len(steps), step.description)
+
+		command, ok := c.commands[step.command]
+		if !ok {
+			return fmt.Errorf("failed to %s: unknown command %s", step.description, step.command) // cannot be tested
+		}
+
+		err = command.Execute(step.args)
+		if err != nil {
+			return fmt.Errorf("failed to %s: %s", step.description, err)
+		}
+	}
+
+	c.logger.Printf("finished converging foundation")
+
+	return nil
+}
+
+// loadManifest reads the manifest and resolves the paths in it relative to
+// the directory of the manifest. The name and version of a product that are
+// not given are read from its product file.
+func (c Converge) loadManifest() (foundationManifest, error) {
+	contents, err := ioutil.ReadFile(c.Options.Manifest)
+	if err != nil {
+		return foundationManifest{}, fmt.Errorf("could not read manifest: %s", err)
+	}
+
+	var manifest foundationManifest
+	err = yaml.UnmarshalStrict(contents, &manifest)
+	if err != nil {
+		return foundationManifest{}, fmt.Errorf("could not parse manifest: %s", err)
+	}
+
+	dir := filepath.Dir(c.Options.Manifest)
+	resolve := func(path string) string {
+		if path == "" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(dir, path)
+	}
+
+	var problems []string
+	for _, section := range sortedKeys(manifest.Director) {
+		if !contains(directorConfigurationSections, section) {
+			problems = append(problems, fmt.Sprintf("director.%s: unknown section, expected one of %s", section, strings.Join(directorConfigurationSections, ", ")))
+		}
+	}
+
+	seen := map[string]bool{}
+	for i, product := range manifest.Products {
+		product.File = resolve(product.File)
+		product.Config = resolve(product.Config)
+		product.VarsFile = resolve(product.VarsFile)
+		product.Stemcell = resolve(product.Stemcell)
+
+		if product.File != "" && (product.Name == "" || product.Version == "") {
+			metadata, err := c.extractor.ExtractProductMetadata(product.File)
+			if err != nil {
+				return foundationManifest{}, fmt.Errorf("could not read product file %s: %s", product.File, err)
+			}
+
+			if product.Name == "" {
+				product.Name = metadata.Name
+			}
+			if product.Version == "" {
+				product.Version = metadata.ProductVersion
+			}
+		}
+
+		switch {
+		case product.Name == "":
+			problems = append(problems, fmt.Sprintf("products[%d]: name is missing", i))
+			continue
+		case seen[product.Name]:
+			problems = append(problems, fmt.Sprintf("%s: is given more than once", product.Name))
+		}
+		seen[product.Name] = true
+
+		if product.Version == "" {
+			problems = append(problems, fmt.Sprintf("%s: version is missing", product.Name))
+		}
+
+		if product.VarsFile != "" && product.Config == "" {
+			problems = append(problems, fmt.Sprintf("%s: vars-file is given without a config", product.Name))
+		}
+
+		for _, errand := range sortedErrandNames(product.Errands) {
+			state := product.Errands[errand]
+			if _, ok := userToOMInputs[state.PostDeploy]; state.PostDeploy != "" && !ok {
+				problems = append(problems, fmt.Sprintf("%s: errand %s has invalid post-deploy state %q", product.Name, errand, state.PostDeploy))
+			}
+			if _, ok := userToOMInputs[state.PreDelete]; state.PreDelete != "" && !ok {
+				problems = append(problems, fmt.Sprintf("%s: errand %s has invalid pre-delete state %q", product.Name, errand, state.PreDelete))
+			}
+		}
+
+		manifest.Products[i] = product
+	}
+
+	if len(problems) > 0 {
+		return foundationManifest{}, fmt.Errorf("manifest is invalid:\n%s", strings.Join(problems, "\n"))
+	}
+
+	return manifest, nil
+}
+
+// plan compares the manifest with the Ops Manager and returns the steps that
+// converge the one to the other. The director is configured first, then
+// artifacts are uploaded and products that are no longer in the manifest are
+// unstaged, dependents first, before the remaining products are staged,
+// assigned their stemcell and configured. The plan always ends with a single
+// apply-changes. When the diagnostic report is unavailable, the plan assumes
+// that nothing has been uploaded or staged yet.
+func (c Converge) plan(manifest foundationManifest) ([]convergeStep, error) {
+	report, err := c.diagnosticService.Report()
+	if err != nil {
+		switch err.(type) {
+		case api.DiagnosticReportUnavailable:
+			c.logger.Printf("%s, planning as if nothing has been uploaded or staged", err)
+		default:
+			return nil, fmt.Errorf("failed to retrieve diagnostic report: %s", err)
+		}
+	}
+
+	var steps []convergeStep
+
+	if len(manifest.Director) > 0 {
+		var args []string
+		for _, section := range directorConfigurationSections {
+			value, ok := manifest.Director[section]
+			if !ok {
+				continue
+			}
+
+			contents, err := json.Marshal(jsonCompatible(value))
+			if err != nil {
+				return nil, fmt.Errorf("could not convert director.%s to json: %s", section, err)
+			}
+
+			args = append(args, "--"+section, string(contents))
+		}
+
+		steps = append(steps, convergeStep{"configure director", "configure-director", args})
+	}
+
+	assignments, err := c.stemcellLibrary.List()
+	if err != nil {
+		c.logger.Printf("could not list the uploaded stemcells, falling back to the file names: %s", err)
+	}
+
+	for _, product := range manifest.Products {
+		if product.Stemcell == "" || containsStemcellStep(steps, product.Stemcell) {
+			continue
+		}
+
+		// a stemcell without a readable manifest is compared by file name
+		stemcellManifest, _ := c.stemcellExtractor.ExtractStemcellManifest(product.Stemcell)
+		if stemcellUploaded(product.Stemcell, stemcellManifest, assignments.StemcellLibrary, report.Stemcells) {
+			continue
+		}
+
+		steps = append(steps, convergeStep{
+			fmt.Sprintf("upload stemcell %s", filepath.Base(product.Stemcell)),
+			"upload-stemcell",
+			[]string{"--stemcell", product.Stemcell},
+		})
+	}
+
+	for _, product := range manifest.Products {
+		if product.File == "" {
+			continue
+		}
+
+		available, err := c.availableProducts.CheckProductAvailability(product.Name, product.Version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check availability of %s %s: %s", product.Name, product.Version, err)
+		}
+
+		if !available {
+			steps = append(steps, convergeStep{
+				fmt.Sprintf("upload product %s %s", product.Name, product.Version),
+				"upload-product",
+				[]string{"--product", product.File},
+			})
+		}
+	}
+
+	staged := map[string]api.DiagnosticProduct{}
+	for _, product := range report.StagedProducts {
+		staged[product.Name] = product
+	}
+
+	wanted := map[string]bool{}
+	for _, product := range manifest.Products {
+		wanted[product.Name] = true
+	}
+
+	var unwanted []string
+	for _, product := range report.StagedProducts {
+		if product.Name == "p-bosh" || wanted[product.Name] {
+			continue
+		}
+
+		unwanted = append(unwanted, product.Name)
+	}
+
+	unwanted, err = c.unstageOrder(unwanted)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range unwanted {
+		steps = append(steps, convergeStep{
+			fmt.Sprintf("unstage product %s", name),
+			"unstage-product",
+			[]string{"--product-name", name},
+		})
+	}
+
+	for _, product := range manifest.Products {
+		stagedProduct, ok := staged[product.Name]
+		restaged := !ok || stagedProduct.Version != product.Version
+
+		if restaged {
+			args := []string{"--product-name", product.Name, "--product-version", product.Version}
+			if product.File != "" {
+				args = append(args, "--product-file", product.File)
+			}
+
+			steps = append(steps, convergeStep{
+				fmt.Sprintf("stage product %s %s", product.Name, product.Version),
+				"stage-product",
+				args,
+			})
+		}
+
+		if product.Stemcell != "" && (restaged || stagedProduct.Stemcell != filepath.Base(product.Stemcell)) {
+			version, err := c.stemcellVersion(product.Stemcell)
+			if err != nil {
+				return nil, err
+			}
+
+			steps = append(steps, convergeStep{
+				fmt.Sprintf("assign stemcell %s to %s", version, product.Name),
+				"assign-stemcell",
+				[]string{"--product-name", product.Name, "--stemcell", version},
+			})
+		}
+
+		errandConfig := map[string]interface{}{}
+		for _, errand := range sortedErrandNames(product.Errands) {
+			state := product.Errands[errand]
+
+			states := map[string]interface{}{}
+			if state.PostDeploy != "" {
+				states["post-deploy-state"] = state.PostDeploy
+			}
+			if state.PreDelete != "" {
+				states["pre-delete-state"] = state.PreDelete
+			}
+
+			if len(states) > 0 {
+				errandConfig[errand] = states
+			}
+		}
+
+		if product.Config != "" || len(errandConfig) > 0 {
+			args := []string{"--product-name", product.Name}
+			if product.Config != "" {
+				args = append(args, "--config", product.Config)
+			}
+			if product.VarsFile != "" {
+				args = append(args, "--vars-file", product.VarsFile)
+			}
+			if len(errandConfig) > 0 {
+				contents, err := json.Marshal(errandConfig)
+				if err != nil {
+					return nil, fmt.Errorf("could not convert the errands of %s to json: %s", product.Name, err) // cannot be tested
+				}
+				args = append(args, "--errand-config", string(contents))
+			}
+
+			// a product that is staged again starts from the configuration
+			// of its new version, so it is always configured
+			changed := true
+			if checker, ok := c.commands["configure-product"].(configurationChecker); ok && !restaged {
+				changed, err = checker.ConfigurationChanged(args)
+				if err != nil {
+					return nil, fmt.Errorf("failed to compare the configuration of %s: %s", product.Name, err)
+				}
+			}
+
+			if changed {
+				steps = append(steps, convergeStep{
+					fmt.Sprintf("configure product %s", product.Name),
+					"configure-product",
+					args,
+				})
+			} else {
+				c.logger.Printf("configuration of %s has not changed", product.Name)
+			}
+		}
+	}
+
+	args := []string{}
+	if c.Options.IgnoreWarnings {
+		args = append(args, "--ignore-warnings")
+	}
+	steps = append(steps, convergeStep{"apply changes", "apply-changes", args})
+
+	return steps, nil
+}
+
+// stemcellVersion reads the version of a stemcell from its manifest, or from
+// its file name when the manifest cannot be read.
+func (c Converge) stemcellVersion(stemcell string) (string, error) {
+	manifest, err := c.stemcellExtractor.ExtractStemcellManifest(stemcell)
+	if err == nil && manifest.Version != "" {
+		return manifest.Version, nil
+	}
+
+	name := strings.TrimPrefix(strings.TrimSuffix(filepath.Base(stemcell), ".tgz"), "light-")
+	if !strings.HasPrefix(name, "bosh-stemcell-") {
+		return "", fmt.Errorf("could not determine the version of stemcell %s", stemcell)
+	}
+
+	return strings.SplitN(strings.TrimPrefix(name, "bosh-stemcell-"), "-", 2)[0], nil
+}
+
+// unstageOrder orders the products so that every product is unstaged before
+// the products it depends on, as Ops Manager refuses to unstage a product
+// that a staged product still depends on.
+func (c Converge) unstageOrder(names []string) ([]string, error) {
+	if len(names) < 2 {
+		return names, nil
+	}
+
+	stagedProducts, err := c.stagedProducts.StagedProducts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list staged products: %s", err)
+	}
+
+	dependents := map[string]int{}
+	dependencies := map[string][]string{}
+	for _, product := range stagedProducts.Products {
+		if !contains(names, product.Type) {
+			continue
+		}
+
+		productDependencies, err := c.stagedProducts.Dependencies(product.GUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve dependencies of %s: %s", product.Type, err)
+		}
+
+		for _, dependency := range productDependencies {
+			if contains(names, dependency.Type) {
+				dependencies[product.Type] = append(dependencies[product.Type], dependency.Type)
+				dependents[dependency.Type]++
+			}
+		}
+	}
+
+	var ordered []string
+	done := map[string]bool{}
+	for len(ordered) < len(names) {
+		next := ""
+		for _, name := range names {
+			if !done[name] && dependents[name] == 0 {
+				next = name
+				break
+			}
+		}
+
+		// products that depend on each other are unstaged in their order
+		if next == "" {
+			for _, name := range names {
+				if !done[name] {
+					ordered = append(ordered, name)
+				}
+			}
+			break
+		}
+
+		done[next] = true
+		ordered = append(ordered, next)
+		for _, dependency := range dependencies[next] {
+			dependents[dependency]--
+		}
+	}
+
+	return ordered, nil
+}
+
+func containsStemcellStep(steps []convergeStep, stemcell string) bool {
+	for _, step := range steps {
+		if step.command == "upload-stemcell" && step.args[1] == stemcell {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortedErrandNames(errands map[string]errandManifest) []string {
+	names := map[string]interface{}{}
+	for name := range errands {
+		names[name] = nil
+	}
+
+	return sortedKeys(names)
+}
+
+func (c Converge) Usage() jhandacommands.Usage {
+	return jhandacommands.Usage{
+		Description:      "This authenticated command converges the Ops Manager to a manifest describing the director and every product of the foundation. It prints the uploads, stagings, stemcell assignments, configurations and unstagings that are needed, executes them, and then applies changes once.",
+		ShortDescription: "converges the Ops Manager to a foundation manifest",
+		Flags:            c.Options,
+	}
+}
@@ -0,0 +1,632 @@
+package commands_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+
+	jhandacommands "github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/extractor"
+	"github.com/pivotal-cf/om/uploads"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Converge", func() {
+	var (
+		commandSet        jhandacommands.Set
+		executed          []string
+		diagnosticService *fakes.DiagnosticService
+		stagedProducts    *fakes.ProductDependenciesLister
+		stemcellLibrary   *fakes.StemcellAssignmentsService
+		availableProducts *fakes.AvailableProductChecker
+		metadataExtractor *fakes.MetadataExtractor
+		stemcellExtractor *fakes.StemcellExtractor
+		logger            *fakes.Logger
+		manifestDir       string
+		manifestPath      string
+	)
+
+	writeManifest := func(contents string) {
+		err := ioutil.WriteFile(manifestPath, []byte(contents), 0644)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	loggedLines := func() []string {
+		var lines []string
+		for i := 0; i < logger.PrintfCallCount(); i++ {
+			format, content := logger.PrintfArgsForCall(i)
+			lines = append(lines, fmt.Sprintf(format, content...))
+		}
+		return lines
+	}
+
+	BeforeEach(func() {
+		executed = nil
+		commandSet = jhandacommands.Set{}
+		for _, name := range []string{"configure-director", "upload-stemcell", "upload-product", "unstage-product", "stage-product", "assign-stemcell", "configure-product", "apply-changes"} {
+			name := name
+			command := &fakes.Command{}
+			command.ExecuteStub = func(args []string) error {
+				executed = append(executed, fmt.Sprintf("%s %v", name, args))
+				return nil
+			}
+			commandSet[name] = command
+		}
+
+		diagnosticService = &fakes.DiagnosticService{}
+		diagnosticService.ReportReturns(api.DiagnosticReport{
+			Stemcells: []string{"bosh-stemcell-3468-uploaded.tgz"},
+			StagedProducts: []api.DiagnosticProduct{
+				{Name: "p-bosh", Version: "2.1.0"},
+				{Name: "cf", Version: "2.0.0"},
+				{Name: "p-old", Version: "1.0.0"},
+			},
+		}, nil)
+
+		stagedProducts = &fakes.ProductDependenciesLister{}
+		stemcellLibrary = &fakes.StemcellAssignmentsService{}
+		availableProducts = &fakes.AvailableProductChecker{}
+		metadataExtractor = &fakes.MetadataExtractor{}
+		stemcellExtractor = &fakes.StemcellExtractor{}
+		stemcellExtractor.ExtractStemcellManifestStub = func(path string) (extractor.StemcellManifest, error) {
+			if filepath.Base(path) == "bosh-stemcell-3468-new.tgz" {
+				return extractor.StemcellManifest{Name: "bosh-vsphere-esxi-ubuntu-trusty-go_agent", Version: "3468.21"}, nil
+			}
+			return extractor.StemcellManifest{}, errors.New("not a stemcell")
+		}
+		logger = &fakes.Logger{}
+
+		var err error
+		manifestDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+		manifestPath = filepath.Join(manifestDir, "foundation.yml")
+
+		writeManifest(`---
+director:
+  director-configuration:
+    ntp_servers_string: ntp.example.com
+products:
+- name: cf
+  version: 2.1.0
+  file: cf-2.1.0.pivotal
+  config: cf.yml
+  vars-file: cf-vars.yml
+  stemcell: bosh-stemcell-3468-new.tgz
+  errands:
+    smoke_tests:
+      post-deploy: disabled
+    push-apps-manager:
+      post-deploy: when-changed
+      pre-delete: enabled
+- name: p-mysql
+  version: 1.10.0
+  stemcell: /stemcells/bosh-stemcell-3468-uploaded.tgz
+`)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(manifestDir)
+	})
+
+	Describe("Execute", func() {
+		It("prints the plan and then executes it", func() {
+			command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+			err := command.Execute([]string{"--manifest", manifestPath})
+			Expect(err).NotTo(HaveOccurred())
+
+			name, version := availableProducts.CheckProductAvailabilityArgsForCall(0)
+			Expect(name).To(Equal("cf"))
+			Expect(version).To(Equal("2.1.0"))
+
+			Expect(loggedLines()).To(Equal([]string{
+				"converge plan:",
+				"  1. configure director",
+				"  2. upload stemcell bosh-stemcell-3468-new.tgz",
+				"  3. upload product cf 2.1.0",
+				"  4. unstage product p-old",
+				"  5. stage product cf 2.1.0",
+				"  6. assign stemcell 3468.21 to cf",
+				"  7. configure product cf",
+				"  8. stage product p-mysql 1.10.0",
+				"  9. assign stemcell 3468 to p-mysql",
+				"  10. apply changes",
+				"step 1/10: configure director",
+				"step 2/10: upload stemcell bosh-stemcell-3468-new.tgz",
+				"step 3/10: upload product cf 2.1.0",
+				"step 4/10: unstage product p-old",
+				"step 5/10: stage product cf 2.1.0",
+				"step 6/10: assign stemcell 3468.21 to cf",
+				"step 7/10: configure product cf",
+				"step 8/10: stage product p-mysql 1.10.0",
+				"step 9/10: assign stemcell 3468 to p-mysql",
+				"step 10/10: apply changes",
+				"finished converging foundation",
+			}))
+
+			Expect(executed).To(Equal([]string{
+				`configure-director [--director-configuration {"ntp_servers_string":"ntp.example.com"}]`,
+				fmt.Sprintf("upload-stemcell [--stemcell %s/bosh-stemcell-3468-new.tgz]", manifestDir),
+				fmt.Sprintf("upload-product [--product %s/cf-2.1.0.pivotal]", manifestDir),
+				"unstage-product [--product-name p-old]",
+				fmt.Sprintf("stage-product [--product-name cf --product-version 2.1.0 --product-file %s/cf-2.1.0.pivotal]", manifestDir),
+				"assign-stemcell [--product-name cf --stemcell 3468.21]",
+				fmt.Sprintf(`configure-product [--product-name cf --config %s/cf.yml --vars-file %s/cf-vars.yml --errand-config {"push-apps-manager":{"post-deploy-state":"when-changed","pre-delete-state":"enabled"},"smoke_tests":{"post-deploy-state":"disabled"}}]`, manifestDir, manifestDir),
+				"stage-product [--product-name p-mysql --product-version 1.10.0]",
+				"assign-stemcell [--product-name p-mysql --stemcell 3468]",
+				"apply-changes []",
+			}))
+
+			Expect(stagedProducts.StagedProductsCallCount()).To(Equal(0))
+		})
+
+		It("skips a stemcell whose version and operating system have been uploaded under another file name", func() {
+			stemcellExtractor.ExtractStemcellManifestReturns(extractor.StemcellManifest{Version: "3468.21", OperatingSystem: "ubuntu-trusty"}, nil)
+			stemcellExtractor.ExtractStemcellManifestStub = nil
+			stemcellLibrary.ListReturns(api.StemcellAssignmentsOutput{
+				StemcellLibrary: []api.LibraryStemcell{{Version: "3468.21", OS: "ubuntu-trusty"}},
+			}, nil)
+			availableProducts.CheckProductAvailabilityReturns(true, nil)
+			writeManifest(`---
+products:
+- name: p-mysql
+  version: 1.10.0
+  stemcell: renamed-stemcell.tgz
+`)
+
+			command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+			err := command.Execute([]string{"--manifest", manifestPath, "--dry-run"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stemcellExtractor.ExtractStemcellManifestArgsForCall(0)).To(Equal(filepath.Join(manifestDir, "renamed-stemcell.tgz")))
+			Expect(loggedLines()).NotTo(ContainElement(ContainSubstring("upload stemcell")))
+			Expect(loggedLines()).To(ContainElement("  4. assign stemcell 3468.21 to p-mysql"))
+		})
+
+		It("unstages the products that others depend on last", func() {
+			diagnosticService.ReportReturns(api.DiagnosticReport{
+				StagedProducts: []api.DiagnosticProduct{
+					{Name: "p-bosh", Version: "2.1.0"},
+					{Name: "p-mysql", Version: "1.10.0"},
+					{Name: "p-spring-cloud-services", Version: "1.5.0"},
+					{Name: "p-redis", Version: "1.12.0"},
+				},
+			}, nil)
+			stagedProducts.StagedProductsReturns(api.StagedProductsOutput{
+				Products: []api.StagedProduct{
+					{GUID: "p-bosh-guid", Type: "p-bosh"},
+					{GUID: "p-mysql-guid", Type: "p-mysql"},
+					{GUID: "p-spring-cloud-services-guid", Type: "p-spring-cloud-services"},
+					{GUID: "p-redis-guid", Type: "p-redis"},
+				},
+			}, nil)
+			stagedProducts.DependenciesStub = func(guid string) ([]api.ProductDependency, error) {
+				if guid == "p-spring-cloud-services-guid" {
+					return []api.ProductDependency{{Type: "p-mysql", Version: "~> 1.10"}, {Type: "cf", Version: "~> 2.1"}}, nil
+				}
+				return nil, nil
+			}
+			writeManifest("products: []\n")
+
+			command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+			err := command.Execute([]string{"--manifest", manifestPath})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(executed).To(Equal([]string{
+				"unstage-product [--product-name p-spring-cloud-services]",
+				"unstage-product [--product-name p-mysql]",
+				"unstage-product [--product-name p-redis]",
+				"apply-changes []",
+			}))
+		})
+
+		It("sets the errand states of a product without a configuration with configure-product", func() {
+			availableProducts.CheckProductAvailabilityReturns(true, nil)
+			writeManifest(`---
+products:
+- name: p-mysql
+  version: 1.10.0
+  errands:
+    smoke-tests:
+      post-deploy: disabled
+`)
+
+			command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+			err := command.Execute([]string{"--manifest", manifestPath})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(executed).To(ContainElement(`configure-product [--product-name p-mysql --errand-config {"smoke-tests":{"post-deploy-state":"disabled"}}]`))
+		})
+
+		It("leaves out the steps that are already done", func() {
+			availableProducts.CheckProductAvailabilityReturns(true, nil)
+			diagnosticService.ReportReturns(api.DiagnosticReport{
+				Stemcells: []string{"bosh-stemcell-3468-new.tgz", "bosh-stemcell-3468-uploaded.tgz"},
+				StagedProducts: []api.DiagnosticProduct{
+					{Name: "p-bosh", Version: "2.1.0"},
+					{Name: "cf", Version: "2.1.0", Stemcell: "bosh-stemcell-3468-new.tgz"},
+					{Name: "p-mysql", Version: "1.10.0"},
+				},
+			}, nil)
+			writeManifest(`---
+products:
+- name: cf
+  version: 2.1.0
+  file: cf-2.1.0.pivotal
+  config: cf.yml
+  stemcell: bosh-stemcell-3468-new.tgz
+- name: p-mysql
+  version: 1.10.0
+  stemcell: bosh-stemcell-3468-uploaded.tgz
+`)
+
+			command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+			err := command.Execute([]string{"--manifest", manifestPath, "--ignore-warnings"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(executed).To(Equal([]string{
+				fmt.Sprintf("configure-product [--product-name cf --config %s/cf.yml]", manifestDir),
+				"assign-stemcell [--product-name p-mysql --stemcell 3468]",
+				"apply-changes [--ignore-warnings]",
+			}))
+		})
+
+		Context("when the upload commands upload a stemcell and a product", func() {
+			var uploadedForms [][]string
+
+			readForm := func(content io.Reader, contentType string, contentLength int64) []string {
+				body, err := ioutil.ReadAll(content)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(int64(len(body))).To(Equal(contentLength))
+
+				_, params, err := mime.ParseMediaType(contentType)
+				Expect(err).NotTo(HaveOccurred())
+
+				var parts []string
+				reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+				for {
+					part, err := reader.NextPart()
+					if err == io.EOF {
+						break
+					}
+					Expect(err).NotTo(HaveOccurred())
+
+					contents, err := ioutil.ReadAll(part)
+					Expect(err).NotTo(HaveOccurred())
+					parts = append(parts, fmt.Sprintf("%s %s: %s", part.FormName(), part.FileName(), contents))
+				}
+
+				return parts
+			}
+
+			BeforeEach(func() {
+				uploadedForms = nil
+
+				err := ioutil.WriteFile(filepath.Join(manifestDir, "bosh-stemcell-3468-new.tgz"), []byte("some stemcell"), 0644)
+				Expect(err).NotTo(HaveOccurred())
+				err = ioutil.WriteFile(filepath.Join(manifestDir, "cf-2.1.0.pivotal"), []byte("some product"), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				stemcellService := &fakes.StemcellService{}
+				stemcellService.UploadStub = func(input api.StemcellUploadInput) (api.StemcellUploadOutput, error) {
+					uploadedForms = append(uploadedForms, readForm(input.Stemcell, input.ContentType, input.ContentLength))
+					return api.StemcellUploadOutput{}, nil
+				}
+
+				productUploader := &fakes.ProductUploader{}
+				productUploader.UploadStub = func(input api.UploadProductInput) (api.UploadProductOutput, error) {
+					uploadedForms = append(uploadedForms, readForm(input.Product, input.ContentType, input.ContentLength))
+					return api.UploadProductOutput{}, nil
+				}
+
+				productExtractor := &fakes.Extractor{}
+				productExtractor.ExtractMetadataReturns("cf", "2.1.0", nil)
+
+				commandSet["upload-stemcell"] = commands.NewUploadStemcell(uploads.NewForm, stemcellService, diagnosticService, &fakes.StemcellAssignmentsService{}, &fakes.StemcellExtractor{}, &fakes.StemcellUploadPool{}, &fakes.Logger{})
+				commandSet["upload-product"] = commands.NewUploadProduct(uploads.NewForm, productExtractor, productUploader, &fakes.ProductUploadPool{}, &fakes.Logger{})
+			})
+
+			It("sends every file in a form of its own", func() {
+				command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+				err := command.Execute([]string{"--manifest", manifestPath})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(uploadedForms).To(Equal([][]string{
+					{"stemcell[file] bosh-stemcell-3468-new.tgz: some stemcell"},
+					{"product[file] cf-2.1.0.pivotal: some product"},
+				}))
+			})
+		})
+
+		Context("when configure-product can tell whether the configuration has changed", func() {
+			var configureProduct *fakes.ConfigurationChecker
+
+			BeforeEach(func() {
+				configureProduct = &fakes.ConfigurationChecker{}
+				configureProduct.ExecuteStub = func(args []string) error {
+					executed = append(executed, fmt.Sprintf("configure-product %v", args))
+					return nil
+				}
+				commandSet["configure-product"] = configureProduct
+
+				availableProducts.CheckProductAvailabilityReturns(true, nil)
+				diagnosticService.ReportReturns(api.DiagnosticReport{
+					StagedProducts: []api.DiagnosticProduct{
+						{Name: "cf", Version: "2.1.0"},
+						{Name: "p-mysql", Version: "1.9.0"},
+					},
+				}, nil)
+				writeManifest(`---
+products:
+- name: cf
+  version: 2.1.0
+  config: cf.yml
+- name: p-mysql
+  version: 1.10.0
+  config: p-mysql.yml
+`)
+			})
+
+			It("leaves out the configuration of staged products that has not changed", func() {
+				command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+				err := command.Execute([]string{"--manifest", manifestPath})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(configureProduct.ConfigurationChangedCallCount()).To(Equal(1))
+				Expect(configureProduct.ConfigurationChangedArgsForCall(0)).To(Equal([]string{"--product-name", "cf", "--config", filepath.Join(manifestDir, "cf.yml")}))
+
+				Expect(loggedLines()[0]).To(Equal("configuration of cf has not changed"))
+				Expect(executed).To(Equal([]string{
+					"stage-product [--product-name p-mysql --product-version 1.10.0]",
+					fmt.Sprintf("configure-product [--product-name p-mysql --config %s/p-mysql.yml]", manifestDir),
+					"apply-changes []",
+				}))
+			})
+
+			It("configures the staged products whose configuration has changed", func() {
+				configureProduct.ConfigurationChangedReturns(true, nil)
+
+				command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+				err := command.Execute([]string{"--manifest", manifestPath})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(executed).To(ContainElement(fmt.Sprintf("configure-product [--product-name cf --config %s/cf.yml]", manifestDir)))
+			})
+
+			Context("when the configuration cannot be compared", func() {
+				It("returns an error", func() {
+					configureProduct.ConfigurationChangedReturns(false, errors.New("some error"))
+
+					command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+					err := command.Execute([]string{"--manifest", manifestPath})
+					Expect(err).To(MatchError("failed to compare the configuration of cf: some error"))
+				})
+			})
+		})
+
+		Context("when the diagnostic report is unavailable", func() {
+			It("plans as if nothing has been uploaded or staged", func() {
+				diagnosticService.ReportReturns(api.DiagnosticReport{}, api.DiagnosticReportUnavailable{})
+				availableProducts.CheckProductAvailabilityReturns(true, nil)
+				writeManifest(`---
+products:
+- name: p-mysql
+  version: 1.10.0
+  stemcell: bosh-stemcell-3468-uploaded.tgz
+`)
+
+				command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+				err := command.Execute([]string{"--manifest", manifestPath})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(loggedLines()[0]).To(Equal("diagnostic report is currently unavailable, planning as if nothing has been uploaded or staged"))
+				Expect(executed).To(Equal([]string{
+					fmt.Sprintf("upload-stemcell [--stemcell %s/bosh-stemcell-3468-uploaded.tgz]", manifestDir),
+					"stage-product [--product-name p-mysql --product-version 1.10.0]",
+					"assign-stemcell [--product-name p-mysql --stemcell 3468]",
+					"apply-changes []",
+				}))
+			})
+		})
+
+		It("reads the name and version of a product from its product file when they are not given", func() {
+			metadataExtractor.ExtractProductMetadataReturns(extractor.Metadata{Name: "cf", ProductVersion: "2.1.0"}, nil)
+			availableProducts.CheckProductAvailabilityReturns(true, nil)
+			writeManifest(`---
+products:
+- file: /products/cf-2.1.0.pivotal
+`)
+
+			command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+			err := command.Execute([]string{"--manifest", manifestPath, "--dry-run"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(metadataExtractor.ExtractProductMetadataArgsForCall(0)).To(Equal("/products/cf-2.1.0.pivotal"))
+			Expect(loggedLines()).To(ContainElement("  2. stage product cf 2.1.0"))
+		})
+
+		Context("when --dry-run is provided", func() {
+			It("prints the plan without executing it", func() {
+				command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+				err := command.Execute([]string{"--manifest", manifestPath, "--dry-run"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(loggedLines()).To(HaveLen(11))
+				Expect(executed).To(BeEmpty())
+			})
+		})
+
+		Context("failure cases", func() {
+			Context("when an unknown flag is provided", func() {
+				It("returns an error", func() {
+					command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+					err := command.Execute([]string{"--badflag"})
+					Expect(err).To(MatchError("could not parse converge flags: flag provided but not defined: -badflag"))
+				})
+			})
+
+			Context("when the manifest flag is not provided", func() {
+				It("returns an error", func() {
+					command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+					err := command.Execute([]string{})
+					Expect(err).To(MatchError("error: manifest is missing. Please see usage for more information."))
+				})
+			})
+
+			Context("when the manifest does not exist", func() {
+				It("returns an error", func() {
+					command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+					err := command.Execute([]string{"--manifest", "/not/a/real/foundation.yml"})
+					Expect(err).To(MatchError(ContainSubstring("could not read manifest:")))
+				})
+			})
+
+			Context("when the manifest has unknown keys", func() {
+				It("returns an error", func() {
+					writeManifest("products:\n- name: cf\n  verison: 2.1.0\n")
+
+					command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+					err := command.Execute([]string{"--manifest", manifestPath})
+					Expect(err).To(MatchError(ContainSubstring("could not parse manifest:")))
+				})
+			})
+
+			Context("when the manifest is not valid", func() {
+				It("returns every error without executing anything", func() {
+					writeManifest(`---
+director:
+  az-config: []
+products:
+- name: cf
+  vars-file: cf-vars.yml
+  errands:
+    smoke_tests:
+      post-deploy: sometimes
+- name: cf
+  version: 2.1.0
+- version: 1.0.0
+`)
+
+					command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+					err := command.Execute([]string{"--manifest", manifestPath})
+					Expect(err).To(MatchError(`manifest is invalid:
+director.az-config: unknown section, expected one of iaas-configurations, az-configuration, networks-configuration, network-assignment, director-configuration, iaas-configuration, security-configuration, syslog-configuration, vmextensions-configuration, resource-configuration
+cf: version is missing
+cf: vars-file is given without a config
+cf: errand smoke_tests has invalid post-deploy state "sometimes"
+cf: is given more than once
+products[2]: name is missing`))
+
+					Expect(diagnosticService.ReportCallCount()).To(Equal(0))
+					Expect(executed).To(BeEmpty())
+				})
+			})
+
+			Context("when the product file cannot be read", func() {
+				It("returns an error", func() {
+					metadataExtractor.ExtractProductMetadataReturns(extractor.Metadata{}, errors.New("some error"))
+					writeManifest("products:\n- file: /products/cf.pivotal\n")
+
+					command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+					err := command.Execute([]string{"--manifest", manifestPath})
+					Expect(err).To(MatchError("could not read product file /products/cf.pivotal: some error"))
+				})
+			})
+
+			Context("when the diagnostic report cannot be retrieved", func() {
+				It("returns an error", func() {
+					diagnosticService.ReportReturns(api.DiagnosticReport{}, errors.New("some error"))
+
+					command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+					err := command.Execute([]string{"--manifest", manifestPath})
+					Expect(err).To(MatchError("failed to retrieve diagnostic report: some error"))
+				})
+			})
+
+			Context("when the version of a stemcell cannot be determined", func() {
+				It("returns an error", func() {
+					writeManifest("products:\n- name: cf\n  version: 2.1.0\n  stemcell: /stemcells/ubuntu.tgz\n")
+
+					command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+					err := command.Execute([]string{"--manifest", manifestPath})
+					Expect(err).To(MatchError("could not determine the version of stemcell /stemcells/ubuntu.tgz"))
+				})
+			})
+
+			Context("when the staged products cannot be listed", func() {
+				It("returns an error", func() {
+					stagedProducts.StagedProductsReturns(api.StagedProductsOutput{}, errors.New("some error"))
+					diagnosticService.ReportReturns(api.DiagnosticReport{
+						StagedProducts: []api.DiagnosticProduct{{Name: "p-mysql"}, {Name: "p-redis"}},
+					}, nil)
+					writeManifest("products: []\n")
+
+					command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+					err := command.Execute([]string{"--manifest", manifestPath})
+					Expect(err).To(MatchError("failed to list staged products: some error"))
+				})
+			})
+
+			Context("when the dependencies of a product cannot be retrieved", func() {
+				It("returns an error", func() {
+					stagedProducts.StagedProductsReturns(api.StagedProductsOutput{
+						Products: []api.StagedProduct{{GUID: "p-mysql-guid", Type: "p-mysql"}, {GUID: "p-redis-guid", Type: "p-redis"}},
+					}, nil)
+					stagedProducts.DependenciesReturns(nil, errors.New("some error"))
+					diagnosticService.ReportReturns(api.DiagnosticReport{
+						StagedProducts: []api.DiagnosticProduct{{Name: "p-mysql"}, {Name: "p-redis"}},
+					}, nil)
+					writeManifest("products: []\n")
+
+					command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+					err := command.Execute([]string{"--manifest", manifestPath})
+					Expect(err).To(MatchError("failed to retrieve dependencies of p-mysql: some error"))
+				})
+			})
+
+			Context("when the product availability cannot be checked", func() {
+				It("returns an error", func() {
+					availableProducts.CheckProductAvailabilityReturns(false, errors.New("some error"))
+
+					command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+					err := command.Execute([]string{"--manifest", manifestPath})
+					Expect(err).To(MatchError("failed to check availability of cf 2.1.0: some error"))
+				})
+			})
+
+			Context("when a step fails", func() {
+				It("returns an error without executing the remaining steps", func() {
+					commandSet["stage-product"].(*fakes.Command).ExecuteStub = nil
+					commandSet["stage-product"].(*fakes.Command).ExecuteReturns(errors.New("some error"))
+
+					command := commands.NewConverge(commandSet, diagnosticService, stagedProducts, stemcellLibrary, availableProducts, metadataExtractor, stemcellExtractor, logger)
+					err := command.Execute([]string{"--manifest", manifestPath})
+					Expect(err).To(MatchError("failed to stage product cf 2.1.0: some error"))
+
+					Expect(executed).To(HaveLen(4))
+				})
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			command := commands.NewConverge(nil, nil, nil, nil, nil, nil, nil, nil)
+			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
+				Description:      "This authenticated command converges the Ops Manager to a manifest describing the director and every product of the foundation. It prints the uploads, stagings, stemcell assignments, configurations and unstagings that are needed, executes them, and then applies changes once.",
+				ShortDescription: "converges the Ops Manager to a foundation manifest",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
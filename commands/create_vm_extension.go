@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/jhanda/flags"
+	"github.com/pivotal-cf/om/api"
+)
+
+type CreateVMExtension struct {
+	service vmExtensionCreator
+	logger  logger
+	Options struct {
+		Name            string `short:"n" long:"name" description:"name of the VM extension"`
+		CloudProperties string `short:"cp" long:"cloud-properties" description:"cloud properties in JSON format" default:"{}"`
+	}
+}
+
+//go:generate counterfeiter -o ./fakes/vm_extension_creator.go --fake-name VMExtensionCreator . vmExtensionCreator
+type vmExtensionCreator interface {
+	CreateVMExtension(api.VMExtension) error
+}
+
+func NewCreateVMExtension(service vmExtensionCreator, logger logger) CreateVMExtension {
+	return CreateVMExtension{service: service, logger: logger}
+}
+
+func (c CreateVMExtension) Execute(args []string) error {
+	_, err := flags.Parse(&c.Options, args)
+	if err != nil {
+		return fmt.Errorf("could not parse create-vm-extension flags: %s", err)
+	}
+
+	if c.Options.Name == "" {
+		return errors.New("error: name is missing. Please see usage for more information.")
+	}
+
+	var cloudProperties map[string]interface{}
+	err = json.Unmarshal([]byte(c.Options.CloudProperties), &cloudProperties)
+	if err != nil || cloudProperties == nil {
+		return fmt.Errorf("could not decode cloud-properties json: expected a JSON object")
+	}
+
+	c.logger.Printf("creating vm extension %s", c.Options.Name)
+
+	err = c.service.CreateVMExtension(api.VMExtension{
+		Name:            c.Options.Name,
+		CloudProperties: json.RawMessage(c.Options.CloudProperties),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create vm extension: %s", err)
+	}
+
+	c.logger.Printf("finished creating vm extension %s", c.Options.Name)
+
+	return nil
+}
+
+func (c CreateVMExtension) Usage() commands.Usage {
+	return commands.Usage{
+		Description:      "This authenticated command creates a VM extension, replacing the cloud properties of any existing VM extension with the same name.",
+		ShortDescription: "creates or updates a VM extension",
+		Flags:            c.Options,
+	}
+}
@@ -0,0 +1,106 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	jhandacommands "github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CreateVMExtension", func() {
+	var (
+		service *fakes.VMExtensionCreator
+		logger  *fakes.Logger
+	)
+
+	BeforeEach(func() {
+		service = &fakes.VMExtensionCreator{}
+		logger = &fakes.Logger{}
+	})
+
+	Describe("Execute", func() {
+		It("creates the vm extension", func() {
+			command := commands.NewCreateVMExtension(service, logger)
+			err := command.Execute([]string{
+				"--name", "some-extension",
+				"--cloud-properties", `{"elbs": ["some-elb"]}`,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.CreateVMExtensionCallCount()).To(Equal(1))
+			Expect(service.CreateVMExtensionArgsForCall(0)).To(Equal(api.VMExtension{
+				Name:            "some-extension",
+				CloudProperties: json.RawMessage(`{"elbs": ["some-elb"]}`),
+			}))
+
+			format, content := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("creating vm extension some-extension"))
+
+			format, content = logger.PrintfArgsForCall(1)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("finished creating vm extension some-extension"))
+		})
+
+		It("defaults to empty cloud properties", func() {
+			command := commands.NewCreateVMExtension(service, logger)
+			err := command.Execute([]string{"--name", "some-extension"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.CreateVMExtensionArgsForCall(0).CloudProperties).To(MatchJSON(`{}`))
+		})
+
+		Context("failure cases", func() {
+			Context("when an unknown flag is provided", func() {
+				It("returns an error", func() {
+					command := commands.NewCreateVMExtension(service, logger)
+					err := command.Execute([]string{"--badflag"})
+					Expect(err).To(MatchError("could not parse create-vm-extension flags: flag provided but not defined: -badflag"))
+				})
+			})
+
+			Context("when the name flag is not provided", func() {
+				It("returns an error", func() {
+					command := commands.NewCreateVMExtension(service, logger)
+					err := command.Execute([]string{"--cloud-properties", "{}"})
+					Expect(err).To(MatchError("error: name is missing. Please see usage for more information."))
+				})
+			})
+
+			Context("when the cloud properties are not a JSON object", func() {
+				It("returns an error", func() {
+					command := commands.NewCreateVMExtension(service, logger)
+					err := command.Execute([]string{"--name", "some-extension", "--cloud-properties", `["some-elb"]`})
+					Expect(err).To(MatchError("could not decode cloud-properties json: expected a JSON object"))
+					Expect(service.CreateVMExtensionCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the vm extension cannot be created", func() {
+				It("returns an error", func() {
+					service.CreateVMExtensionReturns(errors.New("some error"))
+
+					command := commands.NewCreateVMExtension(service, logger)
+					err := command.Execute([]string{"--name", "some-extension"})
+					Expect(err).To(MatchError("failed to create vm extension: some error"))
+				})
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			command := commands.NewCreateVMExtension(nil, nil)
+			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
+				Description:      "This authenticated command creates a VM extension, replacing the cloud properties of any existing VM extension with the same name.",
+				ShortDescription: "creates or updates a VM extension",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
@@ -1,38 +1,176 @@
 package commands
 
 import (
+	"errors"
+	"fmt"
+	"sort"
+
 	"github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/jhanda/flags"
 	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/semver"
 )
 
 type DeleteUnusedProducts struct {
-	productsService ps
-	logger          logger
+	productsService   unusedProductsService
+	diagnosticService diagnosticService
+	logger            logger
+	Options           struct {
+		ProductName string `short:"p"  long:"product-name"  description:"only delete unused versions of this product"`
+		Keep        int    `short:"k"  long:"keep"  description:"number of unused versions of each product to keep, newest first (default: 0)"`
+		DryRun      bool   `long:"dry-run"  description:"list the products that would be deleted without deleting them"`
+	}
+}
+
+//go:generate counterfeiter -o ./fakes/unused_products_service.go --fake-name UnusedProductsService . unusedProductsService
+type unusedProductsService interface {
+	List() (api.AvailableProductsOutput, error)
+	Delete(input api.AvailableProductsInput, deleteAll bool) error
 }
 
-func NewDeleteUnusedProducts(productDeleter ps, logger logger) DeleteUnusedProducts {
+func NewDeleteUnusedProducts(productsService unusedProductsService, diagnosticService diagnosticService, logger logger) DeleteUnusedProducts {
 	return DeleteUnusedProducts{
-		productsService: productDeleter,
-		logger:          logger,
+		productsService:   productsService,
+		diagnosticService: diagnosticService,
+		logger:            logger,
 	}
 }
 
 func (dup DeleteUnusedProducts) Execute(args []string) error {
-	dup.logger.Printf("trashing unused products")
+	_, err := flags.Parse(&dup.Options, args)
+	if err != nil {
+		return fmt.Errorf("could not parse delete-unused-products flags: %s", err)
+	}
+
+	if dup.Options.Keep < 0 {
+		return errors.New("error: keep must not be negative. Please see usage for more information.")
+	}
+
+	if dup.Options.ProductName == "" && dup.Options.Keep == 0 && !dup.Options.DryRun {
+		dup.logger.Printf("trashing unused products")
+
+		err := dup.productsService.Delete(api.AvailableProductsInput{}, true)
+		if err != nil {
+			return err
+		}
+
+		dup.logger.Printf("done")
 
-	err := dup.productsService.Delete(api.AvailableProductsInput{}, true)
+		return nil
+	}
+
+	unusedProducts, err := dup.unusedProducts()
 	if err != nil {
 		return err
 	}
 
+	if len(unusedProducts) == 0 {
+		dup.logger.Printf("no unused products to delete")
+		return nil
+	}
+
+	if dup.Options.DryRun {
+		for _, product := range unusedProducts {
+			dup.logger.Printf("would delete %s %s", product.Name, product.Version)
+		}
+
+		return nil
+	}
+
+	for _, product := range unusedProducts {
+		dup.logger.Printf("deleting %s %s", product.Name, product.Version)
+
+		err := dup.productsService.Delete(api.AvailableProductsInput{
+			ProductName:    product.Name,
+			ProductVersion: product.Version,
+		}, false)
+		if err != nil {
+			return fmt.Errorf("failed to delete %s %s: %s", product.Name, product.Version, err)
+		}
+	}
+
 	dup.logger.Printf("done")
 
 	return nil
 }
 
+func (dup DeleteUnusedProducts) unusedProducts() ([]api.ProductInfo, error) {
+	availableProducts, err := dup.productsService.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list available products: %s", err)
+	}
+
+	report, err := dup.diagnosticService.Report()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve diagnostic report: %s", err)
+	}
+
+	used := map[api.ProductInfo]bool{}
+	for _, product := range append(report.StagedProducts, report.DeployedProducts...) {
+		used[api.ProductInfo{Name: product.Name, Version: product.Version}] = true
+	}
+
+	unused := map[string][]api.ProductInfo{}
+	var names []string
+	for _, product := range availableProducts.ProductsList {
+		if used[product] {
+			continue
+		}
+
+		if dup.Options.ProductName != "" && product.Name != dup.Options.ProductName {
+			continue
+		}
+
+		if _, ok := unused[product.Name]; !ok {
+			names = append(names, product.Name)
+		}
+		unused[product.Name] = append(unused[product.Name], product)
+	}
+
+	sort.Strings(names)
+
+	var products []api.ProductInfo
+	for _, name := range names {
+		versions := unused[name]
+		sort.SliceStable(versions, func(i, j int) bool {
+			return compareProductVersions(versions[i].Version, versions[j].Version) > 0
+		})
+
+		if dup.Options.Keep >= len(versions) {
+			continue
+		}
+
+		products = append(products, versions[dup.Options.Keep:]...)
+	}
+
+	return products, nil
+}
+
+func compareProductVersions(a, b string) int {
+	aVersion, aErr := semver.Parse(a)
+	bVersion, bErr := semver.Parse(b)
+
+	switch {
+	case aErr == nil && bErr == nil:
+		return aVersion.Compare(bVersion)
+	case aErr == nil:
+		return 1
+	case bErr == nil:
+		return -1
+	default:
+		if a < b {
+			return -1
+		} else if a > b {
+			return 1
+		}
+		return 0
+	}
+}
+
 func (dup DeleteUnusedProducts) Usage() commands.Usage {
 	return commands.Usage{
-		Description:      "This command deletes unused products in the targeted Ops Manager",
+		Description:      "This command deletes unused products in the targeted Ops Manager. Products that are staged or deployed are never deleted.",
 		ShortDescription: "deletes unused products on the Ops Manager targeted",
+		Flags:            dup.Options,
 	}
 }
@@ -13,27 +13,51 @@ import (
 	. "github.com/onsi/gomega"
 )
 
-var _ = Describe("DeleteProduct", func() {
+var _ = Describe("DeleteUnusedProducts", func() {
 	var (
-		command commands.DeleteUnusedProducts
-		deleter *fakes.ProductDeleter
-		logger  *fakes.Logger
+		command           commands.DeleteUnusedProducts
+		productsService   *fakes.UnusedProductsService
+		diagnosticService *fakes.DiagnosticService
+		logger            *fakes.Logger
 	)
 
 	BeforeEach(func() {
-		deleter = &fakes.ProductDeleter{}
+		productsService = &fakes.UnusedProductsService{}
+		diagnosticService = &fakes.DiagnosticService{}
 		logger = &fakes.Logger{}
-		command = commands.NewDeleteUnusedProducts(deleter, logger)
+		command = commands.NewDeleteUnusedProducts(productsService, diagnosticService, logger)
+
+		productsService.ListReturns(api.AvailableProductsOutput{
+			ProductsList: []api.ProductInfo{
+				{Name: "cf", Version: "1.10.0"},
+				{Name: "cf", Version: "1.9.2"},
+				{Name: "cf", Version: "1.11.0"},
+				{Name: "cf", Version: "1.8.0"},
+				{Name: "cf", Version: "1.12.0"},
+				{Name: "mysql", Version: "2.0.0"},
+				{Name: "mysql", Version: "1.0.0"},
+			},
+		}, nil)
+
+		diagnosticService.ReportReturns(api.DiagnosticReport{
+			StagedProducts: []api.DiagnosticProduct{
+				{Name: "cf", Version: "1.12.0"},
+			},
+			DeployedProducts: []api.DiagnosticProduct{
+				{Name: "cf", Version: "1.11.0"},
+				{Name: "mysql", Version: "2.0.0"},
+			},
+		}, nil)
 	})
 
 	Describe("Execute", func() {
-		It("deletes all the product", func() {
+		It("deletes all the unused products", func() {
 			err := command.Execute([]string{})
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(deleter.DeleteCallCount()).To(Equal(1))
+			Expect(productsService.DeleteCallCount()).To(Equal(1))
 
-			input, deleteAll := deleter.DeleteArgsForCall(0)
+			input, deleteAll := productsService.DeleteArgsForCall(0)
 			Expect(input).To(Equal(api.AvailableProductsInput{}))
 			Expect(deleteAll).To(BeTrue())
 
@@ -43,25 +67,140 @@ var _ = Describe("DeleteProduct", func() {
 			format, content = logger.PrintfArgsForCall(1)
 			Expect(fmt.Sprintf(format, content...)).To(Equal("done"))
 		})
+
+		Context("when keep is provided", func() {
+			It("keeps the newest unused versions of each product and deletes the rest one by one", func() {
+				err := command.Execute([]string{"--keep", "1"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(productsService.DeleteCallCount()).To(Equal(2))
+
+				input, deleteAll := productsService.DeleteArgsForCall(0)
+				Expect(input).To(Equal(api.AvailableProductsInput{ProductName: "cf", ProductVersion: "1.9.2"}))
+				Expect(deleteAll).To(BeFalse())
+
+				input, deleteAll = productsService.DeleteArgsForCall(1)
+				Expect(input).To(Equal(api.AvailableProductsInput{ProductName: "cf", ProductVersion: "1.8.0"}))
+				Expect(deleteAll).To(BeFalse())
+
+				format, content := logger.PrintfArgsForCall(0)
+				Expect(fmt.Sprintf(format, content...)).To(Equal("deleting cf 1.9.2"))
+
+				format, content = logger.PrintfArgsForCall(1)
+				Expect(fmt.Sprintf(format, content...)).To(Equal("deleting cf 1.8.0"))
+
+				format, content = logger.PrintfArgsForCall(2)
+				Expect(fmt.Sprintf(format, content...)).To(Equal("done"))
+			})
+		})
+
+		Context("when product-name is provided", func() {
+			It("only deletes unused versions of that product", func() {
+				err := command.Execute([]string{"--product-name", "mysql"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(productsService.DeleteCallCount()).To(Equal(1))
+
+				input, deleteAll := productsService.DeleteArgsForCall(0)
+				Expect(input).To(Equal(api.AvailableProductsInput{ProductName: "mysql", ProductVersion: "1.0.0"}))
+				Expect(deleteAll).To(BeFalse())
+			})
+		})
+
+		Context("when dry-run is provided", func() {
+			It("lists the products that would be deleted", func() {
+				err := command.Execute([]string{"--dry-run"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(productsService.DeleteCallCount()).To(Equal(0))
+
+				var lines []string
+				for i := 0; i < logger.PrintfCallCount(); i++ {
+					format, content := logger.PrintfArgsForCall(i)
+					lines = append(lines, fmt.Sprintf(format, content...))
+				}
+
+				Expect(lines).To(Equal([]string{
+					"would delete cf 1.10.0",
+					"would delete cf 1.9.2",
+					"would delete cf 1.8.0",
+					"would delete mysql 1.0.0",
+				}))
+			})
+		})
+
+		Context("when there is nothing to delete", func() {
+			It("says so", func() {
+				err := command.Execute([]string{"--keep", "5"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(productsService.DeleteCallCount()).To(Equal(0))
+
+				format, content := logger.PrintfArgsForCall(0)
+				Expect(fmt.Sprintf(format, content...)).To(Equal("no unused products to delete"))
+			})
+		})
 	})
 
 	Context("when an error occurs", func() {
+		Context("when an unknown flag is provided", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{"--badflag"})
+				Expect(err).To(MatchError("could not parse delete-unused-products flags: flag provided but not defined: -badflag"))
+			})
+		})
+
+		Context("when keep is negative", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{"--keep", "-1"})
+				Expect(err).To(MatchError("error: keep must not be negative. Please see usage for more information."))
+			})
+		})
+
 		Context("when deleting all products fails", func() {
 			It("returns an error", func() {
-				deleter.DeleteReturns(errors.New("something bad happened"))
+				productsService.DeleteReturns(errors.New("something bad happened"))
 
-				err := command.Execute([]string{"-p", "nah", "-v", "nope"})
+				err := command.Execute([]string{})
 				Expect(err).To(MatchError("something bad happened"))
 			})
 		})
+
+		Context("when deleting a single product fails", func() {
+			It("returns an error", func() {
+				productsService.DeleteReturns(errors.New("something bad happened"))
+
+				err := command.Execute([]string{"--keep", "1"})
+				Expect(err).To(MatchError("failed to delete cf 1.9.2: something bad happened"))
+			})
+		})
+
+		Context("when the available products cannot be listed", func() {
+			It("returns an error", func() {
+				productsService.ListReturns(api.AvailableProductsOutput{}, errors.New("something bad happened"))
+
+				err := command.Execute([]string{"--dry-run"})
+				Expect(err).To(MatchError("failed to list available products: something bad happened"))
+			})
+		})
+
+		Context("when the diagnostic report cannot be fetched", func() {
+			It("returns an error", func() {
+				diagnosticService.ReportReturns(api.DiagnosticReport{}, errors.New("something bad happened"))
+
+				err := command.Execute([]string{"--dry-run"})
+				Expect(err).To(MatchError("failed to retrieve diagnostic report: something bad happened"))
+			})
+		})
 	})
 
 	Describe("Usage", func() {
 		It("returns the usage", func() {
 			usage := command.Usage()
 			Expect(usage).To(Equal(jhandacommands.Usage{
-				Description:      "This command deletes unused products in the targeted Ops Manager",
+				Description:      "This command deletes unused products in the targeted Ops Manager. Products that are staged or deployed are never deleted.",
 				ShortDescription: "deletes unused products on the Ops Manager targeted",
+				Flags:            command.Options,
 			}))
 		})
 	})
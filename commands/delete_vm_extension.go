@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/jhanda/flags"
+)
+
+type DeleteVMExtension struct {
+	service vmExtensionDeleter
+	logger  logger
+	Options struct {
+		Name string `short:"n" long:"name" description:"name of the VM extension"`
+	}
+}
+
+//go:generate counterfeiter -o ./fakes/vm_extension_deleter.go --fake-name VMExtensionDeleter . vmExtensionDeleter
+type vmExtensionDeleter interface {
+	DeleteVMExtension(name string) error
+}
+
+func NewDeleteVMExtension(service vmExtensionDeleter, logger logger) DeleteVMExtension {
+	return DeleteVMExtension{service: service, logger: logger}
+}
+
+func (d DeleteVMExtension) Execute(args []string) error {
+	_, err := flags.Parse(&d.Options, args)
+	if err != nil {
+		return fmt.Errorf("could not parse delete-vm-extension flags: %s", err)
+	}
+
+	if d.Options.Name == "" {
+		return errors.New("error: name is missing. Please see usage for more information.")
+	}
+
+	d.logger.Printf("deleting vm extension %s", d.Options.Name)
+
+	err = d.service.DeleteVMExtension(d.Options.Name)
+	if err != nil {
+		return fmt.Errorf("failed to delete vm extension: %s", err)
+	}
+
+	d.logger.Printf("finished deleting vm extension %s", d.Options.Name)
+
+	return nil
+}
+
+func (d DeleteVMExtension) Usage() commands.Usage {
+	return commands.Usage{
+		Description:      "This authenticated command deletes a VM extension.",
+		ShortDescription: "deletes a VM extension",
+		Flags:            d.Options,
+	}
+}
@@ -0,0 +1,81 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+
+	jhandacommands "github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeleteVMExtension", func() {
+	var (
+		service *fakes.VMExtensionDeleter
+		logger  *fakes.Logger
+	)
+
+	BeforeEach(func() {
+		service = &fakes.VMExtensionDeleter{}
+		logger = &fakes.Logger{}
+	})
+
+	Describe("Execute", func() {
+		It("deletes the vm extension", func() {
+			command := commands.NewDeleteVMExtension(service, logger)
+			err := command.Execute([]string{"--name", "some-extension"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.DeleteVMExtensionCallCount()).To(Equal(1))
+			Expect(service.DeleteVMExtensionArgsForCall(0)).To(Equal("some-extension"))
+
+			format, content := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("deleting vm extension some-extension"))
+
+			format, content = logger.PrintfArgsForCall(1)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("finished deleting vm extension some-extension"))
+		})
+
+		Context("failure cases", func() {
+			Context("when an unknown flag is provided", func() {
+				It("returns an error", func() {
+					command := commands.NewDeleteVMExtension(service, logger)
+					err := command.Execute([]string{"--badflag"})
+					Expect(err).To(MatchError("could not parse delete-vm-extension flags: flag provided but not defined: -badflag"))
+				})
+			})
+
+			Context("when the name flag is not provided", func() {
+				It("returns an error", func() {
+					command := commands.NewDeleteVMExtension(service, logger)
+					err := command.Execute([]string{})
+					Expect(err).To(MatchError("error: name is missing. Please see usage for more information."))
+				})
+			})
+
+			Context("when the vm extension cannot be deleted", func() {
+				It("returns an error", func() {
+					service.DeleteVMExtensionReturns(errors.New("some error"))
+
+					command := commands.NewDeleteVMExtension(service, logger)
+					err := command.Execute([]string{"--name", "some-extension"})
+					Expect(err).To(MatchError("failed to delete vm extension: some error"))
+				})
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			command := commands.NewDeleteVMExtension(nil, nil)
+			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
+				Description:      "This authenticated command deletes a VM extension.",
+				ShortDescription: "deletes a VM extension",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
@@ -0,0 +1,382 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/jhanda/flags"
+	tile "github.com/pivotal-cf/om/extractor"
+)
+
+type DiffProductMetadata struct {
+	extractor metadataExtractor
+	logger    logger
+	Options   struct {
+		Old        string `long:"old"  description:"path to the product currently in use"`
+		New        string `long:"new"  description:"path to the product to upgrade to"`
+		ConfigFile string `short:"c"  long:"config"  description:"path to yml file containing the product configuration to check against the new product"`
+		VarsFile   string `long:"vars-file"  description:"path to yml file containing values for ((placeholders)) in the config file"`
+	}
+}
+
+type metadataDiff struct {
+	sections []string
+}
+
+func NewDiffProductMetadata(extractor metadataExtractor, logger logger) DiffProductMetadata {
+	return DiffProductMetadata{
+		extractor: extractor,
+		logger:    logger,
+	}
+}
+
+func (dpm DiffProductMetadata) Execute(args []string) error {
+	_, err := flags.Parse(&dpm.Options, args)
+	if err != nil {
+		return fmt.Errorf("could not parse diff-product-metadata flags: %s", err)
+	}
+
+	if dpm.Options.Old == "" {
+		return errors.New("error: old is missing. Please see usage for more information.")
+	}
+
+	if dpm.Options.New == "" {
+		return errors.New("error: new is missing. Please see usage for more information.")
+	}
+
+	oldMetadata, err := dpm.extractor.ExtractProductMetadata(dpm.Options.Old)
+	if err != nil {
+		return fmt.Errorf("failed to extract metadata from %s: %s", dpm.Options.Old, err)
+	}
+
+	newMetadata, err := dpm.extractor.ExtractProductMetadata(dpm.Options.New)
+	if err != nil {
+		return fmt.Errorf("failed to extract metadata from %s: %s", dpm.Options.New, err)
+	}
+
+	var config *productConfiguration
+	if dpm.Options.ConfigFile != "" {
+		loaded, err := loadProductConfiguration(dpm.Options.ConfigFile, dpm.Options.VarsFile)
+		if err != nil {
+			return err
+		}
+		config = &loaded
+	}
+
+	diff := &metadataDiff{}
+	renames := diff.properties(oldMetadata, newMetadata)
+	diff.jobs(oldMetadata, newMetadata)
+	diff.stemcellCriteria(oldMetadata.StemcellCriteria, newMetadata.StemcellCriteria)
+	diff.dependencies(oldMetadata.RequiresProductVersions, newMetadata.RequiresProductVersions)
+
+	if config != nil {
+		diff.config(*config, newMetadata, renames)
+	}
+
+	dpm.logger.Printf("comparing %s %s with %s %s", oldMetadata.Name, oldMetadata.ProductVersion, newMetadata.Name, newMetadata.ProductVersion)
+
+	if len(diff.sections) == 0 {
+		dpm.logger.Printf("no differences found")
+		return nil
+	}
+
+	dpm.logger.Printf("%s", strings.Join(diff.sections, "\n"))
+
+	return nil
+}
+
+func (dpm DiffProductMetadata) Usage() commands.Usage {
+	return commands.Usage{
+		Description:      "This command compares the metadata of two versions of a product file and lists the properties, jobs, errands, stemcell criteria and dependencies that changed. When a config file is given, it also reports configured keys that no longer exist and properties that become required.",
+		ShortDescription: "compares the metadata of two product files",
+		Flags:            dpm.Options,
+	}
+}
+
+func (d *metadataDiff) add(title string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+
+	d.sections = append(d.sections, fmt.Sprintf("%s:\n  %s", title, strings.Join(lines, "\n  ")))
+}
+
+// properties reports the differences between the properties that are
+// configurable in either product and returns the properties that appear to
+// have been renamed. A property is treated as renamed when it is the only
+// property of its type removed from a parent and exactly one property of that
+// type was added to it.
+func (d *metadataDiff) properties(oldMetadata, newMetadata tile.Metadata) map[string]string {
+	oldProperties := propertyBlueprints(oldMetadata)
+	newProperties := propertyBlueprints(newMetadata)
+
+	var removed, added []string
+	for _, reference := range sortedPropertyReferences(oldProperties) {
+		if _, ok := newProperties[reference]; !ok && oldProperties[reference].Configurable {
+			removed = append(removed, reference)
+		}
+	}
+
+	for _, reference := range sortedPropertyReferences(newProperties) {
+		if _, ok := oldProperties[reference]; !ok && newProperties[reference].Configurable {
+			added = append(added, reference)
+		}
+	}
+
+	group := func(references []string, properties map[string]tile.PropertyBlueprint) map[string][]string {
+		groups := map[string][]string{}
+		for _, reference := range references {
+			key := fmt.Sprintf("%s %s", reference[:strings.LastIndex(reference, ".")], properties[reference].Type)
+			groups[key] = append(groups[key], reference)
+		}
+		return groups
+	}
+
+	removedGroups := group(removed, oldProperties)
+	addedGroups := group(added, newProperties)
+
+	renames := map[string]string{}
+	for key, references := range removedGroups {
+		if len(references) == 1 && len(addedGroups[key]) == 1 {
+			renames[references[0]] = addedGroups[key][0]
+		}
+	}
+
+	renamedTo := map[string]bool{}
+	for _, to := range renames {
+		renamedTo[to] = true
+	}
+
+	var addedLines, removedLines, renamedLines, changedLines []string
+	for _, reference := range added {
+		if renamedTo[reference] {
+			continue
+		}
+
+		description := newProperties[reference].Type
+		if newProperties[reference].Required() {
+			description += ", required"
+		}
+		addedLines = append(addedLines, fmt.Sprintf("%s (%s)", reference, description))
+	}
+
+	for _, reference := range removed {
+		if to, ok := renames[reference]; ok {
+			renamedLines = append(renamedLines, fmt.Sprintf("%s -> %s", reference, to))
+			continue
+		}
+		removedLines = append(removedLines, reference)
+	}
+
+	for _, reference := range sortedPropertyReferences(oldProperties) {
+		newProperty, ok := newProperties[reference]
+		if !ok || !(oldProperties[reference].Configurable || newProperty.Configurable) {
+			continue
+		}
+
+		for _, change := range propertyChanges(oldProperties[reference], newProperty) {
+			changedLines = append(changedLines, fmt.Sprintf("%s: %s", reference, change))
+		}
+	}
+
+	d.add("properties added", addedLines)
+	d.add("properties removed", removedLines)
+	d.add("properties renamed", renamedLines)
+	d.add("properties changed", changedLines)
+
+	return renames
+}
+
+func propertyChanges(oldProperty, newProperty tile.PropertyBlueprint) []string {
+	var changes []string
+
+	if oldProperty.Type != newProperty.Type {
+		changes = append(changes, fmt.Sprintf("type changed from %s to %s", oldProperty.Type, newProperty.Type))
+	}
+
+	if oldProperty.Configurable != newProperty.Configurable {
+		if newProperty.Configurable {
+			changes = append(changes, "is now configurable")
+		} else {
+			changes = append(changes, "is no longer configurable")
+		}
+	}
+
+	if oldProperty.Required() != newProperty.Required() {
+		if newProperty.Required() {
+			changes = append(changes, "is now required")
+		} else {
+			changes = append(changes, "is no longer required")
+		}
+	}
+
+	if !reflect.DeepEqual(oldProperty.Default, newProperty.Default) {
+		changes = append(changes, fmt.Sprintf("default changed from %s to %s", describeValue(oldProperty.Default), describeValue(newProperty.Default)))
+	}
+
+	oldOptions := oldProperty.SelectValues()
+	newOptions := newProperty.SelectValues()
+	for _, option := range newOptions {
+		if !contains(oldOptions, option) {
+			changes = append(changes, fmt.Sprintf("option %q added", option))
+		}
+	}
+	for _, option := range oldOptions {
+		if !contains(newOptions, option) {
+			changes = append(changes, fmt.Sprintf("option %q removed", option))
+		}
+	}
+
+	return changes
+}
+
+func (d *metadataDiff) jobs(oldMetadata, newMetadata tile.Metadata) {
+	jobNames := func(metadata tile.Metadata, errands bool) []string {
+		var names []string
+		for _, job := range metadata.JobTypes {
+			if job.Errand == errands {
+				names = append(names, job.Name)
+			}
+		}
+		return names
+	}
+
+	for _, kind := range []struct {
+		title   string
+		errands bool
+	}{{"jobs", false}, {"errands", true}} {
+		oldNames := jobNames(oldMetadata, kind.errands)
+		newNames := jobNames(newMetadata, kind.errands)
+
+		var added, removed []string
+		for _, name := range newNames {
+			if !contains(oldNames, name) {
+				added = append(added, name)
+			}
+		}
+		for _, name := range oldNames {
+			if !contains(newNames, name) {
+				removed = append(removed, name)
+			}
+		}
+
+		d.add(kind.title+" added", added)
+		d.add(kind.title+" removed", removed)
+	}
+}
+
+func (d *metadataDiff) stemcellCriteria(oldCriteria, newCriteria tile.StemcellCriteria) {
+	var changes []string
+
+	if oldCriteria.OS != newCriteria.OS {
+		changes = append(changes, fmt.Sprintf("os changed from %s to %s", oldCriteria.OS, newCriteria.OS))
+	}
+
+	if oldCriteria.Version != newCriteria.Version {
+		changes = append(changes, fmt.Sprintf("version changed from %s to %s", oldCriteria.Version, newCriteria.Version))
+	}
+
+	if oldCriteria.RequiresCPI != newCriteria.RequiresCPI {
+		changes = append(changes, fmt.Sprintf("requires_cpi changed from %t to %t", oldCriteria.RequiresCPI, newCriteria.RequiresCPI))
+	}
+
+	d.add("stemcell criteria changed", changes)
+}
+
+func (d *metadataDiff) dependencies(oldDependencies, newDependencies []tile.ProductDependency) {
+	oldVersions := map[string]string{}
+	for _, dependency := range oldDependencies {
+		oldVersions[dependency.Name] = dependency.Version
+	}
+
+	newVersions := map[string]string{}
+	for _, dependency := range newDependencies {
+		newVersions[dependency.Name] = dependency.Version
+	}
+
+	var added, removed, changed []string
+	for _, dependency := range newDependencies {
+		version, ok := oldVersions[dependency.Name]
+		switch {
+		case !ok:
+			added = append(added, fmt.Sprintf("%s %s", dependency.Name, dependency.Version))
+		case version != dependency.Version:
+			changed = append(changed, fmt.Sprintf("%s changed from %s to %s", dependency.Name, version, dependency.Version))
+		}
+	}
+
+	for _, dependency := range oldDependencies {
+		if _, ok := newVersions[dependency.Name]; !ok {
+			removed = append(removed, fmt.Sprintf("%s %s", dependency.Name, dependency.Version))
+		}
+	}
+
+	d.add("dependencies added", added)
+	d.add("dependencies removed", removed)
+	d.add("dependencies changed", changed)
+}
+
+func (d *metadataDiff) config(config productConfiguration, newMetadata tile.Metadata, renames map[string]string) {
+	var issues []string
+
+	schema := schemaFromMetadata(newMetadata)
+	properties := jsonCompatible(config.ProductProperties).(map[string]interface{})
+
+	for _, reference := range sortedKeys(properties) {
+		if _, ok := schema[reference]; ok {
+			continue
+		}
+
+		if to, ok := renames[reference]; ok {
+			issues = append(issues, fmt.Sprintf("%s: no longer exists, it appears to have been renamed to %s", reference, to))
+		} else {
+			issues = append(issues, fmt.Sprintf("%s: no longer exists", reference))
+		}
+	}
+
+	for _, reference := range schema.references() {
+		if _, ok := properties[reference]; ok || !schema[reference].Required {
+			continue
+		}
+
+		if schema.active(reference, properties) {
+			issues = append(issues, fmt.Sprintf("%s: is required but not set", reference))
+		}
+	}
+
+	jobs := map[string]bool{}
+	for _, job := range newMetadata.JobTypes {
+		jobs[job.Name] = true
+	}
+
+	for _, job := range sortedKeys(config.ResourceConfig) {
+		if !jobs[job] {
+			issues = append(issues, fmt.Sprintf("resource-config.%s: job no longer exists", job))
+		}
+	}
+
+	d.add("config file", issues)
+}
+
+func propertyBlueprints(metadata tile.Metadata) map[string]tile.PropertyBlueprint {
+	properties := map[string]tile.PropertyBlueprint{}
+	for _, property := range metadata.Properties() {
+		properties[property.Reference] = property.Blueprint
+	}
+
+	return properties
+}
+
+func sortedPropertyReferences(properties map[string]tile.PropertyBlueprint) []string {
+	var references []string
+	for reference := range properties {
+		references = append(references, reference)
+	}
+
+	sort.Strings(references)
+	return references
+}
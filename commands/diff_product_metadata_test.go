@@ -0,0 +1,309 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	jhandacommands "github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/extractor"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DiffProductMetadata", func() {
+	var (
+		metadataExtractor *fakes.MetadataExtractor
+		logger            *fakes.Logger
+		oldMetadata       extractor.Metadata
+		newMetadata       extractor.Metadata
+	)
+
+	BeforeEach(func() {
+		metadataExtractor = &fakes.MetadataExtractor{}
+		logger = &fakes.Logger{}
+
+		oldMetadata = extractor.Metadata{
+			Name:           "some-product",
+			ProductVersion: "1.0.0",
+			PropertyBlueprints: []extractor.PropertyBlueprint{
+				{Name: "some-string", Type: "string", Configurable: true},
+				{Name: "some-port", Type: "port", Configurable: true, Default: 8080},
+				{Name: "old-name", Type: "boolean", Configurable: true, Default: false},
+				{Name: "some-removed", Type: "text", Configurable: true, Optional: true},
+				{Name: "some-integer", Type: "integer", Configurable: true, Optional: true},
+				{Name: "not-configurable", Type: "string"},
+				{
+					Name:         "some-dropdown",
+					Type:         "dropdown_select",
+					Configurable: true,
+					Default:      "small",
+					Options: []extractor.PropertyOption{
+						{Name: "small"},
+						{Name: "medium"},
+					},
+				},
+			},
+			JobTypes: []extractor.JobType{
+				{Name: "some-job"},
+				{Name: "some-old-job"},
+				{Name: "some-errand", Errand: true},
+			},
+			StemcellCriteria: extractor.StemcellCriteria{OS: "ubuntu-trusty", Version: "3468"},
+			RequiresProductVersions: []extractor.ProductDependency{
+				{Name: "cf", Version: "~> 2.0"},
+				{Name: "some-removed-dependency", Version: "~> 1.0"},
+			},
+		}
+
+		newMetadata = extractor.Metadata{
+			Name:           "some-product",
+			ProductVersion: "1.1.0",
+			PropertyBlueprints: []extractor.PropertyBlueprint{
+				{Name: "some-string", Type: "string", Configurable: true},
+				{Name: "some-port", Type: "port", Configurable: true, Default: 9090},
+				{Name: "new-name", Type: "boolean", Configurable: true, Default: false},
+				{Name: "some-integer", Type: "string", Configurable: true},
+				{Name: "some-added", Type: "string", Configurable: true},
+				{Name: "not-configurable", Type: "string"},
+				{
+					Name:         "some-dropdown",
+					Type:         "dropdown_select",
+					Configurable: true,
+					Default:      "small",
+					Options: []extractor.PropertyOption{
+						{Name: "small"},
+						{Name: "large"},
+					},
+				},
+			},
+			JobTypes: []extractor.JobType{
+				{Name: "some-job"},
+				{Name: "some-new-job"},
+				{Name: "some-errand", Errand: true},
+				{Name: "some-new-errand", Errand: true},
+			},
+			StemcellCriteria: extractor.StemcellCriteria{OS: "ubuntu-xenial", Version: "97"},
+			RequiresProductVersions: []extractor.ProductDependency{
+				{Name: "cf", Version: "~> 2.1"},
+				{Name: "some-added-dependency", Version: "~> 3.0"},
+			},
+		}
+
+		metadataExtractor.ExtractProductMetadataStub = func(path string) (extractor.Metadata, error) {
+			if path == "/path/to/new.pivotal" {
+				return newMetadata, nil
+			}
+			return oldMetadata, nil
+		}
+	})
+
+	Describe("Execute", func() {
+		It("reports the differences between the two products", func() {
+			command := commands.NewDiffProductMetadata(metadataExtractor, logger)
+
+			err := command.Execute([]string{
+				"--old", "/path/to/old.pivotal",
+				"--new", "/path/to/new.pivotal",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(metadataExtractor.ExtractProductMetadataCallCount()).To(Equal(2))
+			Expect(metadataExtractor.ExtractProductMetadataArgsForCall(0)).To(Equal("/path/to/old.pivotal"))
+			Expect(metadataExtractor.ExtractProductMetadataArgsForCall(1)).To(Equal("/path/to/new.pivotal"))
+
+			Expect(logger.PrintfCallCount()).To(Equal(2))
+
+			format, content := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("comparing some-product 1.0.0 with some-product 1.1.0"))
+
+			format, content = logger.PrintfArgsForCall(1)
+			Expect(fmt.Sprintf(format, content...)).To(Equal(`properties added:
+  .properties.some-added (string, required)
+properties removed:
+  .properties.some-removed
+properties renamed:
+  .properties.old-name -> .properties.new-name
+properties changed:
+  .properties.some-dropdown: option "large" added
+  .properties.some-dropdown: option "medium" removed
+  .properties.some-integer: type changed from integer to string
+  .properties.some-integer: is now required
+  .properties.some-port: default changed from 8080 to 9090
+jobs added:
+  some-new-job
+jobs removed:
+  some-old-job
+errands added:
+  some-new-errand
+stemcell criteria changed:
+  os changed from ubuntu-trusty to ubuntu-xenial
+  version changed from 3468 to 97
+dependencies added:
+  some-added-dependency ~> 3.0
+dependencies removed:
+  some-removed-dependency ~> 1.0
+dependencies changed:
+  cf changed from ~> 2.0 to ~> 2.1`))
+		})
+
+		Context("when the products are the same", func() {
+			It("reports that there are no differences", func() {
+				command := commands.NewDiffProductMetadata(metadataExtractor, logger)
+
+				err := command.Execute([]string{
+					"--old", "/path/to/old.pivotal",
+					"--new", "/path/to/old.pivotal",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintfCallCount()).To(Equal(2))
+
+				format, content := logger.PrintfArgsForCall(1)
+				Expect(fmt.Sprintf(format, content...)).To(Equal("no differences found"))
+			})
+		})
+
+		Context("when a config file is provided", func() {
+			var configFile, varsFile string
+
+			BeforeEach(func() {
+				file, err := ioutil.TempFile("", "config")
+				Expect(err).NotTo(HaveOccurred())
+				defer file.Close()
+
+				_, err = file.WriteString(`---
+product-properties:
+  .properties.some-string:
+    value: some-value
+  .properties.old-name:
+    value: true
+  .properties.some-removed:
+    value: ((some_removed_value))
+resource-config:
+  some-job:
+    instances: 2
+  some-old-job:
+    instances: 1
+`)
+				Expect(err).NotTo(HaveOccurred())
+
+				configFile = file.Name()
+
+				file, err = ioutil.TempFile("", "vars")
+				Expect(err).NotTo(HaveOccurred())
+				defer file.Close()
+
+				_, err = file.WriteString("some_removed_value: some-value\n")
+				Expect(err).NotTo(HaveOccurred())
+
+				varsFile = file.Name()
+			})
+
+			AfterEach(func() {
+				os.Remove(configFile)
+				os.Remove(varsFile)
+			})
+
+			It("reports the configuration that does not fit the new product", func() {
+				command := commands.NewDiffProductMetadata(metadataExtractor, logger)
+
+				err := command.Execute([]string{
+					"--old", "/path/to/old.pivotal",
+					"--new", "/path/to/new.pivotal",
+					"--config", configFile,
+					"--vars-file", varsFile,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				format, content := logger.PrintfArgsForCall(1)
+				Expect(fmt.Sprintf(format, content...)).To(HaveSuffix(`config file:
+  .properties.old-name: no longer exists, it appears to have been renamed to .properties.new-name
+  .properties.some-removed: no longer exists
+  .properties.some-added: is required but not set
+  .properties.some-integer: is required but not set
+  resource-config.some-old-job: job no longer exists`))
+			})
+
+			Context("when the vars file does not give every placeholder", func() {
+				It("returns an error", func() {
+					command := commands.NewDiffProductMetadata(metadataExtractor, logger)
+
+					err := command.Execute([]string{
+						"--old", "/path/to/old.pivotal",
+						"--new", "/path/to/new.pivotal",
+						"--config", configFile,
+					})
+					Expect(err).To(MatchError("could not interpolate config file: could not find values for the following placeholders: some_removed_value"))
+				})
+			})
+		})
+
+		Context("failure cases", func() {
+			Context("when an unknown flag is provided", func() {
+				It("returns an error", func() {
+					command := commands.NewDiffProductMetadata(metadataExtractor, logger)
+					err := command.Execute([]string{"--badflag"})
+					Expect(err).To(MatchError("could not parse diff-product-metadata flags: flag provided but not defined: -badflag"))
+				})
+			})
+
+			Context("when the old flag is not provided", func() {
+				It("returns an error", func() {
+					command := commands.NewDiffProductMetadata(metadataExtractor, logger)
+					err := command.Execute([]string{"--new", "/path/to/new.pivotal"})
+					Expect(err).To(MatchError("error: old is missing. Please see usage for more information."))
+				})
+			})
+
+			Context("when the new flag is not provided", func() {
+				It("returns an error", func() {
+					command := commands.NewDiffProductMetadata(metadataExtractor, logger)
+					err := command.Execute([]string{"--old", "/path/to/old.pivotal"})
+					Expect(err).To(MatchError("error: new is missing. Please see usage for more information."))
+				})
+			})
+
+			Context("when the metadata cannot be extracted", func() {
+				It("returns an error", func() {
+					metadataExtractor.ExtractProductMetadataStub = nil
+					metadataExtractor.ExtractProductMetadataReturns(extractor.Metadata{}, errors.New("some error"))
+
+					command := commands.NewDiffProductMetadata(metadataExtractor, logger)
+					err := command.Execute([]string{
+						"--old", "/path/to/old.pivotal",
+						"--new", "/path/to/new.pivotal",
+					})
+					Expect(err).To(MatchError("failed to extract metadata from /path/to/old.pivotal: some error"))
+				})
+			})
+
+			Context("when the config file cannot be read", func() {
+				It("returns an error", func() {
+					command := commands.NewDiffProductMetadata(metadataExtractor, logger)
+					err := command.Execute([]string{
+						"--old", "/path/to/old.pivotal",
+						"--new", "/path/to/new.pivotal",
+						"--config", "/not/a/real/file.yml",
+					})
+					Expect(err).To(MatchError(ContainSubstring("could not read config file:")))
+				})
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			command := commands.NewDiffProductMetadata(nil, nil)
+			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
+				Description:      "This command compares the metadata of two versions of a product file and lists the properties, jobs, errands, stemcell criteria and dependencies that changed. When a config file is given, it also reports configured keys that no longer exist and properties that become required.",
+				ShortDescription: "compares the metadata of two product files",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
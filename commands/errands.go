@@ -21,6 +21,7 @@ type stagedProductsFinder interface {
 type errandsService interface {
 	List(productID string) (api.ErrandsListOutput, error)
 	SetState(productID, errandName string, postDeployState, preDeleteState interface{}) error
+	SetStates(productID string, errands []api.Errand) error
 }
 
 type Errands struct {
@@ -3,6 +3,8 @@ package fakes
 
 import (
 	"sync"
+
+	"github.com/pivotal-cf/om/api"
 )
 
 type AvailableProductChecker struct {
@@ -20,6 +22,17 @@ type AvailableProductChecker struct {
 		result1 bool
 		result2 error
 	}
+	ListStub        func() (api.AvailableProductsOutput, error)
+	listMutex       sync.RWMutex
+	listArgsForCall []struct{}
+	listReturns     struct {
+		result1 api.AvailableProductsOutput
+		result2 error
+	}
+	listReturnsOnCall map[int]struct {
+		result1 api.AvailableProductsOutput
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -76,11 +89,56 @@ func (fake *AvailableProductChecker) CheckProductAvailabilityReturnsOnCall(i int
 	}{result1, result2}
 }
 
+func (fake *AvailableProductChecker) List() (api.AvailableProductsOutput, error) {
+	fake.listMutex.Lock()
+	ret, specificReturn := fake.listReturnsOnCall[len(fake.listArgsForCall)]
+	fake.listArgsForCall = append(fake.listArgsForCall, struct{}{})
+	fake.recordInvocation("List", []interface{}{})
+	fake.listMutex.Unlock()
+	if fake.ListStub != nil {
+		return fake.ListStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.listReturns.result1, fake.listReturns.result2
+}
+
+func (fake *AvailableProductChecker) ListCallCount() int {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	return len(fake.listArgsForCall)
+}
+
+func (fake *AvailableProductChecker) ListReturns(result1 api.AvailableProductsOutput, result2 error) {
+	fake.ListStub = nil
+	fake.listReturns = struct {
+		result1 api.AvailableProductsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *AvailableProductChecker) ListReturnsOnCall(i int, result1 api.AvailableProductsOutput, result2 error) {
+	fake.ListStub = nil
+	if fake.listReturnsOnCall == nil {
+		fake.listReturnsOnCall = make(map[int]struct {
+			result1 api.AvailableProductsOutput
+			result2 error
+		})
+	}
+	fake.listReturnsOnCall[i] = struct {
+		result1 api.AvailableProductsOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *AvailableProductChecker) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.checkProductAvailabilityMutex.RLock()
 	defer fake.checkProductAvailabilityMutex.RUnlock()
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
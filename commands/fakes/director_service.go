@@ -53,6 +53,64 @@ type DirectorService struct {
 	propertiesReturnsOnCall map[int]struct {
 		result1 error
 	}
+	IAASConfigurationsStub        func() ([]api.IAASConfiguration, error)
+	iAASConfigurationsMutex       sync.RWMutex
+	iAASConfigurationsArgsForCall []struct {
+	}
+	iAASConfigurationsReturns struct {
+		result1 []api.IAASConfiguration
+		result2 error
+	}
+	iAASConfigurationsReturnsOnCall map[int]struct {
+		result1 []api.IAASConfiguration
+		result2 error
+	}
+	CreateIAASConfigurationStub        func(json.RawMessage) error
+	createIAASConfigurationMutex       sync.RWMutex
+	createIAASConfigurationArgsForCall []struct {
+		arg1 json.RawMessage
+	}
+	createIAASConfigurationReturns struct {
+		result1 error
+	}
+	createIAASConfigurationReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateIAASConfigurationStub        func(string, json.RawMessage) error
+	updateIAASConfigurationMutex       sync.RWMutex
+	updateIAASConfigurationArgsForCall []struct {
+		arg1 string
+		arg2 json.RawMessage
+	}
+	updateIAASConfigurationReturns struct {
+		result1 error
+	}
+	updateIAASConfigurationReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteIAASConfigurationStub        func(string) error
+	deleteIAASConfigurationMutex       sync.RWMutex
+	deleteIAASConfigurationArgsForCall []struct {
+		arg1 string
+	}
+	deleteIAASConfigurationReturns struct {
+		result1 error
+	}
+	deleteIAASConfigurationReturnsOnCall map[int]struct {
+		result1 error
+	}
+	AvailabilityZonesStub        func() ([]api.AvailabilityZone, error)
+	availabilityZonesMutex       sync.RWMutex
+	availabilityZonesArgsForCall []struct {
+	}
+	availabilityZonesReturns struct {
+		result1 []api.AvailabilityZone
+		result2 error
+	}
+	availabilityZonesReturnsOnCall map[int]struct {
+		result1 []api.AvailabilityZone
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -249,6 +307,237 @@ func (fake *DirectorService) PropertiesReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *DirectorService) IAASConfigurations() ([]api.IAASConfiguration, error) {
+	fake.iAASConfigurationsMutex.Lock()
+	ret, specificReturn := fake.iAASConfigurationsReturnsOnCall[len(fake.iAASConfigurationsArgsForCall)]
+	fake.iAASConfigurationsArgsForCall = append(fake.iAASConfigurationsArgsForCall, struct{}{})
+	fake.recordInvocation("IAASConfigurations", []interface{}{})
+	fake.iAASConfigurationsMutex.Unlock()
+	if fake.IAASConfigurationsStub != nil {
+		return fake.IAASConfigurationsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.iAASConfigurationsReturns.result1, fake.iAASConfigurationsReturns.result2
+}
+
+func (fake *DirectorService) IAASConfigurationsCallCount() int {
+	fake.iAASConfigurationsMutex.RLock()
+	defer fake.iAASConfigurationsMutex.RUnlock()
+	return len(fake.iAASConfigurationsArgsForCall)
+}
+
+func (fake *DirectorService) IAASConfigurationsReturns(result1 []api.IAASConfiguration, result2 error) {
+	fake.IAASConfigurationsStub = nil
+	fake.iAASConfigurationsReturns = struct {
+		result1 []api.IAASConfiguration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DirectorService) IAASConfigurationsReturnsOnCall(i int, result1 []api.IAASConfiguration, result2 error) {
+	fake.IAASConfigurationsStub = nil
+	if fake.iAASConfigurationsReturnsOnCall == nil {
+		fake.iAASConfigurationsReturnsOnCall = make(map[int]struct {
+			result1 []api.IAASConfiguration
+			result2 error
+		})
+	}
+	fake.iAASConfigurationsReturnsOnCall[i] = struct {
+		result1 []api.IAASConfiguration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DirectorService) CreateIAASConfiguration(arg1 json.RawMessage) error {
+	fake.createIAASConfigurationMutex.Lock()
+	ret, specificReturn := fake.createIAASConfigurationReturnsOnCall[len(fake.createIAASConfigurationArgsForCall)]
+	fake.createIAASConfigurationArgsForCall = append(fake.createIAASConfigurationArgsForCall, struct {
+		arg1 json.RawMessage
+	}{arg1})
+	fake.recordInvocation("CreateIAASConfiguration", []interface{}{arg1})
+	fake.createIAASConfigurationMutex.Unlock()
+	if fake.CreateIAASConfigurationStub != nil {
+		return fake.CreateIAASConfigurationStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.createIAASConfigurationReturns.result1
+}
+
+func (fake *DirectorService) CreateIAASConfigurationCallCount() int {
+	fake.createIAASConfigurationMutex.RLock()
+	defer fake.createIAASConfigurationMutex.RUnlock()
+	return len(fake.createIAASConfigurationArgsForCall)
+}
+
+func (fake *DirectorService) CreateIAASConfigurationArgsForCall(i int) json.RawMessage {
+	fake.createIAASConfigurationMutex.RLock()
+	defer fake.createIAASConfigurationMutex.RUnlock()
+	return fake.createIAASConfigurationArgsForCall[i].arg1
+}
+
+func (fake *DirectorService) CreateIAASConfigurationReturns(result1 error) {
+	fake.CreateIAASConfigurationStub = nil
+	fake.createIAASConfigurationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *DirectorService) CreateIAASConfigurationReturnsOnCall(i int, result1 error) {
+	fake.CreateIAASConfigurationStub = nil
+	if fake.createIAASConfigurationReturnsOnCall == nil {
+		fake.createIAASConfigurationReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createIAASConfigurationReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *DirectorService) UpdateIAASConfiguration(arg1 string, arg2 json.RawMessage) error {
+	fake.updateIAASConfigurationMutex.Lock()
+	ret, specificReturn := fake.updateIAASConfigurationReturnsOnCall[len(fake.updateIAASConfigurationArgsForCall)]
+	fake.updateIAASConfigurationArgsForCall = append(fake.updateIAASConfigurationArgsForCall, struct {
+		arg1 string
+		arg2 json.RawMessage
+	}{arg1, arg2})
+	fake.recordInvocation("UpdateIAASConfiguration", []interface{}{arg1, arg2})
+	fake.updateIAASConfigurationMutex.Unlock()
+	if fake.UpdateIAASConfigurationStub != nil {
+		return fake.UpdateIAASConfigurationStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateIAASConfigurationReturns.result1
+}
+
+func (fake *DirectorService) UpdateIAASConfigurationCallCount() int {
+	fake.updateIAASConfigurationMutex.RLock()
+	defer fake.updateIAASConfigurationMutex.RUnlock()
+	return len(fake.updateIAASConfigurationArgsForCall)
+}
+
+func (fake *DirectorService) UpdateIAASConfigurationArgsForCall(i int) (string, json.RawMessage) {
+	fake.updateIAASConfigurationMutex.RLock()
+	defer fake.updateIAASConfigurationMutex.RUnlock()
+	return fake.updateIAASConfigurationArgsForCall[i].arg1, fake.updateIAASConfigurationArgsForCall[i].arg2
+}
+
+func (fake *DirectorService) UpdateIAASConfigurationReturns(result1 error) {
+	fake.UpdateIAASConfigurationStub = nil
+	fake.updateIAASConfigurationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *DirectorService) UpdateIAASConfigurationReturnsOnCall(i int, result1 error) {
+	fake.UpdateIAASConfigurationStub = nil
+	if fake.updateIAASConfigurationReturnsOnCall == nil {
+		fake.updateIAASConfigurationReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateIAASConfigurationReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *DirectorService) DeleteIAASConfiguration(arg1 string) error {
+	fake.deleteIAASConfigurationMutex.Lock()
+	ret, specificReturn := fake.deleteIAASConfigurationReturnsOnCall[len(fake.deleteIAASConfigurationArgsForCall)]
+	fake.deleteIAASConfigurationArgsForCall = append(fake.deleteIAASConfigurationArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("DeleteIAASConfiguration", []interface{}{arg1})
+	fake.deleteIAASConfigurationMutex.Unlock()
+	if fake.DeleteIAASConfigurationStub != nil {
+		return fake.DeleteIAASConfigurationStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.deleteIAASConfigurationReturns.result1
+}
+
+func (fake *DirectorService) DeleteIAASConfigurationCallCount() int {
+	fake.deleteIAASConfigurationMutex.RLock()
+	defer fake.deleteIAASConfigurationMutex.RUnlock()
+	return len(fake.deleteIAASConfigurationArgsForCall)
+}
+
+func (fake *DirectorService) DeleteIAASConfigurationArgsForCall(i int) string {
+	fake.deleteIAASConfigurationMutex.RLock()
+	defer fake.deleteIAASConfigurationMutex.RUnlock()
+	return fake.deleteIAASConfigurationArgsForCall[i].arg1
+}
+
+func (fake *DirectorService) DeleteIAASConfigurationReturns(result1 error) {
+	fake.DeleteIAASConfigurationStub = nil
+	fake.deleteIAASConfigurationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *DirectorService) DeleteIAASConfigurationReturnsOnCall(i int, result1 error) {
+	fake.DeleteIAASConfigurationStub = nil
+	if fake.deleteIAASConfigurationReturnsOnCall == nil {
+		fake.deleteIAASConfigurationReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteIAASConfigurationReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *DirectorService) AvailabilityZones() ([]api.AvailabilityZone, error) {
+	fake.availabilityZonesMutex.Lock()
+	ret, specificReturn := fake.availabilityZonesReturnsOnCall[len(fake.availabilityZonesArgsForCall)]
+	fake.availabilityZonesArgsForCall = append(fake.availabilityZonesArgsForCall, struct{}{})
+	fake.recordInvocation("AvailabilityZones", []interface{}{})
+	fake.availabilityZonesMutex.Unlock()
+	if fake.AvailabilityZonesStub != nil {
+		return fake.AvailabilityZonesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.availabilityZonesReturns.result1, fake.availabilityZonesReturns.result2
+}
+
+func (fake *DirectorService) AvailabilityZonesCallCount() int {
+	fake.availabilityZonesMutex.RLock()
+	defer fake.availabilityZonesMutex.RUnlock()
+	return len(fake.availabilityZonesArgsForCall)
+}
+
+func (fake *DirectorService) AvailabilityZonesReturns(result1 []api.AvailabilityZone, result2 error) {
+	fake.AvailabilityZonesStub = nil
+	fake.availabilityZonesReturns = struct {
+		result1 []api.AvailabilityZone
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DirectorService) AvailabilityZonesReturnsOnCall(i int, result1 []api.AvailabilityZone, result2 error) {
+	fake.AvailabilityZonesStub = nil
+	if fake.availabilityZonesReturnsOnCall == nil {
+		fake.availabilityZonesReturnsOnCall = make(map[int]struct {
+			result1 []api.AvailabilityZone
+			result2 error
+		})
+	}
+	fake.availabilityZonesReturnsOnCall[i] = struct {
+		result1 []api.AvailabilityZone
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *DirectorService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -260,6 +549,16 @@ func (fake *DirectorService) Invocations() map[string][][]interface{} {
 	defer fake.networkAndAZMutex.RUnlock()
 	fake.propertiesMutex.RLock()
 	defer fake.propertiesMutex.RUnlock()
+	fake.iAASConfigurationsMutex.RLock()
+	defer fake.iAASConfigurationsMutex.RUnlock()
+	fake.createIAASConfigurationMutex.RLock()
+	defer fake.createIAASConfigurationMutex.RUnlock()
+	fake.updateIAASConfigurationMutex.RLock()
+	defer fake.updateIAASConfigurationMutex.RUnlock()
+	fake.deleteIAASConfigurationMutex.RLock()
+	defer fake.deleteIAASConfigurationMutex.RUnlock()
+	fake.availabilityZonesMutex.RLock()
+	defer fake.availabilityZonesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
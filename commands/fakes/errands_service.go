@@ -35,6 +35,18 @@ type ErrandsService struct {
 	setStateReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetStatesStub        func(productID string, errands []api.Errand) error
+	setStatesMutex       sync.RWMutex
+	setStatesArgsForCall []struct {
+		productID string
+		errands   []api.Errand
+	}
+	setStatesReturns struct {
+		result1 error
+	}
+	setStatesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -141,6 +153,60 @@ func (fake *ErrandsService) SetStateReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *ErrandsService) SetStates(productID string, errands []api.Errand) error {
+	var errandsCopy []api.Errand
+	if errands != nil {
+		errandsCopy = make([]api.Errand, len(errands))
+		copy(errandsCopy, errands)
+	}
+	fake.setStatesMutex.Lock()
+	ret, specificReturn := fake.setStatesReturnsOnCall[len(fake.setStatesArgsForCall)]
+	fake.setStatesArgsForCall = append(fake.setStatesArgsForCall, struct {
+		productID string
+		errands   []api.Errand
+	}{productID, errandsCopy})
+	fake.recordInvocation("SetStates", []interface{}{productID, errandsCopy})
+	fake.setStatesMutex.Unlock()
+	if fake.SetStatesStub != nil {
+		return fake.SetStatesStub(productID, errands)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.setStatesReturns.result1
+}
+
+func (fake *ErrandsService) SetStatesCallCount() int {
+	fake.setStatesMutex.RLock()
+	defer fake.setStatesMutex.RUnlock()
+	return len(fake.setStatesArgsForCall)
+}
+
+func (fake *ErrandsService) SetStatesArgsForCall(i int) (string, []api.Errand) {
+	fake.setStatesMutex.RLock()
+	defer fake.setStatesMutex.RUnlock()
+	return fake.setStatesArgsForCall[i].productID, fake.setStatesArgsForCall[i].errands
+}
+
+func (fake *ErrandsService) SetStatesReturns(result1 error) {
+	fake.SetStatesStub = nil
+	fake.setStatesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ErrandsService) SetStatesReturnsOnCall(i int, result1 error) {
+	fake.SetStatesStub = nil
+	if fake.setStatesReturnsOnCall == nil {
+		fake.setStatesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setStatesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ErrandsService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -148,6 +214,8 @@ func (fake *ErrandsService) Invocations() map[string][][]interface{} {
 	defer fake.listMutex.RUnlock()
 	fake.setStateMutex.RLock()
 	defer fake.setStateMutex.RUnlock()
+	fake.setStatesMutex.RLock()
+	defer fake.setStatesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -45,21 +45,56 @@ type Presenter struct {
 	presentCertificateAuthorityArgsForCall []struct {
 		arg1 api.CA
 	}
+	PresentIAASConfigurationsStub        func([]api.IAASConfiguration)
+	presentIAASConfigurationsMutex       sync.RWMutex
+	presentIAASConfigurationsArgsForCall []struct {
+		arg1 []api.IAASConfiguration
+	}
 	PresentInstallationsStub        func([]models.Installation)
 	presentInstallationsMutex       sync.RWMutex
 	presentInstallationsArgsForCall []struct {
 		arg1 []models.Installation
 	}
-	PresentPendingChangesStub        func([]api.ProductChange)
+	PresentPendingChangesStub        func([]models.PendingChange)
 	presentPendingChangesMutex       sync.RWMutex
 	presentPendingChangesArgsForCall []struct {
-		arg1 []api.ProductChange
+		arg1 []models.PendingChange
+	}
+	PresentProductsStub        func([]models.ProductInventory)
+	presentProductsMutex       sync.RWMutex
+	presentProductsArgsForCall []struct {
+		arg1 []models.ProductInventory
 	}
 	PresentStagedProductsStub        func([]api.DiagnosticProduct)
 	presentStagedProductsMutex       sync.RWMutex
 	presentStagedProductsArgsForCall []struct {
 		arg1 []api.DiagnosticProduct
 	}
+	PresentStemcellAssignmentsStub        func([]api.StemcellAssignment)
+	presentStemcellAssignmentsMutex       sync.RWMutex
+	presentStemcellAssignmentsArgsForCall []struct {
+		arg1 []api.StemcellAssignment
+	}
+	PresentVMExtensionsStub        func([]api.VMExtension)
+	presentVMExtensionsMutex       sync.RWMutex
+	presentVMExtensionsArgsForCall []struct {
+		arg1 []api.VMExtension
+	}
+	PresentVMTypesStub        func([]api.VMType)
+	presentVMTypesMutex       sync.RWMutex
+	presentVMTypesArgsForCall []struct {
+		arg1 []api.VMType
+	}
+	PresentVerifiersStub        func([]api.Verifier)
+	presentVerifiersMutex       sync.RWMutex
+	presentVerifiersArgsForCall []struct {
+		arg1 []api.Verifier
+	}
+	PresentVerifierResultsStub        func([]models.VerifierResult)
+	presentVerifierResultsMutex       sync.RWMutex
+	presentVerifierResultsArgsForCall []struct {
+		arg1 []models.VerifierResult
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -257,6 +292,35 @@ func (fake *Presenter) PresentCertificateAuthorityArgsForCall(i int) api.CA {
 	return fake.presentCertificateAuthorityArgsForCall[i].arg1
 }
 
+func (fake *Presenter) PresentIAASConfigurations(arg1 []api.IAASConfiguration) {
+	var arg1Copy []api.IAASConfiguration
+	if arg1 != nil {
+		arg1Copy = make([]api.IAASConfiguration, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.presentIAASConfigurationsMutex.Lock()
+	fake.presentIAASConfigurationsArgsForCall = append(fake.presentIAASConfigurationsArgsForCall, struct {
+		arg1 []api.IAASConfiguration
+	}{arg1Copy})
+	fake.recordInvocation("PresentIAASConfigurations", []interface{}{arg1Copy})
+	fake.presentIAASConfigurationsMutex.Unlock()
+	if fake.PresentIAASConfigurationsStub != nil {
+		fake.PresentIAASConfigurationsStub(arg1)
+	}
+}
+
+func (fake *Presenter) PresentIAASConfigurationsCallCount() int {
+	fake.presentIAASConfigurationsMutex.RLock()
+	defer fake.presentIAASConfigurationsMutex.RUnlock()
+	return len(fake.presentIAASConfigurationsArgsForCall)
+}
+
+func (fake *Presenter) PresentIAASConfigurationsArgsForCall(i int) []api.IAASConfiguration {
+	fake.presentIAASConfigurationsMutex.RLock()
+	defer fake.presentIAASConfigurationsMutex.RUnlock()
+	return fake.presentIAASConfigurationsArgsForCall[i].arg1
+}
+
 func (fake *Presenter) PresentInstallations(arg1 []models.Installation) {
 	var arg1Copy []models.Installation
 	if arg1 != nil {
@@ -286,15 +350,15 @@ func (fake *Presenter) PresentInstallationsArgsForCall(i int) []models.Installat
 	return fake.presentInstallationsArgsForCall[i].arg1
 }
 
-func (fake *Presenter) PresentPendingChanges(arg1 []api.ProductChange) {
-	var arg1Copy []api.ProductChange
+func (fake *Presenter) PresentPendingChanges(arg1 []models.PendingChange) {
+	var arg1Copy []models.PendingChange
 	if arg1 != nil {
-		arg1Copy = make([]api.ProductChange, len(arg1))
+		arg1Copy = make([]models.PendingChange, len(arg1))
 		copy(arg1Copy, arg1)
 	}
 	fake.presentPendingChangesMutex.Lock()
 	fake.presentPendingChangesArgsForCall = append(fake.presentPendingChangesArgsForCall, struct {
-		arg1 []api.ProductChange
+		arg1 []models.PendingChange
 	}{arg1Copy})
 	fake.recordInvocation("PresentPendingChanges", []interface{}{arg1Copy})
 	fake.presentPendingChangesMutex.Unlock()
@@ -309,12 +373,41 @@ func (fake *Presenter) PresentPendingChangesCallCount() int {
 	return len(fake.presentPendingChangesArgsForCall)
 }
 
-func (fake *Presenter) PresentPendingChangesArgsForCall(i int) []api.ProductChange {
+func (fake *Presenter) PresentPendingChangesArgsForCall(i int) []models.PendingChange {
 	fake.presentPendingChangesMutex.RLock()
 	defer fake.presentPendingChangesMutex.RUnlock()
 	return fake.presentPendingChangesArgsForCall[i].arg1
 }
 
+func (fake *Presenter) PresentProducts(arg1 []models.ProductInventory) {
+	var arg1Copy []models.ProductInventory
+	if arg1 != nil {
+		arg1Copy = make([]models.ProductInventory, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.presentProductsMutex.Lock()
+	fake.presentProductsArgsForCall = append(fake.presentProductsArgsForCall, struct {
+		arg1 []models.ProductInventory
+	}{arg1Copy})
+	fake.recordInvocation("PresentProducts", []interface{}{arg1Copy})
+	fake.presentProductsMutex.Unlock()
+	if fake.PresentProductsStub != nil {
+		fake.PresentProductsStub(arg1)
+	}
+}
+
+func (fake *Presenter) PresentProductsCallCount() int {
+	fake.presentProductsMutex.RLock()
+	defer fake.presentProductsMutex.RUnlock()
+	return len(fake.presentProductsArgsForCall)
+}
+
+func (fake *Presenter) PresentProductsArgsForCall(i int) []models.ProductInventory {
+	fake.presentProductsMutex.RLock()
+	defer fake.presentProductsMutex.RUnlock()
+	return fake.presentProductsArgsForCall[i].arg1
+}
+
 func (fake *Presenter) PresentStagedProducts(arg1 []api.DiagnosticProduct) {
 	var arg1Copy []api.DiagnosticProduct
 	if arg1 != nil {
@@ -344,6 +437,151 @@ func (fake *Presenter) PresentStagedProductsArgsForCall(i int) []api.DiagnosticP
 	return fake.presentStagedProductsArgsForCall[i].arg1
 }
 
+func (fake *Presenter) PresentStemcellAssignments(arg1 []api.StemcellAssignment) {
+	var arg1Copy []api.StemcellAssignment
+	if arg1 != nil {
+		arg1Copy = make([]api.StemcellAssignment, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.presentStemcellAssignmentsMutex.Lock()
+	fake.presentStemcellAssignmentsArgsForCall = append(fake.presentStemcellAssignmentsArgsForCall, struct {
+		arg1 []api.StemcellAssignment
+	}{arg1Copy})
+	fake.recordInvocation("PresentStemcellAssignments", []interface{}{arg1Copy})
+	fake.presentStemcellAssignmentsMutex.Unlock()
+	if fake.PresentStemcellAssignmentsStub != nil {
+		fake.PresentStemcellAssignmentsStub(arg1)
+	}
+}
+
+func (fake *Presenter) PresentStemcellAssignmentsCallCount() int {
+	fake.presentStemcellAssignmentsMutex.RLock()
+	defer fake.presentStemcellAssignmentsMutex.RUnlock()
+	return len(fake.presentStemcellAssignmentsArgsForCall)
+}
+
+func (fake *Presenter) PresentStemcellAssignmentsArgsForCall(i int) []api.StemcellAssignment {
+	fake.presentStemcellAssignmentsMutex.RLock()
+	defer fake.presentStemcellAssignmentsMutex.RUnlock()
+	return fake.presentStemcellAssignmentsArgsForCall[i].arg1
+}
+
+func (fake *Presenter) PresentVMExtensions(arg1 []api.VMExtension) {
+	var arg1Copy []api.VMExtension
+	if arg1 != nil {
+		arg1Copy = make([]api.VMExtension, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.presentVMExtensionsMutex.Lock()
+	fake.presentVMExtensionsArgsForCall = append(fake.presentVMExtensionsArgsForCall, struct {
+		arg1 []api.VMExtension
+	}{arg1Copy})
+	fake.recordInvocation("PresentVMExtensions", []interface{}{arg1Copy})
+	fake.presentVMExtensionsMutex.Unlock()
+	if fake.PresentVMExtensionsStub != nil {
+		fake.PresentVMExtensionsStub(arg1)
+	}
+}
+
+func (fake *Presenter) PresentVMExtensionsCallCount() int {
+	fake.presentVMExtensionsMutex.RLock()
+	defer fake.presentVMExtensionsMutex.RUnlock()
+	return len(fake.presentVMExtensionsArgsForCall)
+}
+
+func (fake *Presenter) PresentVMExtensionsArgsForCall(i int) []api.VMExtension {
+	fake.presentVMExtensionsMutex.RLock()
+	defer fake.presentVMExtensionsMutex.RUnlock()
+	return fake.presentVMExtensionsArgsForCall[i].arg1
+}
+
+func (fake *Presenter) PresentVMTypes(arg1 []api.VMType) {
+	var arg1Copy []api.VMType
+	if arg1 != nil {
+		arg1Copy = make([]api.VMType, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.presentVMTypesMutex.Lock()
+	fake.presentVMTypesArgsForCall = append(fake.presentVMTypesArgsForCall, struct {
+		arg1 []api.VMType
+	}{arg1Copy})
+	fake.recordInvocation("PresentVMTypes", []interface{}{arg1Copy})
+	fake.presentVMTypesMutex.Unlock()
+	if fake.PresentVMTypesStub != nil {
+		fake.PresentVMTypesStub(arg1)
+	}
+}
+
+func (fake *Presenter) PresentVMTypesCallCount() int {
+	fake.presentVMTypesMutex.RLock()
+	defer fake.presentVMTypesMutex.RUnlock()
+	return len(fake.presentVMTypesArgsForCall)
+}
+
+func (fake *Presenter) PresentVMTypesArgsForCall(i int) []api.VMType {
+	fake.presentVMTypesMutex.RLock()
+	defer fake.presentVMTypesMutex.RUnlock()
+	return fake.presentVMTypesArgsForCall[i].arg1
+}
+
+func (fake *Presenter) PresentVerifiers(arg1 []api.Verifier) {
+	var arg1Copy []api.Verifier
+	if arg1 != nil {
+		arg1Copy = make([]api.Verifier, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.presentVerifiersMutex.Lock()
+	fake.presentVerifiersArgsForCall = append(fake.presentVerifiersArgsForCall, struct {
+		arg1 []api.Verifier
+	}{arg1Copy})
+	fake.recordInvocation("PresentVerifiers", []interface{}{arg1Copy})
+	fake.presentVerifiersMutex.Unlock()
+	if fake.PresentVerifiersStub != nil {
+		fake.PresentVerifiersStub(arg1)
+	}
+}
+
+func (fake *Presenter) PresentVerifiersCallCount() int {
+	fake.presentVerifiersMutex.RLock()
+	defer fake.presentVerifiersMutex.RUnlock()
+	return len(fake.presentVerifiersArgsForCall)
+}
+
+func (fake *Presenter) PresentVerifiersArgsForCall(i int) []api.Verifier {
+	fake.presentVerifiersMutex.RLock()
+	defer fake.presentVerifiersMutex.RUnlock()
+	return fake.presentVerifiersArgsForCall[i].arg1
+}
+
+func (fake *Presenter) PresentVerifierResults(arg1 []models.VerifierResult) {
+	var arg1Copy []models.VerifierResult
+	if arg1 != nil {
+		arg1Copy = make([]models.VerifierResult, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.presentVerifierResultsMutex.Lock()
+	fake.presentVerifierResultsArgsForCall = append(fake.presentVerifierResultsArgsForCall, struct {
+		arg1 []models.VerifierResult
+	}{arg1Copy})
+	fake.recordInvocation("PresentVerifierResults", []interface{}{arg1Copy})
+	fake.presentVerifierResultsMutex.Unlock()
+	if fake.PresentVerifierResultsStub != nil {
+		fake.PresentVerifierResultsStub(arg1)
+	}
+}
+
+func (fake *Presenter) PresentVerifierResultsCallCount() int {
+	fake.presentVerifierResultsMutex.RLock()
+	defer fake.presentVerifierResultsMutex.RUnlock()
+	return len(fake.presentVerifierResultsArgsForCall)
+}
+
+func (fake *Presenter) PresentVerifierResultsArgsForCall(i int) []models.VerifierResult {
+	fake.presentVerifierResultsMutex.RLock()
+	defer fake.presentVerifierResultsMutex.RUnlock()
+	return fake.presentVerifierResultsArgsForCall[i].arg1
+}
+
 func (fake *Presenter) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -361,12 +599,26 @@ func (fake *Presenter) Invocations() map[string][][]interface{} {
 	defer fake.presentErrandsMutex.RUnlock()
 	fake.presentCertificateAuthorityMutex.RLock()
 	defer fake.presentCertificateAuthorityMutex.RUnlock()
+	fake.presentIAASConfigurationsMutex.RLock()
+	defer fake.presentIAASConfigurationsMutex.RUnlock()
 	fake.presentInstallationsMutex.RLock()
 	defer fake.presentInstallationsMutex.RUnlock()
 	fake.presentPendingChangesMutex.RLock()
 	defer fake.presentPendingChangesMutex.RUnlock()
+	fake.presentProductsMutex.RLock()
+	defer fake.presentProductsMutex.RUnlock()
 	fake.presentStagedProductsMutex.RLock()
 	defer fake.presentStagedProductsMutex.RUnlock()
+	fake.presentStemcellAssignmentsMutex.RLock()
+	defer fake.presentStemcellAssignmentsMutex.RUnlock()
+	fake.presentVMExtensionsMutex.RLock()
+	defer fake.presentVMExtensionsMutex.RUnlock()
+	fake.presentVMTypesMutex.RLock()
+	defer fake.presentVMTypesMutex.RUnlock()
+	fake.presentVerifiersMutex.RLock()
+	defer fake.presentVerifiersMutex.RUnlock()
+	fake.presentVerifierResultsMutex.RLock()
+	defer fake.presentVerifierResultsMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -19,6 +19,32 @@ type ProductConfigurer struct {
 		result1 api.StagedProductsOutput
 		result2 error
 	}
+	PropertiesStub        func(productGUID string) (map[string]api.ResponseProperty, error)
+	propertiesMutex       sync.RWMutex
+	propertiesArgsForCall []struct {
+		productGUID string
+	}
+	propertiesReturns struct {
+		result1 map[string]api.ResponseProperty
+		result2 error
+	}
+	propertiesReturnsOnCall map[int]struct {
+		result1 map[string]api.ResponseProperty
+		result2 error
+	}
+	NetworksAndAZsStub        func(productGUID string) (map[string]interface{}, error)
+	networksAndAZsMutex       sync.RWMutex
+	networksAndAZsArgsForCall []struct {
+		productGUID string
+	}
+	networksAndAZsReturns struct {
+		result1 map[string]interface{}
+		result2 error
+	}
+	networksAndAZsReturnsOnCall map[int]struct {
+		result1 map[string]interface{}
+		result2 error
+	}
 	ConfigureStub        func(api.ProductsConfigurationInput) error
 	configureMutex       sync.RWMutex
 	configureArgsForCall []struct {
@@ -30,6 +56,56 @@ type ProductConfigurer struct {
 	configureReturnsOnCall map[int]struct {
 		result1 error
 	}
+	MaxInFlightStub        func(productGUID string) (map[string]interface{}, error)
+	maxInFlightMutex       sync.RWMutex
+	maxInFlightArgsForCall []struct {
+		productGUID string
+	}
+	maxInFlightReturns struct {
+		result1 map[string]interface{}
+		result2 error
+	}
+	maxInFlightReturnsOnCall map[int]struct {
+		result1 map[string]interface{}
+		result2 error
+	}
+	ConfigureMaxInFlightStub        func(productGUID string, maxInFlight map[string]interface{}) error
+	configureMaxInFlightMutex       sync.RWMutex
+	configureMaxInFlightArgsForCall []struct {
+		productGUID string
+		maxInFlight map[string]interface{}
+	}
+	configureMaxInFlightReturns struct {
+		result1 error
+	}
+	configureMaxInFlightReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SyslogConfigurationStub        func(productGUID string) (map[string]interface{}, error)
+	syslogConfigurationMutex       sync.RWMutex
+	syslogConfigurationArgsForCall []struct {
+		productGUID string
+	}
+	syslogConfigurationReturns struct {
+		result1 map[string]interface{}
+		result2 error
+	}
+	syslogConfigurationReturnsOnCall map[int]struct {
+		result1 map[string]interface{}
+		result2 error
+	}
+	ConfigureSyslogStub        func(productGUID string, syslogConfiguration map[string]interface{}) error
+	configureSyslogMutex       sync.RWMutex
+	configureSyslogArgsForCall []struct {
+		productGUID         string
+		syslogConfiguration map[string]interface{}
+	}
+	configureSyslogReturns struct {
+		result1 error
+	}
+	configureSyslogReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -77,6 +153,108 @@ func (fake *ProductConfigurer) StagedProductsReturnsOnCall(i int, result1 api.St
 	}{result1, result2}
 }
 
+func (fake *ProductConfigurer) Properties(productGUID string) (map[string]api.ResponseProperty, error) {
+	fake.propertiesMutex.Lock()
+	ret, specificReturn := fake.propertiesReturnsOnCall[len(fake.propertiesArgsForCall)]
+	fake.propertiesArgsForCall = append(fake.propertiesArgsForCall, struct {
+		productGUID string
+	}{productGUID})
+	fake.recordInvocation("Properties", []interface{}{productGUID})
+	fake.propertiesMutex.Unlock()
+	if fake.PropertiesStub != nil {
+		return fake.PropertiesStub(productGUID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.propertiesReturns.result1, fake.propertiesReturns.result2
+}
+
+func (fake *ProductConfigurer) PropertiesCallCount() int {
+	fake.propertiesMutex.RLock()
+	defer fake.propertiesMutex.RUnlock()
+	return len(fake.propertiesArgsForCall)
+}
+
+func (fake *ProductConfigurer) PropertiesArgsForCall(i int) string {
+	fake.propertiesMutex.RLock()
+	defer fake.propertiesMutex.RUnlock()
+	return fake.propertiesArgsForCall[i].productGUID
+}
+
+func (fake *ProductConfigurer) PropertiesReturns(result1 map[string]api.ResponseProperty, result2 error) {
+	fake.PropertiesStub = nil
+	fake.propertiesReturns = struct {
+		result1 map[string]api.ResponseProperty
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ProductConfigurer) PropertiesReturnsOnCall(i int, result1 map[string]api.ResponseProperty, result2 error) {
+	fake.PropertiesStub = nil
+	if fake.propertiesReturnsOnCall == nil {
+		fake.propertiesReturnsOnCall = make(map[int]struct {
+			result1 map[string]api.ResponseProperty
+			result2 error
+		})
+	}
+	fake.propertiesReturnsOnCall[i] = struct {
+		result1 map[string]api.ResponseProperty
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ProductConfigurer) NetworksAndAZs(productGUID string) (map[string]interface{}, error) {
+	fake.networksAndAZsMutex.Lock()
+	ret, specificReturn := fake.networksAndAZsReturnsOnCall[len(fake.networksAndAZsArgsForCall)]
+	fake.networksAndAZsArgsForCall = append(fake.networksAndAZsArgsForCall, struct {
+		productGUID string
+	}{productGUID})
+	fake.recordInvocation("NetworksAndAZs", []interface{}{productGUID})
+	fake.networksAndAZsMutex.Unlock()
+	if fake.NetworksAndAZsStub != nil {
+		return fake.NetworksAndAZsStub(productGUID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.networksAndAZsReturns.result1, fake.networksAndAZsReturns.result2
+}
+
+func (fake *ProductConfigurer) NetworksAndAZsCallCount() int {
+	fake.networksAndAZsMutex.RLock()
+	defer fake.networksAndAZsMutex.RUnlock()
+	return len(fake.networksAndAZsArgsForCall)
+}
+
+func (fake *ProductConfigurer) NetworksAndAZsArgsForCall(i int) string {
+	fake.networksAndAZsMutex.RLock()
+	defer fake.networksAndAZsMutex.RUnlock()
+	return fake.networksAndAZsArgsForCall[i].productGUID
+}
+
+func (fake *ProductConfigurer) NetworksAndAZsReturns(result1 map[string]interface{}, result2 error) {
+	fake.NetworksAndAZsStub = nil
+	fake.networksAndAZsReturns = struct {
+		result1 map[string]interface{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ProductConfigurer) NetworksAndAZsReturnsOnCall(i int, result1 map[string]interface{}, result2 error) {
+	fake.NetworksAndAZsStub = nil
+	if fake.networksAndAZsReturnsOnCall == nil {
+		fake.networksAndAZsReturnsOnCall = make(map[int]struct {
+			result1 map[string]interface{}
+			result2 error
+		})
+	}
+	fake.networksAndAZsReturnsOnCall[i] = struct {
+		result1 map[string]interface{}
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ProductConfigurer) Configure(arg1 api.ProductsConfigurationInput) error {
 	fake.configureMutex.Lock()
 	ret, specificReturn := fake.configureReturnsOnCall[len(fake.configureArgsForCall)]
@@ -125,13 +303,225 @@ func (fake *ProductConfigurer) ConfigureReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *ProductConfigurer) MaxInFlight(productGUID string) (map[string]interface{}, error) {
+	fake.maxInFlightMutex.Lock()
+	ret, specificReturn := fake.maxInFlightReturnsOnCall[len(fake.maxInFlightArgsForCall)]
+	fake.maxInFlightArgsForCall = append(fake.maxInFlightArgsForCall, struct {
+		productGUID string
+	}{productGUID})
+	fake.recordInvocation("MaxInFlight", []interface{}{productGUID})
+	fake.maxInFlightMutex.Unlock()
+	if fake.MaxInFlightStub != nil {
+		return fake.MaxInFlightStub(productGUID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.maxInFlightReturns.result1, fake.maxInFlightReturns.result2
+}
+
+func (fake *ProductConfigurer) MaxInFlightCallCount() int {
+	fake.maxInFlightMutex.RLock()
+	defer fake.maxInFlightMutex.RUnlock()
+	return len(fake.maxInFlightArgsForCall)
+}
+
+func (fake *ProductConfigurer) MaxInFlightArgsForCall(i int) string {
+	fake.maxInFlightMutex.RLock()
+	defer fake.maxInFlightMutex.RUnlock()
+	return fake.maxInFlightArgsForCall[i].productGUID
+}
+
+func (fake *ProductConfigurer) MaxInFlightReturns(result1 map[string]interface{}, result2 error) {
+	fake.MaxInFlightStub = nil
+	fake.maxInFlightReturns = struct {
+		result1 map[string]interface{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ProductConfigurer) MaxInFlightReturnsOnCall(i int, result1 map[string]interface{}, result2 error) {
+	fake.MaxInFlightStub = nil
+	if fake.maxInFlightReturnsOnCall == nil {
+		fake.maxInFlightReturnsOnCall = make(map[int]struct {
+			result1 map[string]interface{}
+			result2 error
+		})
+	}
+	fake.maxInFlightReturnsOnCall[i] = struct {
+		result1 map[string]interface{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ProductConfigurer) ConfigureMaxInFlight(productGUID string, maxInFlight map[string]interface{}) error {
+	fake.configureMaxInFlightMutex.Lock()
+	ret, specificReturn := fake.configureMaxInFlightReturnsOnCall[len(fake.configureMaxInFlightArgsForCall)]
+	fake.configureMaxInFlightArgsForCall = append(fake.configureMaxInFlightArgsForCall, struct {
+		productGUID string
+		maxInFlight map[string]interface{}
+	}{productGUID, maxInFlight})
+	fake.recordInvocation("ConfigureMaxInFlight", []interface{}{productGUID, maxInFlight})
+	fake.configureMaxInFlightMutex.Unlock()
+	if fake.ConfigureMaxInFlightStub != nil {
+		return fake.ConfigureMaxInFlightStub(productGUID, maxInFlight)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.configureMaxInFlightReturns.result1
+}
+
+func (fake *ProductConfigurer) ConfigureMaxInFlightCallCount() int {
+	fake.configureMaxInFlightMutex.RLock()
+	defer fake.configureMaxInFlightMutex.RUnlock()
+	return len(fake.configureMaxInFlightArgsForCall)
+}
+
+func (fake *ProductConfigurer) ConfigureMaxInFlightArgsForCall(i int) (string, map[string]interface{}) {
+	fake.configureMaxInFlightMutex.RLock()
+	defer fake.configureMaxInFlightMutex.RUnlock()
+	return fake.configureMaxInFlightArgsForCall[i].productGUID, fake.configureMaxInFlightArgsForCall[i].maxInFlight
+}
+
+func (fake *ProductConfigurer) ConfigureMaxInFlightReturns(result1 error) {
+	fake.ConfigureMaxInFlightStub = nil
+	fake.configureMaxInFlightReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ProductConfigurer) ConfigureMaxInFlightReturnsOnCall(i int, result1 error) {
+	fake.ConfigureMaxInFlightStub = nil
+	if fake.configureMaxInFlightReturnsOnCall == nil {
+		fake.configureMaxInFlightReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.configureMaxInFlightReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ProductConfigurer) SyslogConfiguration(productGUID string) (map[string]interface{}, error) {
+	fake.syslogConfigurationMutex.Lock()
+	ret, specificReturn := fake.syslogConfigurationReturnsOnCall[len(fake.syslogConfigurationArgsForCall)]
+	fake.syslogConfigurationArgsForCall = append(fake.syslogConfigurationArgsForCall, struct {
+		productGUID string
+	}{productGUID})
+	fake.recordInvocation("SyslogConfiguration", []interface{}{productGUID})
+	fake.syslogConfigurationMutex.Unlock()
+	if fake.SyslogConfigurationStub != nil {
+		return fake.SyslogConfigurationStub(productGUID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.syslogConfigurationReturns.result1, fake.syslogConfigurationReturns.result2
+}
+
+func (fake *ProductConfigurer) SyslogConfigurationCallCount() int {
+	fake.syslogConfigurationMutex.RLock()
+	defer fake.syslogConfigurationMutex.RUnlock()
+	return len(fake.syslogConfigurationArgsForCall)
+}
+
+func (fake *ProductConfigurer) SyslogConfigurationArgsForCall(i int) string {
+	fake.syslogConfigurationMutex.RLock()
+	defer fake.syslogConfigurationMutex.RUnlock()
+	return fake.syslogConfigurationArgsForCall[i].productGUID
+}
+
+func (fake *ProductConfigurer) SyslogConfigurationReturns(result1 map[string]interface{}, result2 error) {
+	fake.SyslogConfigurationStub = nil
+	fake.syslogConfigurationReturns = struct {
+		result1 map[string]interface{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ProductConfigurer) SyslogConfigurationReturnsOnCall(i int, result1 map[string]interface{}, result2 error) {
+	fake.SyslogConfigurationStub = nil
+	if fake.syslogConfigurationReturnsOnCall == nil {
+		fake.syslogConfigurationReturnsOnCall = make(map[int]struct {
+			result1 map[string]interface{}
+			result2 error
+		})
+	}
+	fake.syslogConfigurationReturnsOnCall[i] = struct {
+		result1 map[string]interface{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ProductConfigurer) ConfigureSyslog(productGUID string, syslogConfiguration map[string]interface{}) error {
+	fake.configureSyslogMutex.Lock()
+	ret, specificReturn := fake.configureSyslogReturnsOnCall[len(fake.configureSyslogArgsForCall)]
+	fake.configureSyslogArgsForCall = append(fake.configureSyslogArgsForCall, struct {
+		productGUID         string
+		syslogConfiguration map[string]interface{}
+	}{productGUID, syslogConfiguration})
+	fake.recordInvocation("ConfigureSyslog", []interface{}{productGUID, syslogConfiguration})
+	fake.configureSyslogMutex.Unlock()
+	if fake.ConfigureSyslogStub != nil {
+		return fake.ConfigureSyslogStub(productGUID, syslogConfiguration)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.configureSyslogReturns.result1
+}
+
+func (fake *ProductConfigurer) ConfigureSyslogCallCount() int {
+	fake.configureSyslogMutex.RLock()
+	defer fake.configureSyslogMutex.RUnlock()
+	return len(fake.configureSyslogArgsForCall)
+}
+
+func (fake *ProductConfigurer) ConfigureSyslogArgsForCall(i int) (string, map[string]interface{}) {
+	fake.configureSyslogMutex.RLock()
+	defer fake.configureSyslogMutex.RUnlock()
+	return fake.configureSyslogArgsForCall[i].productGUID, fake.configureSyslogArgsForCall[i].syslogConfiguration
+}
+
+func (fake *ProductConfigurer) ConfigureSyslogReturns(result1 error) {
+	fake.ConfigureSyslogStub = nil
+	fake.configureSyslogReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ProductConfigurer) ConfigureSyslogReturnsOnCall(i int, result1 error) {
+	fake.ConfigureSyslogStub = nil
+	if fake.configureSyslogReturnsOnCall == nil {
+		fake.configureSyslogReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.configureSyslogReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ProductConfigurer) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.stagedProductsMutex.RLock()
 	defer fake.stagedProductsMutex.RUnlock()
+	fake.propertiesMutex.RLock()
+	defer fake.propertiesMutex.RUnlock()
+	fake.networksAndAZsMutex.RLock()
+	defer fake.networksAndAZsMutex.RUnlock()
 	fake.configureMutex.RLock()
 	defer fake.configureMutex.RUnlock()
+	fake.maxInFlightMutex.RLock()
+	defer fake.maxInFlightMutex.RUnlock()
+	fake.configureMaxInFlightMutex.RLock()
+	defer fake.configureMaxInFlightMutex.RUnlock()
+	fake.syslogConfigurationMutex.RLock()
+	defer fake.syslogConfigurationMutex.RUnlock()
+	fake.configureSyslogMutex.RLock()
+	defer fake.configureSyslogMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
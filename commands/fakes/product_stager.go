@@ -20,6 +20,31 @@ type ProductStager struct {
 	stageReturnsOnCall map[int]struct {
 		result1 error
 	}
+	StagedProductsStub        func() (api.StagedProductsOutput, error)
+	stagedProductsMutex       sync.RWMutex
+	stagedProductsArgsForCall []struct {
+	}
+	stagedProductsReturns struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}
+	stagedProductsReturnsOnCall map[int]struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}
+	DependenciesStub        func(string) ([]api.ProductDependency, error)
+	dependenciesMutex       sync.RWMutex
+	dependenciesArgsForCall []struct {
+		arg1 string
+	}
+	dependenciesReturns struct {
+		result1 []api.ProductDependency
+		result2 error
+	}
+	dependenciesReturnsOnCall map[int]struct {
+		result1 []api.ProductDependency
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -73,11 +98,109 @@ func (fake *ProductStager) StageReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *ProductStager) StagedProducts() (api.StagedProductsOutput, error) {
+	fake.stagedProductsMutex.Lock()
+	ret, specificReturn := fake.stagedProductsReturnsOnCall[len(fake.stagedProductsArgsForCall)]
+	fake.stagedProductsArgsForCall = append(fake.stagedProductsArgsForCall, struct{}{})
+	fake.recordInvocation("StagedProducts", []interface{}{})
+	fake.stagedProductsMutex.Unlock()
+	if fake.StagedProductsStub != nil {
+		return fake.StagedProductsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.stagedProductsReturns.result1, fake.stagedProductsReturns.result2
+}
+
+func (fake *ProductStager) StagedProductsCallCount() int {
+	fake.stagedProductsMutex.RLock()
+	defer fake.stagedProductsMutex.RUnlock()
+	return len(fake.stagedProductsArgsForCall)
+}
+
+func (fake *ProductStager) StagedProductsReturns(result1 api.StagedProductsOutput, result2 error) {
+	fake.StagedProductsStub = nil
+	fake.stagedProductsReturns = struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ProductStager) StagedProductsReturnsOnCall(i int, result1 api.StagedProductsOutput, result2 error) {
+	fake.StagedProductsStub = nil
+	if fake.stagedProductsReturnsOnCall == nil {
+		fake.stagedProductsReturnsOnCall = make(map[int]struct {
+			result1 api.StagedProductsOutput
+			result2 error
+		})
+	}
+	fake.stagedProductsReturnsOnCall[i] = struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ProductStager) Dependencies(arg1 string) ([]api.ProductDependency, error) {
+	fake.dependenciesMutex.Lock()
+	ret, specificReturn := fake.dependenciesReturnsOnCall[len(fake.dependenciesArgsForCall)]
+	fake.dependenciesArgsForCall = append(fake.dependenciesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("Dependencies", []interface{}{arg1})
+	fake.dependenciesMutex.Unlock()
+	if fake.DependenciesStub != nil {
+		return fake.DependenciesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.dependenciesReturns.result1, fake.dependenciesReturns.result2
+}
+
+func (fake *ProductStager) DependenciesCallCount() int {
+	fake.dependenciesMutex.RLock()
+	defer fake.dependenciesMutex.RUnlock()
+	return len(fake.dependenciesArgsForCall)
+}
+
+func (fake *ProductStager) DependenciesArgsForCall(i int) string {
+	fake.dependenciesMutex.RLock()
+	defer fake.dependenciesMutex.RUnlock()
+	return fake.dependenciesArgsForCall[i].arg1
+}
+
+func (fake *ProductStager) DependenciesReturns(result1 []api.ProductDependency, result2 error) {
+	fake.DependenciesStub = nil
+	fake.dependenciesReturns = struct {
+		result1 []api.ProductDependency
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ProductStager) DependenciesReturnsOnCall(i int, result1 []api.ProductDependency, result2 error) {
+	fake.DependenciesStub = nil
+	if fake.dependenciesReturnsOnCall == nil {
+		fake.dependenciesReturnsOnCall = make(map[int]struct {
+			result1 []api.ProductDependency
+			result2 error
+		})
+	}
+	fake.dependenciesReturnsOnCall[i] = struct {
+		result1 []api.ProductDependency
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ProductStager) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.stageMutex.RLock()
 	defer fake.stageMutex.RUnlock()
+	fake.stagedProductsMutex.RLock()
+	defer fake.stagedProductsMutex.RUnlock()
+	fake.dependenciesMutex.RLock()
+	defer fake.dependenciesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/presenters"
+)
+
+type IAASConfigurations struct {
+	service   iaasConfigurationsLister
+	presenter presenters.Presenter
+}
+
+//go:generate counterfeiter -o ./fakes/iaas_configurations_lister.go --fake-name IAASConfigurationsLister . iaasConfigurationsLister
+type iaasConfigurationsLister interface {
+	IAASConfigurations() ([]api.IAASConfiguration, error)
+}
+
+func NewIAASConfigurations(service iaasConfigurationsLister, presenter presenters.Presenter) IAASConfigurations {
+	return IAASConfigurations{
+		service:   service,
+		presenter: presenter,
+	}
+}
+
+func (i IAASConfigurations) Execute(args []string) error {
+	configurations, err := i.service.IAASConfigurations()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve iaas configurations: %s", err)
+	}
+
+	i.presenter.PresentIAASConfigurations(configurations)
+	return nil
+}
+
+func (i IAASConfigurations) Usage() commands.Usage {
+	return commands.Usage{
+		Description:      "This authenticated command lists the named IaaS configurations of the director. With --format json, the configurations are printed in full, as accepted by the iaas-configurations of configure-director.",
+		ShortDescription: "lists director IaaS configurations",
+	}
+}
@@ -0,0 +1,63 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	jhandacommands "github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IAASConfigurations", func() {
+	var (
+		service   *fakes.IAASConfigurationsLister
+		presenter *fakes.Presenter
+	)
+
+	BeforeEach(func() {
+		service = &fakes.IAASConfigurationsLister{}
+		presenter = &fakes.Presenter{}
+	})
+
+	Describe("Execute", func() {
+		It("presents the iaas configurations", func() {
+			configurations := []api.IAASConfiguration{
+				{GUID: "some-guid", Name: "vcenter-one", Properties: json.RawMessage(`{"guid": "some-guid", "name": "vcenter-one"}`)},
+				{GUID: "other-guid", Name: "vcenter-two", Properties: json.RawMessage(`{"guid": "other-guid", "name": "vcenter-two"}`)},
+			}
+			service.IAASConfigurationsReturns(configurations, nil)
+
+			command := commands.NewIAASConfigurations(service, presenter)
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(presenter.PresentIAASConfigurationsCallCount()).To(Equal(1))
+			Expect(presenter.PresentIAASConfigurationsArgsForCall(0)).To(Equal(configurations))
+		})
+
+		Context("when the iaas configurations cannot be retrieved", func() {
+			It("returns an error", func() {
+				service.IAASConfigurationsReturns(nil, errors.New("some error"))
+
+				command := commands.NewIAASConfigurations(service, presenter)
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to retrieve iaas configurations: some error"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			command := commands.NewIAASConfigurations(nil, nil)
+			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
+				Description:      "This authenticated command lists the named IaaS configurations of the director. With --format json, the configurations are printed in full, as accepted by the iaas-configurations of configure-director.",
+				ShortDescription: "lists director IaaS configurations",
+			}))
+		})
+	})
+})
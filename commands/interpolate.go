@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var placeholderRegexp = regexp.MustCompile(`\(\(([-\w\./]+)\)\)`)
+
+func interpolate(node interface{}, vars map[string]interface{}) (interface{}, error) {
+	missing := map[string]bool{}
+	interpolated := interpolateNode(node, vars, missing)
+
+	if len(missing) > 0 {
+		var names []string
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return nil, fmt.Errorf("could not find values for the following placeholders: %s", strings.Join(names, ", "))
+	}
+
+	return interpolated, nil
+}
+
+func interpolateNode(node interface{}, vars map[string]interface{}, missing map[string]bool) interface{} {
+	switch n := node.(type) {
+	case map[interface{}]interface{}:
+		interpolated := map[interface{}]interface{}{}
+		for key, value := range n {
+			interpolated[key] = interpolateNode(value, vars, missing)
+		}
+		return interpolated
+	case []interface{}:
+		var interpolated []interface{}
+		for _, value := range n {
+			interpolated = append(interpolated, interpolateNode(value, vars, missing))
+		}
+		return interpolated
+	case string:
+		if match := placeholderRegexp.FindStringSubmatch(n); match != nil && match[0] == n {
+			value, ok := vars[match[1]]
+			if !ok || value == nil {
+				missing[match[1]] = true
+				return n
+			}
+			return value
+		}
+
+		return placeholderRegexp.ReplaceAllStringFunc(n, func(placeholder string) string {
+			name := placeholderRegexp.FindStringSubmatch(placeholder)[1]
+			value, ok := vars[name]
+			if !ok || value == nil {
+				missing[name] = true
+				return placeholder
+			}
+			return fmt.Sprintf("%v", value)
+		})
+	default:
+		return node
+	}
+}
+
+func jsonCompatible(node interface{}) interface{} {
+	switch n := node.(type) {
+	case map[interface{}]interface{}:
+		converted := map[string]interface{}{}
+		for key, value := range n {
+			converted[fmt.Sprintf("%v", key)] = jsonCompatible(value)
+		}
+		return converted
+	case map[string]interface{}:
+		converted := map[string]interface{}{}
+		for key, value := range n {
+			converted[key] = jsonCompatible(value)
+		}
+		return converted
+	case []interface{}:
+		converted := []interface{}{}
+		for _, value := range n {
+			converted = append(converted, jsonCompatible(value))
+		}
+		return converted
+	default:
+		return node
+	}
+}
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("interpolate", func() {
+	vars := map[string]interface{}{
+		"name":            "some-name",
+		"port":            8443,
+		"enabled":         true,
+		"certificate":     map[interface{}]interface{}{"cert_pem": "some-cert", "private_key_pem": "some-key"},
+		"nested/path.key": "some-nested-value",
+		"empty":           nil,
+	}
+
+	DescribeTable("fills in the placeholders",
+		func(node, expected interface{}) {
+			interpolated, err := interpolate(node, vars)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(interpolated).To(Equal(expected))
+		},
+		Entry("keeps the type of a value that replaces a whole string", "((port))", 8443),
+		Entry("replaces a whole string with a map", "((certificate))", map[interface{}]interface{}{"cert_pem": "some-cert", "private_key_pem": "some-key"}),
+		Entry("formats values that are part of a string", "https://((name)):((port))", "https://some-name:8443"),
+		Entry("accepts names with slashes and dots", "((nested/path.key))", "some-nested-value"),
+		Entry("leaves strings without placeholders alone", "some-value", "some-value"),
+		Entry("leaves other values alone", false, false),
+		Entry("fills in the values of maps",
+			map[interface{}]interface{}{"name": "((name))", "enabled": "((enabled))"},
+			map[interface{}]interface{}{"name": "some-name", "enabled": true},
+		),
+		Entry("fills in the items of lists",
+			[]interface{}{"((name))", map[interface{}]interface{}{"port": "((port))"}},
+			[]interface{}{"some-name", map[interface{}]interface{}{"port": 8443}},
+		),
+	)
+
+	DescribeTable("lists every missing placeholder once, sorted",
+		func(node interface{}, message string) {
+			_, err := interpolate(node, vars)
+			Expect(err).To(MatchError(message))
+		},
+		Entry("in a whole string", "((missing))", "could not find values for the following placeholders: missing"),
+		Entry("in part of a string", "some-((missing))-value", "could not find values for the following placeholders: missing"),
+		Entry("with a value of null", "((empty))", "could not find values for the following placeholders: empty"),
+		Entry("across maps and lists",
+			map[interface{}]interface{}{
+				"first":  "((zebra))",
+				"second": []interface{}{"((apple))", "((zebra))"},
+			},
+			"could not find values for the following placeholders: apple, zebra",
+		),
+	)
+})
+
+var _ = Describe("jsonCompatible", func() {
+	It("turns the keys of yaml maps into strings", func() {
+		converted := jsonCompatible(map[interface{}]interface{}{
+			"some-key": []interface{}{
+				map[interface{}]interface{}{1: "some-value"},
+			},
+		})
+
+		Expect(converted).To(Equal(map[string]interface{}{
+			"some-key": []interface{}{
+				map[string]interface{}{"1": "some-value"},
+			},
+		}))
+	})
+})
@@ -0,0 +1,337 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	"github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/jhanda/flags"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// openStackAPIFields maps the configure-bosh names of the OpenStack iaas
+// fields to the names the director api uses for them.
+var openStackAPIFields = map[string]string{
+	"openstack_domain":             "domain",
+	"openstack_authentication_url": "identity_endpoint",
+	"openstack_key_pair_name":      "key_pair_name",
+	"openstack_password":           "password",
+	"openstack_region":             "region",
+	"openstack_security_group":     "security_group",
+	"openstack_tenant":             "tenant",
+	"openstack_username":           "username",
+}
+
+type MigrateBoshConfig struct {
+	stdout  logger
+	stderr  logger
+	Options struct {
+		IaaSConfiguration              string `short:"i"  long:"iaas-configuration"  description:"iaas specific JSON configuration given to configure-bosh"`
+		DirectorConfiguration          string `short:"d"  long:"director-configuration"  description:"director-specific JSON configuration given to configure-bosh"`
+		SecurityConfiguration          string `short:"s"  long:"security-configuration"  description:"security-specific JSON configuration given to configure-bosh"`
+		AvailabilityZonesConfiguration string `short:"a"  long:"az-configuration"  description:"availability zones JSON configuration given to configure-bosh"`
+		NetworksConfiguration          string `short:"n"  long:"networks-configuration"  description:"network configuration given to configure-bosh"`
+		NetworkAssignment              string `short:"na"  long:"network-assignment"  description:"network assignment given to configure-bosh"`
+		ResourceConfiguration          string `short:"r"  long:"resource-configuration"  description:"resource configuration given to configure-bosh"`
+		OutputFile                     string `short:"o"  long:"output-file"  description:"path to write the configure-director configuration to (default: stdout)"`
+	}
+}
+
+type boshConfigMigration struct {
+	sections map[string]interface{}
+	dropped  []string
+}
+
+func NewMigrateBoshConfig(stdout logger, stderr logger) MigrateBoshConfig {
+	return MigrateBoshConfig{
+		stdout: stdout,
+		stderr: stderr,
+	}
+}
+
+func (m MigrateBoshConfig) Execute(args []string) error {
+	_, err := flags.Parse(&m.Options, args)
+	if err != nil {
+		return fmt.Errorf("could not parse migrate-bosh-config flags: %s", err)
+	}
+
+	migration := &boshConfigMigration{sections: map[string]interface{}{}}
+
+	inputs := []struct {
+		name    string
+		value   string
+		migrate func(map[string]interface{}) (interface{}, error)
+	}{
+		{"iaas-configuration", m.Options.IaaSConfiguration, migration.iaasConfiguration},
+		{"director-configuration", m.Options.DirectorConfiguration, migration.directorConfiguration},
+		{"security-configuration", m.Options.SecurityConfiguration, migration.securityConfiguration},
+		{"az-configuration", m.Options.AvailabilityZonesConfiguration, migration.azConfiguration},
+		{"networks-configuration", m.Options.NetworksConfiguration, migration.networksConfiguration},
+		{"network-assignment", m.Options.NetworkAssignment, migration.networkAssignment},
+		{"resource-configuration", m.Options.ResourceConfiguration, migration.resourceConfiguration},
+	}
+
+	var given bool
+	for _, input := range inputs {
+		if input.value == "" || input.value == "{}" {
+			continue
+		}
+		given = true
+
+		var config map[string]interface{}
+		err = json.Unmarshal([]byte(input.value), &config)
+		if err != nil {
+			return fmt.Errorf("could not decode %s json: %s", input.name, err)
+		}
+
+		section, err := input.migrate(config)
+		if err != nil {
+			return fmt.Errorf("could not migrate %s: %s", input.name, err)
+		}
+
+		if section != nil {
+			migration.sections[input.name] = section
+		}
+	}
+
+	if !given {
+		return errors.New("at least one configuration flag must be provided. Please see usage for more information.")
+	}
+
+	contents, err := yaml.Marshal(migration.sections)
+	if err != nil {
+		return err // cannot be tested
+	}
+
+	if m.Options.OutputFile == "" {
+		m.stdout.Printf("%s", contents)
+	} else {
+		err = ioutil.WriteFile(m.Options.OutputFile, contents, 0644)
+		if err != nil {
+			return fmt.Errorf("could not write configure-director configuration: %s", err)
+		}
+	}
+
+	if len(migration.dropped) > 0 {
+		m.stderr.Printf("the following fields have no configure-director equivalent and were not migrated:")
+		for _, field := range migration.dropped {
+			m.stderr.Printf("  %s", field)
+		}
+	}
+
+	return nil
+}
+
+func (b *boshConfigMigration) iaasConfiguration(config map[string]interface{}) (interface{}, error) {
+	return b.fields("iaas-configuration", config, jsonFieldNames(reflect.TypeOf(IaaSConfiguration{})), openStackAPIFields), nil
+}
+
+func (b *boshConfigMigration) directorConfiguration(config map[string]interface{}) (interface{}, error) {
+	director := b.fields("director-configuration", config, jsonFieldNames(reflect.TypeOf(DirectorConfiguration{})), nil)
+
+	if emailer, ok := director["hm_emailer_options"].(map[string]interface{}); ok {
+		if recipients, ok := emailer["recipients"].(string); ok {
+			emailer["recipients"] = map[string]interface{}{"value": recipients}
+		}
+	}
+
+	return director, nil
+}
+
+func (b *boshConfigMigration) securityConfiguration(config map[string]interface{}) (interface{}, error) {
+	security := b.fields("security-configuration", config, jsonFieldNames(reflect.TypeOf(SecurityConfiguration{})), nil)
+
+	if passwordType, ok := security["vm_password_type"]; ok {
+		delete(security, "vm_password_type")
+
+		switch passwordType {
+		case "generate":
+			security["generate_vm_passwords"] = true
+		case "bosh_default":
+			security["generate_vm_passwords"] = false
+		default:
+			b.dropped = append(b.dropped, fmt.Sprintf("security-configuration.vm_password_type: %v", passwordType))
+		}
+	}
+
+	return security, nil
+}
+
+func (b *boshConfigMigration) azConfiguration(config map[string]interface{}) (interface{}, error) {
+	b.fields("az-configuration", config, map[string]bool{"availability_zones": true}, nil)
+
+	azs, err := objects("availability_zones", config["availability_zones"])
+	if err != nil {
+		return nil, err
+	}
+
+	migrated := []interface{}{}
+	for i, az := range azs {
+		migrated = append(migrated, b.fields(fmt.Sprintf("az-configuration.availability_zones[%d]", i), az, jsonFieldNames(reflect.TypeOf(AvailabilityZone{})), nil))
+	}
+
+	return migrated, nil
+}
+
+// networksConfiguration refers to the availability zones of each subnet by
+// name, which the api accepts in place of the guids the form needed.
+func (b *boshConfigMigration) networksConfiguration(config map[string]interface{}) (interface{}, error) {
+	migrated := b.fields("networks-configuration", config, map[string]bool{"icmp_checks_enabled": true, "networks": true}, nil)
+
+	networks, err := objects("networks", config["networks"])
+	if err != nil {
+		return nil, err
+	}
+
+	migratedNetworks := []interface{}{}
+	for i, network := range networks {
+		section := fmt.Sprintf("networks-configuration.networks[%d]", i)
+		migratedNetwork := b.fields(section, network, jsonFieldNames(reflect.TypeOf(NetworkConfiguration{})), nil)
+
+		subnets, err := objects(fmt.Sprintf("networks[%d].subnets", i), network["subnets"])
+		if err != nil {
+			return nil, err
+		}
+
+		migratedSubnets := []interface{}{}
+		for j, subnet := range subnets {
+			migratedSubnets = append(migratedSubnets, b.fields(fmt.Sprintf("%s.subnets[%d]", section, j), subnet, jsonFieldNames(reflect.TypeOf(Subnet{})), map[string]string{
+				"availability_zones": "availability_zone_names",
+			}))
+		}
+
+		migratedNetwork["subnets"] = migratedSubnets
+		migratedNetworks = append(migratedNetworks, migratedNetwork)
+	}
+
+	migrated["networks"] = migratedNetworks
+
+	return migrated, nil
+}
+
+func (b *boshConfigMigration) networkAssignment(config map[string]interface{}) (interface{}, error) {
+	assignment := b.fields("network-assignment", config, jsonFieldNames(reflect.TypeOf(NetworkAssignment{})), nil)
+
+	for _, key := range []string{"network", "singleton_availability_zone"} {
+		if name, ok := assignment[key]; ok {
+			assignment[key] = map[string]interface{}{"name": name}
+		}
+	}
+
+	return assignment, nil
+}
+
+// resourceConfiguration keeps the director and compilation jobs, whose
+// configure-bosh fields already match the resource config api.
+func (b *boshConfigMigration) resourceConfiguration(config map[string]interface{}) (interface{}, error) {
+	known := map[string]map[string]bool{
+		"director":    jsonFieldNames(reflect.TypeOf(DirectorResourceConfiguration{})),
+		"compilation": jsonFieldNames(reflect.TypeOf(CompilationResourceConfiguration{})),
+	}
+
+	resources := map[string]interface{}{}
+	for _, name := range sortedKeys(config) {
+		section := fmt.Sprintf("resource-configuration.%s", name)
+
+		fields, ok := known[name]
+		if !ok {
+			b.dropped = append(b.dropped, section)
+			continue
+		}
+
+		job, ok := config[name].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s must be an object", name)
+		}
+
+		resources[name] = b.fields(section, job, fields, nil)
+	}
+
+	if len(resources) == 0 {
+		return nil, nil
+	}
+
+	return resources, nil
+}
+
+// fields copies the known fields of a configure-bosh section under their api
+// names. Empty fields are left out, as configure-bosh never submits them, and
+// unknown fields are recorded as dropped.
+func (b *boshConfigMigration) fields(section string, config map[string]interface{}, known map[string]bool, apiNames map[string]string) map[string]interface{} {
+	migrated := map[string]interface{}{}
+
+	for _, key := range sortedKeys(config) {
+		value := config[key]
+		if value == nil || value == "" {
+			continue
+		}
+
+		if !known[key] {
+			b.dropped = append(b.dropped, fmt.Sprintf("%s.%s", section, key))
+			continue
+		}
+
+		if name, ok := apiNames[key]; ok {
+			key = name
+		}
+
+		migrated[key] = value
+	}
+
+	return migrated
+}
+
+// jsonFieldNames returns the json names of the fields of a configure-director
+// struct, including the fields of the structs embedded in it.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+
+		switch {
+		case field.Anonymous && name == "":
+			for embedded := range jsonFieldNames(field.Type) {
+				names[embedded] = true
+			}
+		case name != "" && name != "-":
+			names[name] = true
+		}
+	}
+
+	return names
+}
+
+func objects(name string, value interface{}) ([]map[string]interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be a list", name)
+	}
+
+	var objects []map[string]interface{}
+	for i, item := range list {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s[%d] must be an object", name, i)
+		}
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+func (m MigrateBoshConfig) Usage() commands.Usage {
+	return commands.Usage{
+		Description:      "This command translates the flags of a configure-bosh invocation into the configuration sections of configure-director, which can be given to configure-director or put in the director section of a converge manifest. Fields that have no configure-director equivalent are reported.",
+		ShortDescription: "translates configure-bosh flags into configure-director configuration",
+		Flags:            m.Options,
+	}
+}
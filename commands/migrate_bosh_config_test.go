@@ -0,0 +1,226 @@
+package commands_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	jhandacommands "github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MigrateBoshConfig", func() {
+	var (
+		stdout *fakes.Logger
+		stderr *fakes.Logger
+	)
+
+	loggedLines := func(logger *fakes.Logger) []string {
+		var lines []string
+		for i := 0; i < logger.PrintfCallCount(); i++ {
+			format, content := logger.PrintfArgsForCall(i)
+			lines = append(lines, fmt.Sprintf(format, content...))
+		}
+		return lines
+	}
+
+	BeforeEach(func() {
+		stdout = &fakes.Logger{}
+		stderr = &fakes.Logger{}
+	})
+
+	Describe("Execute", func() {
+		It("translates the configure-bosh flags into configure-director configuration", func() {
+			command := commands.NewMigrateBoshConfig(stdout, stderr)
+			err := command.Execute([]string{
+				"--iaas-configuration", `{
+					"openstack_authentication_url": "https://keystone.example.com",
+					"openstack_username": "some-username",
+					"openstack_password": "some-password",
+					"ssh_private_key": "some-key",
+					"disable_dhcp": false
+				}`,
+				"--director-configuration", `{
+					"ntp_servers_string": "ntp.example.com",
+					"max_threads": 5,
+					"metrics_ip": "",
+					"hm_emailer_options": {"enabled": true, "recipients": "ops@example.com"}
+				}`,
+				"--security-configuration", `{"trusted_certificates": "some-cert", "vm_password_type": "bosh_default"}`,
+				"--az-configuration", `{"availability_zones": [{"name": "az-1", "cluster": "some-cluster", "resource_pool": "some-pool"}, {"name": "az-2"}]}`,
+				"--networks-configuration", `{
+					"icmp_checks_enabled": false,
+					"networks": [{
+						"name": "some-network",
+						"service_network": true,
+						"subnets": [{
+							"iaas_identifier": "some-vpc",
+							"cidr": "10.0.0.0/24",
+							"reserved_ip_ranges": "10.0.0.1-10.0.0.9",
+							"dns": "8.8.8.8",
+							"gateway": "10.0.0.1",
+							"availability_zones": ["az-1", "az-2"]
+						}]
+					}]
+				}`,
+				"--network-assignment", `{"network": "some-network", "singleton_availability_zone": "az-1"}`,
+				"--resource-configuration", `{
+					"director": {"instance_type": {"id": "m4.large"}, "persistent_disk": {"size_mb": "51200"}},
+					"compilation": {"instances": 4, "internet_connected": false}
+				}`,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(loggedLines(stdout)).To(Equal([]string{`az-configuration:
+- cluster: some-cluster
+  name: az-1
+  resource_pool: some-pool
+- name: az-2
+director-configuration:
+  hm_emailer_options:
+    enabled: true
+    recipients:
+      value: ops@example.com
+  max_threads: 5
+  ntp_servers_string: ntp.example.com
+iaas-configuration:
+  disable_dhcp: false
+  identity_endpoint: https://keystone.example.com
+  password: some-password
+  ssh_private_key: some-key
+  username: some-username
+network-assignment:
+  network:
+    name: some-network
+  singleton_availability_zone:
+    name: az-1
+networks-configuration:
+  icmp_checks_enabled: false
+  networks:
+  - name: some-network
+    service_network: true
+    subnets:
+    - availability_zone_names:
+      - az-1
+      - az-2
+      cidr: 10.0.0.0/24
+      dns: 8.8.8.8
+      gateway: 10.0.0.1
+      iaas_identifier: some-vpc
+      reserved_ip_ranges: 10.0.0.1-10.0.0.9
+resource-configuration:
+  compilation:
+    instances: 4
+    internet_connected: false
+  director:
+    instance_type:
+      id: m4.large
+    persistent_disk:
+      size_mb: "51200"
+security-configuration:
+  generate_vm_passwords: false
+  trusted_certificates: some-cert
+`}))
+			Expect(stderr.PrintfCallCount()).To(Equal(0))
+		})
+
+		It("reports the fields that have no configure-director equivalent", func() {
+			command := commands.NewMigrateBoshConfig(stdout, stderr)
+			err := command.Execute([]string{
+				"--iaas-configuration", `{"project": "some-project", "some-unknown-field": "value"}`,
+				"--security-configuration", `{"vm_password_type": "something-else"}`,
+				"--networks-configuration", `{"networks": [{"name": "some-network", "subnets": [{"cidr": "10.0.0.0/24", "vlan": 12}]}]}`,
+				"--resource-configuration", `{"director": {"instance_type": {"id": "m4.large"}, "vm_extensions": ["some-extension"]}, "router": {"instances": 2}}`,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(loggedLines(stderr)).To(Equal([]string{
+				"the following fields have no configure-director equivalent and were not migrated:",
+				"  iaas-configuration.some-unknown-field",
+				"  security-configuration.vm_password_type: something-else",
+				"  networks-configuration.networks[0].subnets[0].vlan",
+				"  resource-configuration.director.vm_extensions",
+				"  resource-configuration.router",
+			}))
+		})
+
+		It("writes the configuration to the output file when one is given", func() {
+			outputFile, err := ioutil.TempFile("", "director.yml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputFile.Close()).To(Succeed())
+			defer os.Remove(outputFile.Name())
+
+			command := commands.NewMigrateBoshConfig(stdout, stderr)
+			err = command.Execute([]string{
+				"--director-configuration", `{"ntp_servers_string": "ntp.example.com"}`,
+				"--output-file", outputFile.Name(),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := ioutil.ReadFile(outputFile.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("director-configuration:\n  ntp_servers_string: ntp.example.com\n"))
+			Expect(stdout.PrintfCallCount()).To(Equal(0))
+		})
+
+		Context("failure cases", func() {
+			Context("when an unknown flag is provided", func() {
+				It("returns an error", func() {
+					command := commands.NewMigrateBoshConfig(stdout, stderr)
+					err := command.Execute([]string{"--badflag"})
+					Expect(err).To(MatchError("could not parse migrate-bosh-config flags: flag provided but not defined: -badflag"))
+				})
+			})
+
+			Context("when no configuration flag is provided", func() {
+				It("returns an error", func() {
+					command := commands.NewMigrateBoshConfig(stdout, stderr)
+					err := command.Execute([]string{"--iaas-configuration", "{}"})
+					Expect(err).To(MatchError("at least one configuration flag must be provided. Please see usage for more information."))
+				})
+			})
+
+			Context("when a configuration is not valid json", func() {
+				It("returns an error", func() {
+					command := commands.NewMigrateBoshConfig(stdout, stderr)
+					err := command.Execute([]string{"--director-configuration", "{"})
+					Expect(err).To(MatchError(ContainSubstring("could not decode director-configuration json:")))
+				})
+			})
+
+			Context("when the networks are not a list", func() {
+				It("returns an error", func() {
+					command := commands.NewMigrateBoshConfig(stdout, stderr)
+					err := command.Execute([]string{"--networks-configuration", `{"networks": {"name": "some-network"}}`})
+					Expect(err).To(MatchError("could not migrate networks-configuration: networks must be a list"))
+				})
+			})
+
+			Context("when the output file cannot be written", func() {
+				It("returns an error", func() {
+					command := commands.NewMigrateBoshConfig(stdout, stderr)
+					err := command.Execute([]string{
+						"--director-configuration", `{"ntp_servers_string": "ntp.example.com"}`,
+						"--output-file", "/not/a/real/dir/director.yml",
+					})
+					Expect(err).To(MatchError(ContainSubstring("could not write configure-director configuration:")))
+				})
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			command := commands.NewMigrateBoshConfig(nil, nil)
+			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
+				Description:      "This command translates the flags of a configure-bosh invocation into the configuration sections of configure-director, which can be given to configure-director or put in the director section of a converge manifest. Fields that have no configure-director equivalent are reported.",
+				ShortDescription: "translates configure-bosh flags into configure-director configuration",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
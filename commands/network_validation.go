@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+)
+
+type directorNetworksConfiguration struct {
+	Networks []directorNetwork `json:"networks"`
+}
+
+type directorNetwork struct {
+	Name    string           `json:"name"`
+	Network string           `json:"network"`
+	Subnets []directorSubnet `json:"subnets"`
+}
+
+// name returns the name of the network, which older configurations give as
+// network rather than name.
+func (n directorNetwork) name() string {
+	if n.Name != "" {
+		return n.Name
+	}
+
+	return n.Network
+}
+
+type directorSubnet struct {
+	IAASIdentifier        string   `json:"iaas_identifier"`
+	CIDR                  string   `json:"cidr"`
+	ReservedIPRanges      string   `json:"reserved_ip_ranges"`
+	DNS                   string   `json:"dns"`
+	Gateway               string   `json:"gateway"`
+	AvailabilityZoneNames []string `json:"availability_zone_names"`
+}
+
+// validateNetworks checks the networks configuration for the mistakes the
+// api would otherwise only report when the networks are applied. Each subnet
+// must refer to availability zones that are in the az configuration or that
+// are already staged. stagedAZs is only called when a subnet refers to an
+// availability zone that is not in the az configuration.
+func validateNetworks(networksConfiguration, azConfiguration string, stagedAZs func() ([]string, error)) error {
+	var config directorNetworksConfiguration
+	err := json.Unmarshal([]byte(networksConfiguration), &config)
+	if err != nil {
+		return fmt.Errorf("could not decode networks-configuration json: %s", err)
+	}
+
+	configuredAZs := azNames(azConfiguration)
+
+	var problems []string
+	var cidrs []*net.IPNet
+	var cidrPaths []string
+	var names []string
+	var unknownAZs []string
+	var unknownAZPaths []string
+
+	for i, network := range config.Networks {
+		path := fmt.Sprintf("networks-configuration.networks[%d]", i)
+
+		name := network.name()
+		switch {
+		case name == "":
+			problems = append(problems, fmt.Sprintf("%s: name is missing", path))
+		case contains(names, name):
+			problems = append(problems, fmt.Sprintf("%s: name %s is used more than once", path, name))
+		default:
+			names = append(names, name)
+		}
+
+		for j, subnet := range network.Subnets {
+			subnetPath := fmt.Sprintf("%s.subnets[%d]", path, j)
+
+			for _, az := range subnet.AvailabilityZoneNames {
+				if !contains(configuredAZs, az) {
+					unknownAZs = append(unknownAZs, az)
+					unknownAZPaths = append(unknownAZPaths, subnetPath)
+				}
+			}
+
+			_, cidr, err := net.ParseCIDR(subnet.CIDR)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: cidr %q is not a valid cidr", subnetPath, subnet.CIDR))
+				continue
+			}
+
+			for k, other := range cidrs {
+				if cidr.Contains(other.IP) || other.Contains(cidr.IP) {
+					problems = append(problems, fmt.Sprintf("%s: cidr %s overlaps with %s of %s", subnetPath, cidr, other, cidrPaths[k]))
+				}
+			}
+			cidrs = append(cidrs, cidr)
+			cidrPaths = append(cidrPaths, subnetPath)
+
+			if subnet.Gateway != "" {
+				gateway := net.ParseIP(subnet.Gateway)
+				switch {
+				case gateway == nil:
+					problems = append(problems, fmt.Sprintf("%s: gateway %q is not a valid ip address", subnetPath, subnet.Gateway))
+				case !cidr.Contains(gateway):
+					problems = append(problems, fmt.Sprintf("%s: gateway %s is not in cidr %s", subnetPath, gateway, cidr))
+				}
+			}
+
+			problems = append(problems, validateReservedIPRanges(subnetPath, subnet.ReservedIPRanges, cidr)...)
+		}
+	}
+
+	if len(unknownAZs) > 0 {
+		staged, err := stagedAZs()
+		if err != nil {
+			return fmt.Errorf("failed to fetch availability zones: %s", err)
+		}
+
+		for i, az := range unknownAZs {
+			if !contains(staged, az) {
+				problems = append(problems, fmt.Sprintf("%s: availability zone %s is neither in the az-configuration nor staged", unknownAZPaths[i], az))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("networks-configuration is invalid:\n%s", strings.Join(problems, "\n"))
+	}
+
+	return nil
+}
+
+// validateReservedIPRanges checks a comma separated list of addresses and
+// address ranges, such as "10.0.0.1-10.0.0.9,10.0.0.20".
+func validateReservedIPRanges(path, reservedIPRanges string, cidr *net.IPNet) []string {
+	if strings.TrimSpace(reservedIPRanges) == "" {
+		return nil
+	}
+
+	var problems []string
+	for _, reserved := range strings.Split(reservedIPRanges, ",") {
+		reserved = strings.TrimSpace(reserved)
+
+		bounds := strings.SplitN(reserved, "-", 2)
+		first := net.ParseIP(strings.TrimSpace(bounds[0]))
+		last := first
+		if len(bounds) == 2 {
+			last = net.ParseIP(strings.TrimSpace(bounds[1]))
+		}
+
+		switch {
+		case first == nil || last == nil:
+			problems = append(problems, fmt.Sprintf("%s: reserved ip range %q is not valid", path, reserved))
+		case !cidr.Contains(first) || !cidr.Contains(last):
+			problems = append(problems, fmt.Sprintf("%s: reserved ip range %s is not in cidr %s", path, reserved, cidr))
+		case bytes.Compare(first.To16(), last.To16()) > 0:
+			problems = append(problems, fmt.Sprintf("%s: reserved ip range %s ends before it starts", path, reserved))
+		}
+	}
+
+	return problems
+}
+
+// azNames returns the names of the availability zones in an az
+// configuration, which is a list of availability zones.
+func azNames(azConfiguration string) []string {
+	var azs []struct {
+		Name string `json:"name"`
+	}
+	err := json.Unmarshal([]byte(azConfiguration), &azs)
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, az := range azs {
+		names = append(names, az.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"errors"
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("validateNetworks", func() {
+	stagedAZs := func() ([]string, error) {
+		return []string{"staged-az"}, nil
+	}
+
+	DescribeTable("accepts valid networks",
+		func(networksConfiguration, azConfiguration string) {
+			Expect(validateNetworks(networksConfiguration, azConfiguration, stagedAZs)).To(Succeed())
+		},
+		Entry("without networks", `{}`, ``),
+		Entry("named with name", `{"networks": [{"name": "network-1"}]}`, ``),
+		Entry("named with network", `{"networks": [{"network": "network-1"}]}`, ``),
+		Entry("with a subnet in a configured az",
+			`{"networks": [{"name": "network-1", "subnets": [{
+				"cidr": "10.0.0.0/24",
+				"gateway": "10.0.0.1",
+				"reserved_ip_ranges": "10.0.0.1-10.0.0.9, 10.0.0.20",
+				"availability_zone_names": ["az-1"]
+			}]}]}`,
+			`[{"name": "az-1"}]`,
+		),
+		Entry("with a subnet in a staged az",
+			`{"networks": [{"name": "network-1", "subnets": [{"cidr": "10.0.0.0/24", "availability_zone_names": ["staged-az"]}]}]}`,
+			``,
+		),
+	)
+
+	DescribeTable("reports invalid networks",
+		func(networksConfiguration string, problem string) {
+			err := validateNetworks(networksConfiguration, `[{"name": "az-1"}]`, stagedAZs)
+			Expect(err).To(MatchError("networks-configuration is invalid:\n" + problem))
+		},
+		Entry("without a name",
+			`{"networks": [{"subnets": []}]}`,
+			"networks-configuration.networks[0]: name is missing",
+		),
+		Entry("with a name used twice",
+			`{"networks": [{"name": "network-1"}, {"network": "network-1"}]}`,
+			"networks-configuration.networks[1]: name network-1 is used more than once",
+		),
+		Entry("with an invalid cidr",
+			`{"networks": [{"name": "network-1", "subnets": [{"cidr": "10.0.0.0"}]}]}`,
+			`networks-configuration.networks[0].subnets[0]: cidr "10.0.0.0" is not a valid cidr`,
+		),
+		Entry("with overlapping cidrs",
+			`{"networks": [{"name": "network-1", "subnets": [{"cidr": "10.0.0.0/16"}]}, {"name": "network-2", "subnets": [{"cidr": "10.0.1.0/24"}]}]}`,
+			"networks-configuration.networks[1].subnets[0]: cidr 10.0.1.0/24 overlaps with 10.0.0.0/16 of networks-configuration.networks[0].subnets[0]",
+		),
+		Entry("with an invalid gateway",
+			`{"networks": [{"name": "network-1", "subnets": [{"cidr": "10.0.0.0/24", "gateway": "10.0.0"}]}]}`,
+			`networks-configuration.networks[0].subnets[0]: gateway "10.0.0" is not a valid ip address`,
+		),
+		Entry("with a gateway outside the cidr",
+			`{"networks": [{"name": "network-1", "subnets": [{"cidr": "10.0.0.0/24", "gateway": "10.0.1.1"}]}]}`,
+			"networks-configuration.networks[0].subnets[0]: gateway 10.0.1.1 is not in cidr 10.0.0.0/24",
+		),
+		Entry("with an unknown az",
+			`{"networks": [{"name": "network-1", "subnets": [{"cidr": "10.0.0.0/24", "availability_zone_names": ["az-2"]}]}]}`,
+			"networks-configuration.networks[0].subnets[0]: availability zone az-2 is neither in the az-configuration nor staged",
+		),
+	)
+
+	It("only fetches the staged azs when a subnet refers to an az that is not configured", func() {
+		err := validateNetworks(
+			`{"networks": [{"name": "network-1", "subnets": [{"cidr": "10.0.0.0/24", "availability_zone_names": ["az-1"]}]}]}`,
+			`[{"name": "az-1"}]`,
+			func() ([]string, error) {
+				Fail("the staged azs should not be fetched")
+				return nil, nil
+			},
+		)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when the staged azs cannot be fetched", func() {
+		It("returns an error", func() {
+			err := validateNetworks(
+				`{"networks": [{"name": "network-1", "subnets": [{"cidr": "10.0.0.0/24", "availability_zone_names": ["az-2"]}]}]}`,
+				``,
+				func() ([]string, error) {
+					return nil, errors.New("some error")
+				},
+			)
+			Expect(err).To(MatchError("failed to fetch availability zones: some error"))
+		})
+	})
+
+	Context("when the networks configuration is not json", func() {
+		It("returns an error", func() {
+			err := validateNetworks(`%%%`, ``, stagedAZs)
+			Expect(err).To(MatchError(ContainSubstring("could not decode networks-configuration json")))
+		})
+	})
+})
+
+var _ = Describe("validateReservedIPRanges", func() {
+	_, cidr, _ := net.ParseCIDR("10.0.0.0/24")
+
+	DescribeTable("checks each range",
+		func(reservedIPRanges string, problems []string) {
+			Expect(validateReservedIPRanges("some-subnet", reservedIPRanges, cidr)).To(Equal(problems))
+		},
+		Entry("nothing reserved", " ", nil),
+		Entry("an address and a range", "10.0.0.1, 10.0.0.10-10.0.0.20", nil),
+		Entry("an invalid address", "10.0.0", []string{`some-subnet: reserved ip range "10.0.0" is not valid`}),
+		Entry("an invalid range", "10.0.0.1-banana", []string{`some-subnet: reserved ip range "10.0.0.1-banana" is not valid`}),
+		Entry("a range outside the cidr", "10.0.0.250-10.0.1.5", []string{"some-subnet: reserved ip range 10.0.0.250-10.0.1.5 is not in cidr 10.0.0.0/24"}),
+		Entry("a range that ends before it starts", "10.0.0.20-10.0.0.10", []string{"some-subnet: reserved ip range 10.0.0.20-10.0.0.10 ends before it starts"}),
+	)
+})
+
+var _ = Describe("azNames", func() {
+	DescribeTable("returns the names of the availability zones",
+		func(azConfiguration string, names []string) {
+			Expect(azNames(azConfiguration)).To(Equal(names))
+		},
+		Entry("a list of availability zones", `[{"name": "az-1"}, {"name": "az-2", "cluster": "some-cluster"}]`, []string{"az-1", "az-2"}),
+		Entry("no az configuration", ``, nil),
+		Entry("an az configuration that is not a list", `{"name": "az-1"}`, nil),
+	)
+})
@@ -2,15 +2,23 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/jhanda/flags"
 	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/models"
 	"github.com/pivotal-cf/om/presenters"
 )
 
 type PendingChanges struct {
-	service   pendingChangesService
-	presenter presenters.Presenter
+	service               pendingChangesService
+	stagedProductsService stagedProductsLister
+	diagnosticService     diagnosticService
+	presenter             presenters.Presenter
+	Options               struct {
+		Check bool `long:"check" description:"exit with an error when there are pending changes or incomplete products"`
+	}
 }
 
 //go:generate counterfeiter -o ./fakes/pending_changes_service.go --fake-name PendingChangesService . pendingChangesService
@@ -18,26 +26,146 @@ type pendingChangesService interface {
 	List() (api.PendingChangesOutput, error)
 }
 
-func NewPendingChanges(presenter presenters.Presenter, service pendingChangesService) PendingChanges {
+func NewPendingChanges(presenter presenters.Presenter, service pendingChangesService, stagedProductsService stagedProductsLister, diagnosticService diagnosticService) PendingChanges {
 	return PendingChanges{
-		service:   service,
-		presenter: presenter,
+		service:               service,
+		stagedProductsService: stagedProductsService,
+		diagnosticService:     diagnosticService,
+		presenter:             presenter,
 	}
 }
 
 func (pc PendingChanges) Execute(args []string) error {
+	_, err := flags.Parse(&pc.Options, args)
+	if err != nil {
+		return fmt.Errorf("could not parse pending-changes flags: %s", err)
+	}
+
 	output, err := pc.service.List()
 	if err != nil {
 		return fmt.Errorf("failed to retrieve pending changes %s", err)
 	}
 
-	pc.presenter.PresentPendingChanges(output.ChangeList)
+	// Product names and versions are only extra detail, so the pending
+	// changes are still listed by GUID when they cannot be looked up.
+	names := map[string]string{}
+	stagedProducts, err := pc.stagedProductsService.StagedProducts()
+	if err == nil {
+		for _, product := range stagedProducts.Products {
+			names[product.GUID] = product.Type
+		}
+	}
+
+	report, err := pc.diagnosticService.Report()
+	if err != nil {
+		report = api.DiagnosticReport{}
+	}
+
+	var (
+		pendingChanges []models.PendingChange
+		changed        int
+		incomplete     int
+	)
+	for _, change := range output.ChangeList {
+		pendingChange := models.PendingChange{
+			GUID:     change.Product,
+			Name:     productNameFromGUID(change.Product, names, report.DeployedProducts),
+			Action:   change.Action,
+			Errands:  []models.PendingChangeErrand{},
+			Complete: true,
+		}
+
+		if change.Action == "delete" {
+			pendingChange.Version = productVersion(pendingChange.Name, report.DeployedProducts)
+		} else {
+			pendingChange.Version = productVersion(pendingChange.Name, report.StagedProducts)
+		}
+
+		for _, errand := range change.Errands {
+			pendingChange.Errands = append(pendingChange.Errands, models.PendingChangeErrand{
+				Name:       errand.Name,
+				PostDeploy: errand.PostDeploy,
+				PreDelete:  errand.PreDelete,
+			})
+		}
+
+		if checks := change.CompletenessChecks; checks != nil {
+			if !checks.ConfigurationComplete {
+				pendingChange.Problems = append(pendingChange.Problems, "configuration is incomplete")
+			}
+			if !checks.StemcellPresent {
+				pendingChange.Problems = append(pendingChange.Problems, "stemcell is missing")
+			}
+			if !checks.ConfigurablePropertiesValid {
+				pendingChange.Problems = append(pendingChange.Problems, "properties are invalid")
+			}
+			pendingChange.Complete = len(pendingChange.Problems) == 0
+		}
+
+		if change.Action != "unchanged" {
+			changed++
+		}
+		if !pendingChange.Complete {
+			incomplete++
+		}
+
+		pendingChanges = append(pendingChanges, pendingChange)
+	}
+
+	pc.presenter.PresentPendingChanges(pendingChanges)
+
+	if pc.Options.Check && (changed > 0 || incomplete > 0) {
+		var problems []string
+		if changed > 0 {
+			problems = append(problems, fmt.Sprintf("%d product(s) with pending changes", changed))
+		}
+		if incomplete > 0 {
+			problems = append(problems, fmt.Sprintf("%d incomplete product(s)", incomplete))
+		}
+
+		return fmt.Errorf("foundation is not converged: %s", strings.Join(problems, " and "))
+	}
+
 	return nil
 }
 
+// productNameFromGUID finds the name of a product by its GUID among the
+// staged products. A product that is being deleted is no longer staged, so
+// its name is matched against the deployed products instead, whose GUIDs
+// start with the product name.
+func productNameFromGUID(guid string, stagedNames map[string]string, deployedProducts []api.DiagnosticProduct) string {
+	if name, ok := stagedNames[guid]; ok {
+		return name
+	}
+
+	var name string
+	for _, product := range deployedProducts {
+		if strings.HasPrefix(guid, product.Name+"-") && len(product.Name) > len(name) {
+			name = product.Name
+		}
+	}
+
+	if name == "" {
+		return guid
+	}
+
+	return name
+}
+
+func productVersion(name string, products []api.DiagnosticProduct) string {
+	for _, product := range products {
+		if product.Name == name {
+			return product.Version
+		}
+	}
+
+	return ""
+}
+
 func (pc PendingChanges) Usage() commands.Usage {
 	return commands.Usage{
-		Description:      "This authenticated command lists all pending changes.",
+		Description:      "This authenticated command lists all pending changes, with the version of each product and whether its configuration is complete. With --check it fails when the foundation is not converged.",
 		ShortDescription: "lists pending changes",
+		Flags:            pc.Options,
 	}
 }
@@ -9,26 +9,29 @@ import (
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/commands"
 	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/models"
 )
 
 var _ = Describe("PendingChanges", func() {
 	var (
-		presenter *fakes.Presenter
-		pcService *fakes.PendingChangesService
-		command   commands.PendingChanges
+		presenter             *fakes.Presenter
+		pcService             *fakes.PendingChangesService
+		stagedProductsService *fakes.StagedProductsLister
+		diagnosticService     *fakes.DiagnosticService
+		command               commands.PendingChanges
 	)
 
 	BeforeEach(func() {
 		presenter = &fakes.Presenter{}
 		pcService = &fakes.PendingChangesService{}
-		command = commands.NewPendingChanges(presenter, pcService)
-	})
+		stagedProductsService = &fakes.StagedProductsLister{}
+		diagnosticService = &fakes.DiagnosticService{}
+		command = commands.NewPendingChanges(presenter, pcService, stagedProductsService, diagnosticService)
 
-	It("lists the pending changes", func() {
 		pcService.ListReturns(api.PendingChangesOutput{
 			ChangeList: []api.ProductChange{
 				{
-					Product: "some-product",
+					Product: "some-product-guid",
 					Action:  "update",
 					Errands: []api.Errand{
 						{
@@ -42,26 +45,157 @@ var _ = Describe("PendingChanges", func() {
 							PreDelete:  "false",
 						},
 					},
+					CompletenessChecks: &api.CompletenessChecks{
+						ConfigurationComplete:       true,
+						StemcellPresent:             true,
+						ConfigurablePropertiesValid: true,
+					},
 				},
 				{
-					Product: "some-product-without-errand",
+					Product: "some-product-without-errand-guid",
 					Action:  "install",
 					Errands: []api.Errand{},
+					CompletenessChecks: &api.CompletenessChecks{
+						ConfigurationComplete:       false,
+						StemcellPresent:             false,
+						ConfigurablePropertiesValid: false,
+					},
+				},
+				{
+					Product: "some-deleted-product-abc123",
+					Action:  "delete",
+				},
+				{
+					Product: "p-bosh-guid",
+					Action:  "unchanged",
 				},
 			},
 		}, nil)
 
+		stagedProductsService.StagedProductsReturns(api.StagedProductsOutput{
+			Products: []api.StagedProduct{
+				{GUID: "some-product-guid", Type: "some-product"},
+				{GUID: "some-product-without-errand-guid", Type: "some-product-without-errand"},
+				{GUID: "p-bosh-guid", Type: "p-bosh"},
+			},
+		}, nil)
+
+		diagnosticService.ReportReturns(api.DiagnosticReport{
+			StagedProducts: []api.DiagnosticProduct{
+				{Name: "some-product", Version: "1.1.0"},
+				{Name: "some-product-without-errand", Version: "2.0.0"},
+				{Name: "p-bosh", Version: "2.1.0"},
+			},
+			DeployedProducts: []api.DiagnosticProduct{
+				{Name: "some-product", Version: "1.0.0"},
+				{Name: "some-deleted-product", Version: "3.0.0"},
+				{Name: "p-bosh", Version: "2.1.0"},
+			},
+		}, nil)
+	})
+
+	It("lists the pending changes with the name, version and completeness of each product", func() {
 		err := command.Execute([]string{})
 		Expect(err).NotTo(HaveOccurred())
 
 		Expect(presenter.PresentPendingChangesCallCount()).To(Equal(1))
+		Expect(presenter.PresentPendingChangesArgsForCall(0)).To(Equal([]models.PendingChange{
+			{
+				GUID:    "some-product-guid",
+				Name:    "some-product",
+				Version: "1.1.0",
+				Action:  "update",
+				Errands: []models.PendingChangeErrand{
+					{Name: "some-errand", PostDeploy: "on", PreDelete: "false"},
+					{Name: "some-errand-2", PostDeploy: "when-change", PreDelete: "false"},
+				},
+				Complete: true,
+			},
+			{
+				GUID:     "some-product-without-errand-guid",
+				Name:     "some-product-without-errand",
+				Version:  "2.0.0",
+				Action:   "install",
+				Errands:  []models.PendingChangeErrand{},
+				Problems: []string{"configuration is incomplete", "stemcell is missing", "properties are invalid"},
+			},
+			{
+				GUID:     "some-deleted-product-abc123",
+				Name:     "some-deleted-product",
+				Version:  "3.0.0",
+				Action:   "delete",
+				Errands:  []models.PendingChangeErrand{},
+				Complete: true,
+			},
+			{
+				GUID:     "p-bosh-guid",
+				Name:     "p-bosh",
+				Version:  "2.1.0",
+				Action:   "unchanged",
+				Errands:  []models.PendingChangeErrand{},
+				Complete: true,
+			},
+		}))
+	})
+
+	Context("when --check is provided", func() {
+		It("returns an error when there are pending changes or incomplete products", func() {
+			err := command.Execute([]string{"--check"})
+			Expect(err).To(MatchError("foundation is not converged: 3 product(s) with pending changes and 1 incomplete product(s)"))
+
+			Expect(presenter.PresentPendingChangesCallCount()).To(Equal(1))
+		})
+
+		It("succeeds when every product is unchanged and complete", func() {
+			pcService.ListReturns(api.PendingChangesOutput{
+				ChangeList: []api.ProductChange{
+					{Product: "p-bosh-guid", Action: "unchanged"},
+					{
+						Product: "some-product-guid",
+						Action:  "unchanged",
+						CompletenessChecks: &api.CompletenessChecks{
+							ConfigurationComplete:       true,
+							StemcellPresent:             true,
+							ConfigurablePropertiesValid: true,
+						},
+					},
+				},
+			}, nil)
+
+			err := command.Execute([]string{"--check"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an error when an unchanged product is incomplete", func() {
+			pcService.ListReturns(api.PendingChangesOutput{
+				ChangeList: []api.ProductChange{
+					{
+						Product: "some-product-guid",
+						Action:  "unchanged",
+						CompletenessChecks: &api.CompletenessChecks{
+							ConfigurationComplete:       true,
+							StemcellPresent:             false,
+							ConfigurablePropertiesValid: true,
+						},
+					},
+				},
+			}, nil)
+
+			err := command.Execute([]string{"--check"})
+			Expect(err).To(MatchError("foundation is not converged: 1 incomplete product(s)"))
+		})
 	})
 
 	Context("failure cases", func() {
-		Context("when fetching the pending changes fails", func() {
+		Context("when an unknown flag is provided", func() {
 			It("returns an error", func() {
-				command := commands.NewPendingChanges(presenter, pcService)
+				err := command.Execute([]string{"--badflag"})
+				Expect(err).To(MatchError("could not parse pending-changes flags: flag provided but not defined: -badflag"))
+			})
+		})
 
+		Context("when fetching the pending changes fails", func() {
+			It("returns an error", func() {
 				pcService.ListReturns(api.PendingChangesOutput{}, errors.New("beep boop"))
 
 				err := command.Execute([]string{})
@@ -70,12 +204,65 @@ var _ = Describe("PendingChanges", func() {
 		})
 	})
 
+	Context("when the staged products cannot be listed", func() {
+		It("matches the names against the deployed products instead", func() {
+			stagedProductsService.StagedProductsReturns(api.StagedProductsOutput{}, errors.New("beep boop"))
+
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			pendingChanges := presenter.PresentPendingChangesArgsForCall(0)
+			Expect(pendingChanges).To(HaveLen(4))
+			Expect(pendingChanges[0].GUID).To(Equal("some-product-guid"))
+			Expect(pendingChanges[0].Name).To(Equal("some-product"))
+			Expect(pendingChanges[0].Version).To(Equal("1.1.0"))
+			Expect(pendingChanges[2].GUID).To(Equal("some-deleted-product-abc123"))
+			Expect(pendingChanges[2].Name).To(Equal("some-deleted-product"))
+			Expect(pendingChanges[2].Version).To(Equal("3.0.0"))
+		})
+	})
+
+	Context("when the diagnostic report is unavailable", func() {
+		It("lists the pending changes without versions", func() {
+			diagnosticService.ReportReturns(api.DiagnosticReport{}, api.DiagnosticReportUnavailable{})
+
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			pendingChanges := presenter.PresentPendingChangesArgsForCall(0)
+			Expect(pendingChanges).To(HaveLen(4))
+			for _, change := range pendingChanges {
+				Expect(change.Version).To(BeEmpty())
+			}
+			Expect(pendingChanges[0].Name).To(Equal("some-product"))
+			Expect(pendingChanges[2].Name).To(Equal("some-deleted-product-abc123"))
+		})
+	})
+
+	Context("when neither the staged products nor the diagnostic report can be retrieved", func() {
+		It("lists the pending changes by GUID", func() {
+			stagedProductsService.StagedProductsReturns(api.StagedProductsOutput{}, errors.New("beep boop"))
+			diagnosticService.ReportReturns(api.DiagnosticReport{}, errors.New("beep boop"))
+
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			pendingChanges := presenter.PresentPendingChangesArgsForCall(0)
+			Expect(pendingChanges).To(HaveLen(4))
+			for _, change := range pendingChanges {
+				Expect(change.Name).To(Equal(change.GUID))
+				Expect(change.Version).To(BeEmpty())
+			}
+		})
+	})
+
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewPendingChanges(nil, nil)
+			command := commands.NewPendingChanges(nil, nil, nil, nil)
 			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
-				Description:      "This authenticated command lists all pending changes.",
+				Description:      "This authenticated command lists all pending changes, with the version of each product and whether its configuration is complete. With --check it fails when the foundation is not converged.",
 				ShortDescription: "lists pending changes",
+				Flags:            command.Options,
 			}))
 		})
 	})
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/models"
+	"github.com/pivotal-cf/om/presenters"
+)
+
+type Products struct {
+	presenter                presenters.Presenter
+	availableProductsService availableProductsService
+	stagedProductsService    stagedProductsLister
+	diagnosticService        diagnosticService
+}
+
+//go:generate counterfeiter -o ./fakes/staged_products_lister.go --fake-name StagedProductsLister . stagedProductsLister
+type stagedProductsLister interface {
+	StagedProducts() (api.StagedProductsOutput, error)
+}
+
+func NewProducts(presenter presenters.Presenter, availableProductsService availableProductsService, stagedProductsService stagedProductsLister, diagnosticService diagnosticService) Products {
+	return Products{
+		presenter:                presenter,
+		availableProductsService: availableProductsService,
+		stagedProductsService:    stagedProductsService,
+		diagnosticService:        diagnosticService,
+	}
+}
+
+func (p Products) Execute(args []string) error {
+	availableProducts, err := p.availableProductsService.List()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve available products: %s", err)
+	}
+
+	stagedProducts, err := p.stagedProductsService.StagedProducts()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve staged products: %s", err)
+	}
+
+	diagnosticReport, err := p.diagnosticService.Report()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve diagnostic report: %s", err)
+	}
+
+	inventory := map[string]*models.ProductInventory{}
+	product := func(name string) *models.ProductInventory {
+		if _, ok := inventory[name]; !ok {
+			inventory[name] = &models.ProductInventory{Name: name, AvailableVersions: []string{}}
+		}
+		return inventory[name]
+	}
+
+	for _, available := range availableProducts.ProductsList {
+		entry := product(available.Name)
+		entry.AvailableVersions = append(entry.AvailableVersions, available.Version)
+	}
+
+	for _, staged := range stagedProducts.Products {
+		product(staged.Type).GUID = staged.GUID
+	}
+
+	for _, staged := range diagnosticReport.StagedProducts {
+		entry := product(staged.Name)
+		entry.StagedVersion = staged.Version
+		entry.Stemcell = staged.Stemcell
+	}
+
+	for _, deployed := range diagnosticReport.DeployedProducts {
+		entry := product(deployed.Name)
+		entry.DeployedVersion = deployed.Version
+		if entry.Stemcell == "" {
+			entry.Stemcell = deployed.Stemcell
+		}
+	}
+
+	var names []string
+	for name := range inventory {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var products []models.ProductInventory
+	for _, name := range names {
+		entry := inventory[name]
+
+		sort.SliceStable(entry.AvailableVersions, func(i, j int) bool {
+			return compareProductVersions(entry.AvailableVersions[i], entry.AvailableVersions[j]) < 0
+		})
+
+		entry.PendingUpgrade = pendingUpgrade(*entry)
+
+		products = append(products, *entry)
+	}
+
+	p.presenter.PresentProducts(products)
+
+	return nil
+}
+
+func pendingUpgrade(product models.ProductInventory) bool {
+	if product.StagedVersion != "" && product.DeployedVersion != "" && product.StagedVersion != product.DeployedVersion {
+		return true
+	}
+
+	current := product.StagedVersion
+	if current == "" {
+		current = product.DeployedVersion
+	}
+
+	if current == "" || len(product.AvailableVersions) == 0 {
+		return false
+	}
+
+	latest := product.AvailableVersions[len(product.AvailableVersions)-1]
+	return compareProductVersions(latest, current) > 0
+}
+
+func (p Products) Usage() commands.Usage {
+	return commands.Usage{
+		Description:      "This authenticated command lists every product known to Ops Manager with its available, staged and deployed versions, and flags products with pending upgrades.",
+		ShortDescription: "lists available, staged and deployed products",
+	}
+}
@@ -0,0 +1,151 @@
+package commands_test
+
+import (
+	"errors"
+
+	jhandacommands "github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Products", func() {
+	var (
+		presenter                *fakes.Presenter
+		availableProductsService *fakes.AvailableProductsService
+		stagedProductsService    *fakes.StagedProductsLister
+		diagnosticService        *fakes.DiagnosticService
+		command                  commands.Products
+	)
+
+	BeforeEach(func() {
+		presenter = &fakes.Presenter{}
+		availableProductsService = &fakes.AvailableProductsService{}
+		stagedProductsService = &fakes.StagedProductsLister{}
+		diagnosticService = &fakes.DiagnosticService{}
+		command = commands.NewProducts(presenter, availableProductsService, stagedProductsService, diagnosticService)
+
+		availableProductsService.ListReturns(api.AvailableProductsOutput{
+			ProductsList: []api.ProductInfo{
+				{Name: "cf", Version: "1.12.0"},
+				{Name: "cf", Version: "1.11.0"},
+				{Name: "p-mysql", Version: "2.1.0"},
+				{Name: "p-mysql", Version: "2.0.0"},
+				{Name: "p-redis", Version: "1.0.0"},
+				{Name: "p-rabbitmq", Version: "3.0.0"},
+			},
+		}, nil)
+
+		stagedProductsService.StagedProductsReturns(api.StagedProductsOutput{
+			Products: []api.StagedProduct{
+				{Type: "p-bosh", GUID: "p-bosh-guid"},
+				{Type: "cf", GUID: "cf-guid"},
+				{Type: "p-mysql", GUID: "p-mysql-guid"},
+				{Type: "p-redis", GUID: "p-redis-guid"},
+			},
+		}, nil)
+
+		diagnosticService.ReportReturns(api.DiagnosticReport{
+			StagedProducts: []api.DiagnosticProduct{
+				{Name: "p-bosh", Version: "2.0-build.1"},
+				{Name: "cf", Version: "1.12.0", Stemcell: "bosh-stemcell-3541.10"},
+				{Name: "p-mysql", Version: "2.0.0", Stemcell: "bosh-stemcell-3541.10"},
+				{Name: "p-redis", Version: "1.0.0"},
+			},
+			DeployedProducts: []api.DiagnosticProduct{
+				{Name: "p-bosh", Version: "2.0-build.1"},
+				{Name: "cf", Version: "1.11.0", Stemcell: "bosh-stemcell-3468.21"},
+				{Name: "p-mysql", Version: "2.0.0", Stemcell: "bosh-stemcell-3541.10"},
+				{Name: "p-redis", Version: "1.0.0", Stemcell: "bosh-stemcell-3541.5"},
+			},
+		}, nil)
+	})
+
+	It("joins the available, staged and deployed products", func() {
+		err := command.Execute([]string{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(presenter.PresentProductsCallCount()).To(Equal(1))
+		Expect(presenter.PresentProductsArgsForCall(0)).To(Equal([]models.ProductInventory{
+			{
+				Name:              "cf",
+				GUID:              "cf-guid",
+				AvailableVersions: []string{"1.11.0", "1.12.0"},
+				StagedVersion:     "1.12.0",
+				DeployedVersion:   "1.11.0",
+				Stemcell:          "bosh-stemcell-3541.10",
+				PendingUpgrade:    true,
+			},
+			{
+				Name:              "p-bosh",
+				GUID:              "p-bosh-guid",
+				AvailableVersions: []string{},
+				StagedVersion:     "2.0-build.1",
+				DeployedVersion:   "2.0-build.1",
+			},
+			{
+				Name:              "p-mysql",
+				GUID:              "p-mysql-guid",
+				AvailableVersions: []string{"2.0.0", "2.1.0"},
+				StagedVersion:     "2.0.0",
+				DeployedVersion:   "2.0.0",
+				Stemcell:          "bosh-stemcell-3541.10",
+				PendingUpgrade:    true,
+			},
+			{
+				Name:              "p-rabbitmq",
+				AvailableVersions: []string{"3.0.0"},
+			},
+			{
+				Name:              "p-redis",
+				GUID:              "p-redis-guid",
+				AvailableVersions: []string{"1.0.0"},
+				StagedVersion:     "1.0.0",
+				DeployedVersion:   "1.0.0",
+				Stemcell:          "bosh-stemcell-3541.5",
+			},
+		}))
+	})
+
+	Context("failure cases", func() {
+		Context("when the available products cannot be fetched", func() {
+			It("returns an error", func() {
+				availableProductsService.ListReturns(api.AvailableProductsOutput{}, errors.New("some error"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to retrieve available products: some error"))
+			})
+		})
+
+		Context("when the staged products cannot be fetched", func() {
+			It("returns an error", func() {
+				stagedProductsService.StagedProductsReturns(api.StagedProductsOutput{}, errors.New("some error"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to retrieve staged products: some error"))
+			})
+		})
+
+		Context("when the diagnostic report cannot be fetched", func() {
+			It("returns an error", func() {
+				diagnosticService.ReportReturns(api.DiagnosticReport{}, errors.New("some error"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to retrieve diagnostic report: some error"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
+				Description:      "This authenticated command lists every product known to Ops Manager with its available, staged and deployed versions, and flags products with pending upgrades.",
+				ShortDescription: "lists available, staged and deployed products",
+			}))
+		})
+	})
+})
@@ -0,0 +1,382 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pivotal-cf/om/api"
+	tile "github.com/pivotal-cf/om/extractor"
+)
+
+var stringPropertyTypes = map[string]bool{
+	"string":                           true,
+	"text":                             true,
+	"email":                            true,
+	"domain":                           true,
+	"wildcard_domain":                  true,
+	"network_address":                  true,
+	"network_address_list":             true,
+	"ip_address":                       true,
+	"ip_ranges":                        true,
+	"ldap_url":                         true,
+	"http_url":                         true,
+	"uuid":                             true,
+	"ca_certificate":                   true,
+	"string_list":                      true,
+	"vm_type_dropdown":                 true,
+	"disk_type_dropdown":               true,
+	"service_network_az_single_select": true,
+}
+
+type propertySchema struct {
+	Type         string
+	Configurable bool
+	Required     bool
+	Value        interface{}
+	Options      []string
+	Selector     string
+	Option       string
+	Fields       productSchema
+}
+
+type productSchema map[string]propertySchema
+
+func schemaFromMetadata(metadata tile.Metadata) productSchema {
+	schema := productSchema{}
+	for _, property := range metadata.Properties() {
+		schema[property.Reference] = propertySchema{
+			Type:         property.Blueprint.Type,
+			Configurable: property.Blueprint.Configurable,
+			Required:     property.Blueprint.Required(),
+			Value:        property.Blueprint.Default,
+			Options:      property.Blueprint.SelectValues(),
+			Selector:     property.Selector,
+			Option:       property.Option,
+			Fields:       fieldsFromBlueprints(property.Blueprint.PropertyBlueprints),
+		}
+	}
+
+	return schema
+}
+
+func fieldsFromBlueprints(blueprints []tile.PropertyBlueprint) productSchema {
+	if len(blueprints) == 0 {
+		return nil
+	}
+
+	fields := productSchema{}
+	for _, blueprint := range blueprints {
+		fields[blueprint.Name] = propertySchema{
+			Type:         blueprint.Type,
+			Configurable: blueprint.Configurable,
+			Required:     blueprint.Required(),
+			Value:        blueprint.Default,
+			Options:      blueprint.SelectValues(),
+			Fields:       fieldsFromBlueprints(blueprint.PropertyBlueprints),
+		}
+	}
+
+	return fields
+}
+
+func schemaFromProperties(properties map[string]api.ResponseProperty) productSchema {
+	schema := productSchema{}
+	for reference, property := range properties {
+		schema[reference] = propertySchemaFromResponse(property)
+	}
+
+	for reference, property := range schema {
+		for selector, selectorProperty := range schema {
+			if selectorProperty.Type != "selector" || !strings.HasPrefix(reference, selector+".") {
+				continue
+			}
+
+			parts := strings.SplitN(strings.TrimPrefix(reference, selector+"."), ".", 2)
+			if len(parts) == 2 && len(selector) > len(property.Selector) {
+				property.Selector = selector
+				property.Option = parts[0]
+			}
+		}
+		schema[reference] = property
+	}
+
+	return schema
+}
+
+func propertySchemaFromResponse(property api.ResponseProperty) propertySchema {
+	schema := propertySchema{
+		Type:         property.Type,
+		Configurable: property.Configurable,
+		Value:        property.Value,
+	}
+
+	if property.SelectedOption != "" {
+		schema.Value = property.SelectedOption
+	}
+
+	for _, option := range property.Options {
+		schema.Options = append(schema.Options, fmt.Sprintf("%v", option.Value))
+	}
+
+	if items, ok := property.Value.([]interface{}); ok && property.Type == "collection" && len(items) > 0 {
+		if item, ok := items[0].(map[string]interface{}); ok {
+			schema.Fields = productSchema{}
+			for name, field := range item {
+				contents, err := json.Marshal(field)
+				if err != nil {
+					continue
+				}
+
+				var fieldProperty api.ResponseProperty
+				if json.Unmarshal(contents, &fieldProperty) != nil {
+					continue
+				}
+
+				schema.Fields[name] = propertySchemaFromResponse(fieldProperty)
+			}
+		}
+	}
+
+	return schema
+}
+
+func (s productSchema) validate(properties map[string]interface{}) []string {
+	var errs []string
+
+	properties = jsonCompatible(properties).(map[string]interface{})
+
+	for _, reference := range sortedKeys(properties) {
+		property, ok := s[reference]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("%s: unknown property", reference))
+			continue
+		}
+
+		if !property.Configurable {
+			errs = append(errs, fmt.Sprintf("%s: property is not configurable", reference))
+			continue
+		}
+
+		entry, ok := properties[reference].(map[string]interface{})
+		if !ok {
+			errs = append(errs, fmt.Sprintf("%s: expected a map with a value key, got %s", reference, describeValue(properties[reference])))
+			continue
+		}
+
+		value, ok := entry["value"]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("%s: expected a map with a value key", reference))
+			continue
+		}
+
+		errs = append(errs, property.check(reference, value)...)
+	}
+
+	return errs
+}
+
+// missing returns an error for every required property that is not in
+// properties, unless it belongs to an option of a selector that is not
+// selected.
+func (s productSchema) missing(properties map[string]interface{}) []string {
+	var errs []string
+
+	properties = jsonCompatible(properties).(map[string]interface{})
+
+	for _, reference := range s.references() {
+		if _, ok := properties[reference]; ok || !s[reference].Required {
+			continue
+		}
+
+		if s.active(reference, properties) {
+			errs = append(errs, fmt.Sprintf("%s: required property is missing", reference))
+		}
+	}
+
+	return errs
+}
+
+func (s productSchema) active(reference string, properties map[string]interface{}) bool {
+	property := s[reference]
+	if property.Selector == "" {
+		return true
+	}
+
+	selected := s[property.Selector].Value
+	if entry, ok := properties[property.Selector].(map[string]interface{}); ok {
+		if value, ok := entry["value"]; ok {
+			selected = value
+		}
+	}
+
+	if selected == nil || fmt.Sprintf("%v", selected) != property.Option {
+		return false
+	}
+
+	return s.active(property.Selector, properties)
+}
+
+func (p propertySchema) check(name string, value interface{}) []string {
+	if value == nil {
+		return nil
+	}
+
+	invalid := func(expected string) []string {
+		return []string{fmt.Sprintf("%s: expected %s, got %s", name, expected, describeValue(value))}
+	}
+
+	if fields, ok := credentialFields[p.Type]; ok {
+		credential, ok := value.(map[string]interface{})
+		if !ok {
+			return invalid(fmt.Sprintf("a map with keys %v", fields))
+		}
+
+		var errs []string
+		for _, field := range fields {
+			if _, ok := credential[field].(string); !ok {
+				errs = append(errs, fmt.Sprintf("%s: expected %s to be a string, got %s", name, field, describeValue(credential[field])))
+			}
+		}
+		return errs
+	}
+
+	switch {
+	case p.Type == "integer" || p.Type == "port":
+		if !isInteger(value) {
+			return invalid("an integer")
+		}
+	case p.Type == "boolean":
+		if _, ok := value.(bool); !ok {
+			return invalid("a boolean")
+		}
+	case p.Type == "selector" || p.Type == "dropdown_select":
+		if len(p.Options) > 0 && !contains(p.Options, fmt.Sprintf("%v", value)) {
+			return invalid(fmt.Sprintf("one of %v", p.Options))
+		}
+	case p.Type == "multi_select_options":
+		values, ok := value.([]interface{})
+		if !ok {
+			return invalid(fmt.Sprintf("a list of %v", p.Options))
+		}
+
+		for _, v := range values {
+			if len(p.Options) > 0 && !contains(p.Options, fmt.Sprintf("%v", v)) {
+				return invalid(fmt.Sprintf("a list of %v", p.Options))
+			}
+		}
+	case p.Type == "collection":
+		return p.checkCollection(name, value)
+	case stringPropertyTypes[p.Type]:
+		if _, ok := value.(string); !ok {
+			return invalid("a string")
+		}
+	}
+
+	return nil
+}
+
+func (p propertySchema) checkCollection(name string, value interface{}) []string {
+	items, ok := value.([]interface{})
+	if !ok {
+		return []string{fmt.Sprintf("%s: expected a list, got %s", name, describeValue(value))}
+	}
+
+	var errs []string
+	for i, item := range items {
+		itemName := fmt.Sprintf("%s[%d]", name, i)
+
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			errs = append(errs, fmt.Sprintf("%s: expected a map, got %s", itemName, describeValue(item)))
+			continue
+		}
+
+		if p.Fields == nil {
+			continue
+		}
+
+		for _, field := range sortedKeys(fields) {
+			if field == "guid" {
+				continue
+			}
+
+			schema, ok := p.Fields[field]
+			if !ok {
+				errs = append(errs, fmt.Sprintf("%s.%s: unknown property", itemName, field))
+				continue
+			}
+
+			errs = append(errs, schema.check(fmt.Sprintf("%s.%s", itemName, field), fields[field])...)
+		}
+
+		for _, field := range p.Fields.references() {
+			if _, ok := fields[field]; !ok && p.Fields[field].Required {
+				errs = append(errs, fmt.Sprintf("%s.%s: required property is missing", itemName, field))
+			}
+		}
+	}
+
+	return errs
+}
+
+func isInteger(value interface{}) bool {
+	switch v := value.(type) {
+	case int, int64:
+		return true
+	case float64:
+		return v == float64(int64(v))
+	case string:
+		_, err := strconv.Atoi(v)
+		return err == nil
+	default:
+		return false
+	}
+}
+
+func describeValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "nothing"
+	case string:
+		return fmt.Sprintf("%q", v)
+	case map[string]interface{}:
+		return "a map"
+	case []interface{}:
+		return "a list"
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s productSchema) references() []string {
+	var references []string
+	for reference := range s {
+		references = append(references, reference)
+	}
+
+	sort.Strings(references)
+	return references
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"github.com/pivotal-cf/om/api"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("propertySchema", func() {
+	DescribeTable("check",
+		func(schema propertySchema, value interface{}, errs []string) {
+			Expect(schema.check("some-property", value)).To(Equal(errs))
+		},
+		Entry("nothing is always valid", propertySchema{Type: "integer"}, nil, nil),
+		Entry("integer", propertySchema{Type: "integer"}, float64(3), nil),
+		Entry("integer as a string", propertySchema{Type: "port"}, "8443", nil),
+		Entry("integer with a fraction", propertySchema{Type: "integer"}, 3.5,
+			[]string{"some-property: expected an integer, got 3.5"}),
+		Entry("boolean", propertySchema{Type: "boolean"}, true, nil),
+		Entry("boolean as a string", propertySchema{Type: "boolean"}, "true",
+			[]string{`some-property: expected a boolean, got "true"`}),
+		Entry("string", propertySchema{Type: "ip_ranges"}, "10.0.0.1-10.0.0.9", nil),
+		Entry("string given a number", propertySchema{Type: "string"}, float64(1),
+			[]string{"some-property: expected a string, got 1"}),
+		Entry("selector option", propertySchema{Type: "selector", Options: []string{"internal", "external"}}, "internal", nil),
+		Entry("unknown selector option", propertySchema{Type: "selector", Options: []string{"internal", "external"}}, "other",
+			[]string{`some-property: expected one of [internal external], got "other"`}),
+		Entry("selector without known options", propertySchema{Type: "dropdown_select"}, "anything", nil),
+		Entry("multi select options", propertySchema{Type: "multi_select_options", Options: []string{"a", "b"}}, []interface{}{"a", "b"}, nil),
+		Entry("unknown multi select option", propertySchema{Type: "multi_select_options", Options: []string{"a", "b"}}, []interface{}{"c"},
+			[]string{"some-property: expected a list of [a b], got a list"}),
+		Entry("credential", propertySchema{Type: "simple_credentials"}, map[string]interface{}{"identity": "admin", "password": "secret"}, nil),
+		Entry("credential without a field", propertySchema{Type: "rsa_cert_credentials"}, map[string]interface{}{"cert_pem": "some-cert"},
+			[]string{"some-property: expected private_key_pem to be a string, got nothing"}),
+		Entry("credential given a string", propertySchema{Type: "secret"}, "some-secret",
+			[]string{`some-property: expected a map with keys [secret], got "some-secret"`}),
+		Entry("unknown types are not checked", propertySchema{Type: "some-new-type"}, float64(1), nil),
+	)
+
+	DescribeTable("check of a collection",
+		func(value interface{}, errs []string) {
+			schema := propertySchema{
+				Type: "collection",
+				Fields: productSchema{
+					"name": {Type: "string", Required: true},
+					"port": {Type: "port"},
+				},
+			}
+
+			Expect(schema.check("some-collection", value)).To(Equal(errs))
+		},
+		Entry("valid items", []interface{}{
+			map[string]interface{}{"guid": "some-guid", "name": "some-name", "port": float64(80)},
+		}, nil),
+		Entry("not a list", "some-value",
+			[]string{`some-collection: expected a list, got "some-value"`}),
+		Entry("an item that is not a map", []interface{}{"some-value"},
+			[]string{`some-collection[0]: expected a map, got "some-value"`}),
+		Entry("invalid, unknown and missing fields", []interface{}{
+			map[string]interface{}{"name": "some-name"},
+			map[string]interface{}{"port": "http", "other": true},
+		}, []string{
+			"some-collection[1].other: unknown property",
+			`some-collection[1].port: expected an integer, got "http"`,
+			"some-collection[1].name: required property is missing",
+		}),
+	)
+})
+
+var _ = Describe("productSchema", func() {
+	schema := productSchema{
+		".properties.name":     {Type: "string", Configurable: true, Required: true},
+		".properties.computed": {Type: "string"},
+		".properties.auth":     {Type: "selector", Configurable: true, Value: "internal", Options: []string{"internal", "ldap"}},
+		".properties.auth.ldap.url": {
+			Type: "ldap_url", Configurable: true, Required: true, Selector: ".properties.auth", Option: "ldap",
+		},
+	}
+
+	DescribeTable("validate",
+		func(properties map[string]interface{}, errs []string) {
+			Expect(schema.validate(properties)).To(Equal(errs))
+		},
+		Entry("valid properties", map[string]interface{}{
+			".properties.name": map[string]interface{}{"value": "some-name"},
+		}, nil),
+		Entry("every problem, sorted by property", map[string]interface{}{
+			".properties.unknown":  map[string]interface{}{"value": "some-value"},
+			".properties.computed": map[string]interface{}{"value": "some-value"},
+			".properties.name":     "some-name",
+			".properties.auth":     map[string]interface{}{"value": "saml"},
+		}, []string{
+			`.properties.auth: expected one of [internal ldap], got "saml"`,
+			".properties.computed: property is not configurable",
+			`.properties.name: expected a map with a value key, got "some-name"`,
+			".properties.unknown: unknown property",
+		}),
+		Entry("a map without a value key", map[string]interface{}{
+			".properties.name": map[interface{}]interface{}{"selected_option": "some-name"},
+		}, []string{
+			".properties.name: expected a map with a value key",
+		}),
+	)
+
+	DescribeTable("missing",
+		func(properties map[string]interface{}, errs []string) {
+			Expect(schema.missing(properties)).To(Equal(errs))
+		},
+		Entry("required properties that are given", map[string]interface{}{
+			".properties.name": map[string]interface{}{"value": "some-name"},
+		}, nil),
+		Entry("a required property that is not given", map[string]interface{}{}, []string{
+			".properties.name: required property is missing",
+		}),
+		Entry("a required property of the selected option", map[string]interface{}{
+			".properties.name": map[string]interface{}{"value": "some-name"},
+			".properties.auth": map[string]interface{}{"value": "ldap"},
+		}, []string{
+			".properties.auth.ldap.url: required property is missing",
+		}),
+	)
+})
+
+var _ = Describe("schemaFromProperties", func() {
+	It("assigns the properties of a selector option to the selector", func() {
+		schema := schemaFromProperties(map[string]api.ResponseProperty{
+			".properties.auth": {
+				Type:           "selector",
+				Configurable:   true,
+				SelectedOption: "internal",
+				Options: []api.ResponsePropertyOption{
+					{Label: "Internal", Value: "internal"},
+					{Label: "LDAP", Value: "ldap"},
+				},
+			},
+			".properties.auth.ldap.url": {Type: "ldap_url", Configurable: true},
+		})
+
+		Expect(schema[".properties.auth"]).To(Equal(propertySchema{
+			Type:         "selector",
+			Configurable: true,
+			Value:        "internal",
+			Options:      []string{"internal", "ldap"},
+		}))
+		Expect(schema[".properties.auth.ldap.url"].Selector).To(Equal(".properties.auth"))
+		Expect(schema[".properties.auth.ldap.url"].Option).To(Equal("ldap"))
+	})
+})
+
+var _ = Describe("describeValue", func() {
+	DescribeTable("describes a value for an error message",
+		func(value interface{}, description string) {
+			Expect(describeValue(value)).To(Equal(description))
+		},
+		Entry("nothing", nil, "nothing"),
+		Entry("a string", "some-value", `"some-value"`),
+		Entry("a map", map[string]interface{}{}, "a map"),
+		Entry("a list", []interface{}{}, "a list"),
+		Entry("a number", float64(2), "2"),
+	)
+})
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/jhanda/flags"
+)
+
+type ReplicateProduct struct {
+	replicator productReplicator
+	logger     logger
+	Options    struct {
+		Product string `short:"p"  long:"product"  description:"path to product"`
+		Name    string `short:"n"  long:"name"  description:"suffix appended to the product name, label and job names"`
+		Output  string `short:"o"  long:"output"  description:"path to write the replicated product to"`
+	}
+}
+
+//go:generate counterfeiter -o ./fakes/product_replicator.go --fake-name ProductReplicator . productReplicator
+type productReplicator interface {
+	ReplicateProduct(productPath, outputPath, suffix string) (string, error)
+}
+
+func NewReplicateProduct(replicator productReplicator, logger logger) ReplicateProduct {
+	return ReplicateProduct{
+		replicator: replicator,
+		logger:     logger,
+	}
+}
+
+func (rp ReplicateProduct) Execute(args []string) error {
+	_, err := flags.Parse(&rp.Options, args)
+	if err != nil {
+		return fmt.Errorf("could not parse replicate-product flags: %s", err)
+	}
+
+	if rp.Options.Product == "" {
+		return errors.New("error: product is missing. Please see usage for more information.")
+	}
+
+	if rp.Options.Name == "" {
+		return errors.New("error: name is missing. Please see usage for more information.")
+	}
+
+	if rp.Options.Output == "" {
+		return errors.New("error: output is missing. Please see usage for more information.")
+	}
+
+	rp.logger.Printf("replicating %s with suffix %s", rp.Options.Product, rp.Options.Name)
+
+	name, err := rp.replicator.ReplicateProduct(rp.Options.Product, rp.Options.Output, rp.Options.Name)
+	if err != nil {
+		return fmt.Errorf("failed to replicate product: %s", err)
+	}
+
+	rp.logger.Printf("wrote %s to %s", name, rp.Options.Output)
+
+	return nil
+}
+
+func (rp ReplicateProduct) Usage() commands.Usage {
+	return commands.Usage{
+		Description:      "This command writes a copy of a product file under a new name so that it can be uploaded and deployed alongside the original. The product name, label and job names are given the suffix, and the releases are copied unchanged.",
+		ShortDescription: "copies a product file under a new name",
+		Flags:            rp.Options,
+	}
+}
@@ -0,0 +1,106 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+
+	jhandacommands "github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReplicateProduct", func() {
+	var (
+		replicator *fakes.ProductReplicator
+		logger     *fakes.Logger
+	)
+
+	BeforeEach(func() {
+		replicator = &fakes.ProductReplicator{}
+		replicator.ReplicateProductReturns("some-product-blue", nil)
+		logger = &fakes.Logger{}
+	})
+
+	Describe("Execute", func() {
+		It("replicates the product", func() {
+			command := commands.NewReplicateProduct(replicator, logger)
+
+			err := command.Execute([]string{
+				"--product", "/path/to/some-product.pivotal",
+				"--name", "blue",
+				"--output", "/path/to/some-product-blue.pivotal",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(replicator.ReplicateProductCallCount()).To(Equal(1))
+			productPath, outputPath, suffix := replicator.ReplicateProductArgsForCall(0)
+			Expect(productPath).To(Equal("/path/to/some-product.pivotal"))
+			Expect(outputPath).To(Equal("/path/to/some-product-blue.pivotal"))
+			Expect(suffix).To(Equal("blue"))
+
+			format, content := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("replicating /path/to/some-product.pivotal with suffix blue"))
+
+			format, content = logger.PrintfArgsForCall(1)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("wrote some-product-blue to /path/to/some-product-blue.pivotal"))
+		})
+
+		Context("failure cases", func() {
+			Context("when an unknown flag is provided", func() {
+				It("returns an error", func() {
+					command := commands.NewReplicateProduct(replicator, logger)
+					err := command.Execute([]string{"--badflag"})
+					Expect(err).To(MatchError("could not parse replicate-product flags: flag provided but not defined: -badflag"))
+				})
+			})
+
+			Context("when the product flag is not provided", func() {
+				It("returns an error", func() {
+					command := commands.NewReplicateProduct(replicator, logger)
+					err := command.Execute([]string{"--name", "blue", "--output", "out.pivotal"})
+					Expect(err).To(MatchError("error: product is missing. Please see usage for more information."))
+				})
+			})
+
+			Context("when the name flag is not provided", func() {
+				It("returns an error", func() {
+					command := commands.NewReplicateProduct(replicator, logger)
+					err := command.Execute([]string{"--product", "in.pivotal", "--output", "out.pivotal"})
+					Expect(err).To(MatchError("error: name is missing. Please see usage for more information."))
+				})
+			})
+
+			Context("when the output flag is not provided", func() {
+				It("returns an error", func() {
+					command := commands.NewReplicateProduct(replicator, logger)
+					err := command.Execute([]string{"--product", "in.pivotal", "--name", "blue"})
+					Expect(err).To(MatchError("error: output is missing. Please see usage for more information."))
+				})
+			})
+
+			Context("when the product cannot be replicated", func() {
+				It("returns an error", func() {
+					replicator.ReplicateProductReturns("", errors.New("some error"))
+
+					command := commands.NewReplicateProduct(replicator, logger)
+					err := command.Execute([]string{"--product", "in.pivotal", "--name", "blue", "--output", "out.pivotal"})
+					Expect(err).To(MatchError("failed to replicate product: some error"))
+				})
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			command := commands.NewReplicateProduct(nil, nil)
+			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
+				Description:      "This command writes a copy of a product file under a new name so that it can be uploaded and deployed alongside the original. The product name, label and job names are given the suffix, and the releases are copied unchanged.",
+				ShortDescription: "copies a product file under a new name",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
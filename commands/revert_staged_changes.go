@@ -9,8 +9,17 @@ import (
 )
 
 type RevertStagedChanges struct {
-	service dashboardService
-	logger  logger
+	service                 stagedChangesReverter
+	dashboardService        dashboardService
+	stagedProductsService   stagedProductsLister
+	deployedProductsService deployedProductsLister
+	logger                  logger
+}
+
+//go:generate counterfeiter -o ./fakes/staged_changes_reverter.go --fake-name StagedChangesReverter . stagedChangesReverter
+type stagedChangesReverter interface {
+	List() (api.PendingChangesOutput, error)
+	Revert() (bool, error)
 }
 
 //go:generate counterfeiter -o ./fakes/dashboard_service.go --fake-name DashboardService . dashboardService
@@ -20,20 +29,107 @@ type dashboardService interface {
 	PostInstallForm(api.PostFormInput) error
 }
 
-func NewRevertStagedChanges(s dashboardService, l logger) RevertStagedChanges {
-	return RevertStagedChanges{service: s, logger: l}
+func NewRevertStagedChanges(s stagedChangesReverter, ds dashboardService, sps stagedProductsLister, dps deployedProductsLister, l logger) RevertStagedChanges {
+	return RevertStagedChanges{
+		service:                 s,
+		dashboardService:        ds,
+		stagedProductsService:   sps,
+		deployedProductsService: dps,
+		logger:                  l,
+	}
 }
 
 func (c RevertStagedChanges) Execute(args []string) error {
-	form, err := c.service.GetRevertForm()
+	output, err := c.service.List()
 	if err != nil {
-		return fmt.Errorf("could not fetch form: %s", err)
+		c.logger.Printf("warning: failed to retrieve pending changes, the discarded changes will not be listed: %s", err)
 	}
 
-	if form == (api.Form{}) {
+	// products that were added since the last deploy are gone after the
+	// revert, so their names are looked up beforehand
+	var names map[string]string
+	if len(output.ChangeList) > 0 {
+		names = c.productNames()
+	}
+
+	c.logger.Printf("reverting staged changes on the targeted Ops Manager")
+
+	reverted, err := c.service.Revert()
+	switch err.(type) {
+	case nil:
+	case api.RevertUnsupported:
+		c.logger.Printf("the Ops Manager cannot revert staged changes through the api, using the installation dashboard instead")
+
+		reverted, err = c.revertWithForm()
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("failed to revert staged changes: %s", err)
+	}
+
+	if !reverted {
+		c.logger.Printf("there were no staged changes to revert")
 		return nil
 	}
 
+	var discarded []api.ProductChange
+	for _, change := range output.ChangeList {
+		if change.Action != "unchanged" {
+			discarded = append(discarded, change)
+		}
+	}
+
+	if len(discarded) > 0 {
+		c.logger.Printf("discarded pending changes:")
+		for _, change := range discarded {
+			c.logger.Printf("  %s: %s", productNameFromGUID(change.Product, names, nil), change.Action)
+		}
+	}
+
+	c.logger.Printf("done")
+
+	return nil
+}
+
+// productNames maps the guids of the staged and deployed products to their
+// names. The names only make the summary easier to read, so a product whose
+// name cannot be retrieved is listed by its guid.
+func (c RevertStagedChanges) productNames() map[string]string {
+	names := map[string]string{}
+
+	deployedProducts, err := c.deployedProductsService.DeployedProducts()
+	if err != nil {
+		c.logger.Printf("warning: failed to retrieve deployed products: %s", err)
+	}
+	for _, product := range deployedProducts {
+		names[product.GUID] = product.Type
+	}
+
+	stagedProducts, err := c.stagedProductsService.StagedProducts()
+	if err != nil {
+		c.logger.Printf("warning: failed to retrieve staged products: %s", err)
+	}
+	for _, product := range stagedProducts.Products {
+		names[product.GUID] = product.Type
+	}
+
+	return names
+}
+
+// revertWithForm reverts the staged changes by submitting the revert form of
+// the installation dashboard, which is the only way to do so on Ops Managers
+// that predate the api endpoint.
+func (c RevertStagedChanges) revertWithForm() (bool, error) {
+	form, err := c.dashboardService.GetRevertForm()
+	if err != nil {
+		return false, fmt.Errorf("could not fetch form: %s", err)
+	}
+
+	if form == (api.Form{}) {
+		return false, nil
+	}
+
 	var formConfig CommonConfiguration
 	formConfig.AuthenticityToken = form.AuthenticityToken
 	formConfig.Method = "delete"
@@ -41,22 +137,20 @@ func (c RevertStagedChanges) Execute(args []string) error {
 
 	formValues, err := query.Values(formConfig)
 	if err != nil {
-		return err // cannot be tested
+		return false, err // cannot be tested
 	}
 
-	c.logger.Printf("reverting staged changes on the targeted Ops Manager")
-	err = c.service.PostInstallForm(api.PostFormInput{Form: form, EncodedPayload: formValues.Encode()})
+	err = c.dashboardService.PostInstallForm(api.PostFormInput{Form: form, EncodedPayload: formValues.Encode()})
 	if err != nil {
-		return fmt.Errorf("failed to revert staged changes: %s", err)
+		return false, fmt.Errorf("failed to revert staged changes: %s", err)
 	}
-	c.logger.Printf("done")
 
-	return nil
+	return true, nil
 }
 
 func (c RevertStagedChanges) Usage() commands.Usage {
 	return commands.Usage{
-		Description:      "reverts staged changes on the installation dashboard page in the target Ops Manager",
+		Description:      "This authenticated command reverts all staged changes on the target Ops Manager and lists the pending changes that were discarded. Ops Managers that cannot revert staged changes through the api are reverted through the installation dashboard.",
 		ShortDescription: "reverts staged changes on the Ops Manager targeted",
 	}
 }
@@ -15,61 +15,184 @@ import (
 
 var _ = Describe("RevertStagedChanges", func() {
 	var (
-		service *fakes.DashboardService
-		logger  *fakes.Logger
+		service                 *fakes.StagedChangesReverter
+		dashboardService        *fakes.DashboardService
+		stagedProductsService   *fakes.StagedProductsLister
+		deployedProductsService *fakes.DeployedProductsLister
+		logger                  *fakes.Logger
 	)
 
+	loggedLines := func() []string {
+		var lines []string
+		for i := 0; i < logger.PrintfCallCount(); i++ {
+			format, content := logger.PrintfArgsForCall(i)
+			lines = append(lines, fmt.Sprintf(format, content...))
+		}
+		return lines
+	}
+
 	BeforeEach(func() {
-		service = &fakes.DashboardService{}
+		service = &fakes.StagedChangesReverter{}
+		dashboardService = &fakes.DashboardService{}
+		stagedProductsService = &fakes.StagedProductsLister{}
+		deployedProductsService = &fakes.DeployedProductsLister{}
 		logger = &fakes.Logger{}
+
+		service.ListReturns(api.PendingChangesOutput{
+			ChangeList: []api.ProductChange{
+				{Product: "p-bosh-guid", Action: "unchanged"},
+				{Product: "some-product-guid", Action: "update"},
+				{Product: "some-other-product-guid", Action: "install"},
+			},
+		}, nil)
+		service.RevertReturns(true, nil)
+		stagedProductsService.StagedProductsReturns(api.StagedProductsOutput{
+			Products: []api.StagedProduct{
+				{GUID: "p-bosh-guid", Type: "p-bosh"},
+				{GUID: "some-product-guid", Type: "some-product"},
+				{GUID: "some-other-product-guid", Type: "some-other-product"},
+			},
+		}, nil)
+		deployedProductsService.DeployedProductsReturns([]api.DeployedProductOutput{
+			{GUID: "p-bosh-guid", Type: "p-bosh"},
+			{GUID: "some-product-guid", Type: "some-product"},
+		}, nil)
 	})
 
 	Describe("Execute", func() {
-		It("reverts staged changes on the targeted OpsMan", func() {
-			command := commands.NewRevertStagedChanges(service, logger)
-
-			service.GetRevertFormReturns(api.Form{
-				Action:            "/installation",
-				AuthenticityToken: "some-auth-token",
-				RailsMethod:       "the-rails",
-			}, nil)
+		It("reverts staged changes through the api and lists the discarded changes", func() {
+			command := commands.NewRevertStagedChanges(service, dashboardService, stagedProductsService, deployedProductsService, logger)
 
 			err := command.Execute([]string{})
-
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(service.PostInstallFormArgsForCall(0)).To(Equal(api.PostFormInput{
-				Form: api.Form{
+			Expect(service.RevertCallCount()).To(Equal(1))
+			Expect(dashboardService.GetRevertFormCallCount()).To(Equal(0))
+
+			Expect(loggedLines()).To(Equal([]string{
+				"reverting staged changes on the targeted Ops Manager",
+				"discarded pending changes:",
+				"  some-product: update",
+				"  some-other-product: install",
+				"done",
+			}))
+
+			Expect(stagedProductsService.StagedProductsCallCount()).To(Equal(1))
+			Expect(deployedProductsService.DeployedProductsCallCount()).To(Equal(1))
+		})
+
+		Context("when the product names can't be fetched", func() {
+			It("lists the discarded changes by guid", func() {
+				stagedProductsService.StagedProductsReturns(api.StagedProductsOutput{}, errors.New("meow meow meow"))
+				deployedProductsService.DeployedProductsReturns(nil, errors.New("purr purr purr"))
+
+				command := commands.NewRevertStagedChanges(service, dashboardService, stagedProductsService, deployedProductsService, logger)
+				err := command.Execute([]string{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(loggedLines()).To(Equal([]string{
+					"warning: failed to retrieve deployed products: purr purr purr",
+					"warning: failed to retrieve staged products: meow meow meow",
+					"reverting staged changes on the targeted Ops Manager",
+					"discarded pending changes:",
+					"  some-product-guid: update",
+					"  some-other-product-guid: install",
+					"done",
+				}))
+			})
+		})
+
+		Context("when the pending changes can't be fetched", func() {
+			It("warns and reverts the staged changes without listing them", func() {
+				service.ListReturns(api.PendingChangesOutput{}, errors.New("meow meow meow"))
+				service.RevertReturns(false, api.RevertUnsupported{})
+				dashboardService.GetRevertFormReturns(api.Form{
 					Action:            "/installation",
 					AuthenticityToken: "some-auth-token",
-					RailsMethod:       "the-rails",
-				},
-				EncodedPayload: "_method=delete&authenticity_token=some-auth-token&commit=Confirm",
-			}))
+				}, nil)
 
-			format, content := logger.PrintfArgsForCall(0)
-			Expect(fmt.Sprintf(format, content...)).To(Equal("reverting staged changes on the targeted Ops Manager"))
-			format, content = logger.PrintfArgsForCall(1)
-			Expect(fmt.Sprintf(format, content...)).To(Equal("done"))
+				command := commands.NewRevertStagedChanges(service, dashboardService, stagedProductsService, deployedProductsService, logger)
+				err := command.Execute([]string{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(dashboardService.PostInstallFormCallCount()).To(Equal(1))
+				Expect(stagedProductsService.StagedProductsCallCount()).To(Equal(0))
+
+				Expect(loggedLines()).To(Equal([]string{
+					"warning: failed to retrieve pending changes, the discarded changes will not be listed: meow meow meow",
+					"reverting staged changes on the targeted Ops Manager",
+					"the Ops Manager cannot revert staged changes through the api, using the installation dashboard instead",
+					"done",
+				}))
+			})
 		})
 
 		Context("when there are no staged changes to revert", func() {
 			It("returns without error", func() {
-				command := commands.NewRevertStagedChanges(service, logger)
-				service.GetRevertFormReturns(api.Form{}, nil)
+				service.RevertReturns(false, nil)
+
+				command := commands.NewRevertStagedChanges(service, dashboardService, stagedProductsService, deployedProductsService, logger)
 				err := command.Execute([]string{})
 				Expect(err).NotTo(HaveOccurred())
-				Expect(service.PostInstallFormCallCount()).To(Equal(0))
+
+				Expect(loggedLines()).To(Equal([]string{
+					"reverting staged changes on the targeted Ops Manager",
+					"there were no staged changes to revert",
+				}))
 			})
 		})
 
-		Context("error cases", func() {
+		Context("when the Ops Manager cannot revert staged changes through the api", func() {
+			BeforeEach(func() {
+				service.RevertReturns(false, api.RevertUnsupported{})
+			})
+
+			It("reverts staged changes on the installation dashboard", func() {
+				dashboardService.GetRevertFormReturns(api.Form{
+					Action:            "/installation",
+					AuthenticityToken: "some-auth-token",
+					RailsMethod:       "the-rails",
+				}, nil)
+
+				command := commands.NewRevertStagedChanges(service, dashboardService, stagedProductsService, deployedProductsService, logger)
+				err := command.Execute([]string{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(dashboardService.PostInstallFormArgsForCall(0)).To(Equal(api.PostFormInput{
+					Form: api.Form{
+						Action:            "/installation",
+						AuthenticityToken: "some-auth-token",
+						RailsMethod:       "the-rails",
+					},
+					EncodedPayload: "_method=delete&authenticity_token=some-auth-token&commit=Confirm",
+				}))
+
+				Expect(loggedLines()).To(Equal([]string{
+					"reverting staged changes on the targeted Ops Manager",
+					"the Ops Manager cannot revert staged changes through the api, using the installation dashboard instead",
+					"discarded pending changes:",
+					"  some-product: update",
+					"  some-other-product: install",
+					"done",
+				}))
+			})
+
+			Context("when the dashboard has no revert form", func() {
+				It("returns without error", func() {
+					dashboardService.GetRevertFormReturns(api.Form{}, nil)
+
+					command := commands.NewRevertStagedChanges(service, dashboardService, stagedProductsService, deployedProductsService, logger)
+					err := command.Execute([]string{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(dashboardService.PostInstallFormCallCount()).To(Equal(0))
+				})
+			})
+
 			Context("when the form can't be fetched", func() {
 				It("returns an error", func() {
-					service.GetRevertFormReturns(api.Form{}, errors.New("meow meow meow"))
-
-					command := commands.NewRevertStagedChanges(service, logger)
+					dashboardService.GetRevertFormReturns(api.Form{}, errors.New("meow meow meow"))
 
+					command := commands.NewRevertStagedChanges(service, dashboardService, stagedProductsService, deployedProductsService, logger)
 					err := command.Execute([]string{""})
 					Expect(err).To(MatchError("could not fetch form: meow meow meow"))
 				})
@@ -77,16 +200,26 @@ var _ = Describe("RevertStagedChanges", func() {
 
 			Context("when the form can't be posted", func() {
 				It("returns an error", func() {
-					service.GetRevertFormReturns(api.Form{
+					dashboardService.GetRevertFormReturns(api.Form{
 						Action:            "/installation",
 						AuthenticityToken: "some-auth-token",
 						RailsMethod:       "the-rails",
 					}, nil)
+					dashboardService.PostInstallFormReturns(errors.New("meow meow meow"))
 
-					service.PostInstallFormReturns(errors.New("meow meow meow"))
+					command := commands.NewRevertStagedChanges(service, dashboardService, stagedProductsService, deployedProductsService, logger)
+					err := command.Execute([]string{""})
+					Expect(err).To(MatchError("failed to revert staged changes: meow meow meow"))
+				})
+			})
+		})
 
-					command := commands.NewRevertStagedChanges(service, logger)
+		Context("error cases", func() {
+			Context("when the staged changes can't be reverted", func() {
+				It("returns an error", func() {
+					service.RevertReturns(false, errors.New("meow meow meow"))
 
+					command := commands.NewRevertStagedChanges(service, dashboardService, stagedProductsService, deployedProductsService, logger)
 					err := command.Execute([]string{""})
 					Expect(err).To(MatchError("failed to revert staged changes: meow meow meow"))
 				})
@@ -96,10 +229,10 @@ var _ = Describe("RevertStagedChanges", func() {
 
 	Describe("Usage", func() {
 		It("returns the usage for the command", func() {
-			command := commands.NewRevertStagedChanges(nil, nil)
+			command := commands.NewRevertStagedChanges(nil, nil, nil, nil, nil)
 
 			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
-				Description:      "reverts staged changes on the installation dashboard page in the target Ops Manager",
+				Description:      "This authenticated command reverts all staged changes on the target Ops Manager and lists the pending changes that were discarded. Ops Managers that cannot revert staged changes through the api are reverted through the installation dashboard.",
 				ShortDescription: "reverts staged changes on the Ops Manager targeted",
 			}))
 		})
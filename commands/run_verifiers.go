@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/jhanda/flags"
+	"github.com/pivotal-cf/om/models"
+	"github.com/pivotal-cf/om/presenters"
+)
+
+const (
+	verifierPassed   = "passed"
+	verifierFailed   = "failed"
+	verifierWarning  = "warning"
+	verifierDisabled = "disabled"
+)
+
+type RunVerifiers struct {
+	service              verifiersService
+	stagedProductsFinder stagedProductsFinder
+	presenter            presenters.Presenter
+	Options              struct {
+		ProductName string `short:"p" long:"product-name" description:"name of the staged product (default: the director)"`
+	}
+}
+
+func NewRunVerifiers(service verifiersService, stagedProductsFinder stagedProductsFinder, presenter presenters.Presenter) RunVerifiers {
+	return RunVerifiers{
+		service:              service,
+		stagedProductsFinder: stagedProductsFinder,
+		presenter:            presenter,
+	}
+}
+
+func (r RunVerifiers) Execute(args []string) error {
+	_, err := flags.Parse(&r.Options, args)
+	if err != nil {
+		return fmt.Errorf("could not parse run-verifiers flags: %s", err)
+	}
+
+	productGUID, err := verifiersProductGUID(r.stagedProductsFinder, r.Options.ProductName)
+	if err != nil {
+		return err
+	}
+
+	verifiers, err := r.service.Verifiers(productGUID)
+	if err != nil {
+		return fmt.Errorf("failed to list verifiers: %s", err)
+	}
+
+	verifierResults, err := r.service.RunVerifiers(productGUID)
+	if err != nil {
+		return fmt.Errorf("failed to run verifiers: %s", err)
+	}
+
+	var results []models.VerifierResult
+	for _, verifier := range verifiers {
+		result := models.VerifierResult{Type: verifier.Type, Status: verifierPassed}
+		if !verifier.Enabled {
+			result.Status = verifierDisabled
+		}
+
+		results = append(results, result)
+	}
+
+	var failed int
+	for _, verifierResult := range verifierResults {
+		status := verifierFailed
+		if verifierResult.Ignorable {
+			status = verifierWarning
+		} else {
+			failed++
+		}
+
+		result := models.VerifierResult{
+			Type:     verifierResult.Type,
+			Status:   status,
+			Messages: verifierResult.Errors,
+		}
+
+		found := false
+		for i := range results {
+			if results[i].Type == verifierResult.Type {
+				results[i] = result
+				found = true
+			}
+		}
+
+		if !found {
+			results = append(results, result)
+		}
+	}
+
+	r.presenter.PresentVerifierResults(results)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d verifiers failed", failed, len(results))
+	}
+
+	return nil
+}
+
+func (r RunVerifiers) Usage() commands.Usage {
+	return commands.Usage{
+		Description:      "This authenticated command runs the install time verifiers of the director or of a staged product against the staged configuration, and reports which of them passed. It fails when a verifier reports an error that cannot be ignored.",
+		ShortDescription: "runs verifiers",
+		Flags:            r.Options,
+	}
+}
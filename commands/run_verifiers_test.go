@@ -0,0 +1,124 @@
+package commands_test
+
+import (
+	"errors"
+
+	jhandacommands "github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RunVerifiers", func() {
+	var (
+		service              *fakes.VerifiersService
+		stagedProductsFinder *fakes.StagedProductsFinder
+		presenter            *fakes.Presenter
+		command              commands.RunVerifiers
+	)
+
+	BeforeEach(func() {
+		service = &fakes.VerifiersService{}
+		stagedProductsFinder = &fakes.StagedProductsFinder{}
+		presenter = &fakes.Presenter{}
+		command = commands.NewRunVerifiers(service, stagedProductsFinder, presenter)
+
+		stagedProductsFinder.FindReturns(api.StagedProductsFindOutput{
+			Product: api.StagedProduct{Type: "cf", GUID: "cf-guid"},
+		}, nil)
+
+		service.VerifiersReturns([]api.Verifier{
+			{Type: "WildcardDomainVerifier", Enabled: true},
+			{Type: "AppsDomainVerifier", Enabled: true},
+			{Type: "NetworksPingableVerifier", Enabled: false},
+		}, nil)
+	})
+
+	Describe("Execute", func() {
+		It("presents which verifiers passed", func() {
+			err := command.Execute([]string{"--product-name", "cf"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.VerifiersArgsForCall(0)).To(Equal("cf-guid"))
+			Expect(service.RunVerifiersArgsForCall(0)).To(Equal("cf-guid"))
+
+			Expect(presenter.PresentVerifierResultsArgsForCall(0)).To(Equal([]models.VerifierResult{
+				{Type: "WildcardDomainVerifier", Status: "passed"},
+				{Type: "AppsDomainVerifier", Status: "passed"},
+				{Type: "NetworksPingableVerifier", Status: "disabled"},
+			}))
+		})
+
+		It("runs the verifiers of the director when no product is given", func() {
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stagedProductsFinder.FindCallCount()).To(Equal(0))
+			Expect(service.RunVerifiersArgsForCall(0)).To(Equal(""))
+		})
+
+		Context("when verifiers find errors", func() {
+			BeforeEach(func() {
+				service.RunVerifiersReturns([]api.VerifierResult{
+					{Type: "WildcardDomainVerifier", Errors: []string{"*.apps.example.com does not resolve", "*.sys.example.com does not resolve"}},
+					{Type: "AppsDomainVerifier", Errors: []string{"apps domain is not reachable"}, Ignorable: true},
+				}, nil)
+			})
+
+			It("presents the messages and returns an error for the errors that cannot be ignored", func() {
+				err := command.Execute([]string{"--product-name", "cf"})
+				Expect(err).To(MatchError("1 of 3 verifiers failed"))
+
+				Expect(presenter.PresentVerifierResultsArgsForCall(0)).To(Equal([]models.VerifierResult{
+					{Type: "WildcardDomainVerifier", Status: "failed", Messages: []string{"*.apps.example.com does not resolve", "*.sys.example.com does not resolve"}},
+					{Type: "AppsDomainVerifier", Status: "warning", Messages: []string{"apps domain is not reachable"}},
+					{Type: "NetworksPingableVerifier", Status: "disabled"},
+				}))
+			})
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the flags cannot be parsed", func() {
+				err := command.Execute([]string{"--badflag"})
+				Expect(err).To(MatchError("could not parse run-verifiers flags: flag provided but not defined: -badflag"))
+			})
+
+			It("returns an error when the product is not staged", func() {
+				stagedProductsFinder.FindReturns(api.StagedProductsFindOutput{}, errors.New("could not find product"))
+
+				err := command.Execute([]string{"--product-name", "cf"})
+				Expect(err).To(MatchError(`failed to find staged product "cf": could not find product`))
+			})
+
+			It("returns an error when the verifiers cannot be listed", func() {
+				service.VerifiersReturns(nil, errors.New("some error"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to list verifiers: some error"))
+			})
+
+			It("returns an error when the verifiers cannot be run", func() {
+				service.RunVerifiersReturns(nil, errors.New("some error"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to run verifiers: some error"))
+				Expect(presenter.PresentVerifierResultsCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			command := commands.NewRunVerifiers(nil, nil, nil)
+			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
+				Description:      "This authenticated command runs the install time verifiers of the director or of a staged product against the staged configuration, and reports which of them passed. It fails when a verifier reports an error that cannot be ignored.",
+				ShortDescription: "runs verifiers",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
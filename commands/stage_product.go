@@ -3,10 +3,13 @@ package commands
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/pivotal-cf/jhanda/commands"
 	"github.com/pivotal-cf/jhanda/flags"
 	"github.com/pivotal-cf/om/api"
+	tile "github.com/pivotal-cf/om/extractor"
+	"github.com/pivotal-cf/om/semver"
 )
 
 type StageProduct struct {
@@ -15,15 +18,19 @@ type StageProduct struct {
 	deployedProductsService  deployedProductsLister
 	availableProductsService availableProductChecker
 	diagnosticService        diagnosticService
+	metadataExtractor        metadataExtractor
 	Options                  struct {
-		Product string `short:"p"  long:"product-name"  description:"name of product"`
-		Version string `short:"v"  long:"product-version"  description:"version of product"`
+		Product     string `short:"p"  long:"product-name"  description:"name of product"`
+		Version     string `short:"v"  long:"product-version"  description:"version of product, \"latest\" or a version constraint such as \"~> 2.1\""`
+		ProductFile string `long:"product-file"  description:"path to the product file, used to check that the products it requires are staged or deployed"`
 	}
 }
 
 //go:generate counterfeiter -o ./fakes/product_stager.go --fake-name ProductStager . productStager
 type productStager interface {
 	Stage(api.StageProductInput, string) error
+	StagedProducts() (api.StagedProductsOutput, error)
+	Dependencies(productGUID string) ([]api.ProductDependency, error)
 }
 
 //go:generate counterfeiter -o ./fakes/deployed_products_lister.go --fake-name DeployedProductsLister . deployedProductsLister
@@ -34,15 +41,17 @@ type deployedProductsLister interface {
 //go:generate counterfeiter -o ./fakes/available_product_checker.go --fake-name AvailableProductChecker . availableProductChecker
 type availableProductChecker interface {
 	CheckProductAvailability(productName string, productVersion string) (bool, error)
+	List() (api.AvailableProductsOutput, error)
 }
 
-func NewStageProduct(productStager productStager, deployedProductsService deployedProductsLister, availableProductChecker availableProductChecker, diagnosticService diagnosticService, logger logger) StageProduct {
+func NewStageProduct(productStager productStager, deployedProductsService deployedProductsLister, availableProductChecker availableProductChecker, diagnosticService diagnosticService, metadataExtractor metadataExtractor, logger logger) StageProduct {
 	return StageProduct{
 		logger:                   logger,
 		stagedProductsService:    productStager,
 		deployedProductsService:  deployedProductsService,
 		availableProductsService: availableProductChecker,
 		diagnosticService:        diagnosticService,
+		metadataExtractor:        metadataExtractor,
 	}
 }
 
@@ -60,6 +69,16 @@ func (sp StageProduct) Execute(args []string) error {
 		return errors.New("error: product-version is missing. Please see usage for more information.")
 	}
 
+	if isVersionConstraint(sp.Options.Version) {
+		version, err := sp.resolveVersion(sp.Options.Product, sp.Options.Version)
+		if err != nil {
+			return fmt.Errorf("failed to stage product: %s", err)
+		}
+
+		sp.logger.Printf("resolved %s %s to version %s", sp.Options.Product, sp.Options.Version, version)
+		sp.Options.Version = version
+	}
+
 	diagnosticReport, err := sp.diagnosticService.Report()
 	if err != nil {
 		return fmt.Errorf("failed to stage product: %s", err)
@@ -93,6 +112,30 @@ func (sp StageProduct) Execute(args []string) error {
 		return fmt.Errorf("failed to stage product: cannot find product %s %s", sp.Options.Product, sp.Options.Version)
 	}
 
+	var requirements []tile.ProductDependency
+	if sp.Options.ProductFile != "" {
+		metadata, err := sp.metadataExtractor.ExtractProductMetadata(sp.Options.ProductFile)
+		if err != nil {
+			return fmt.Errorf("failed to stage product: cannot extract product metadata: %s", err)
+		}
+
+		requirements = metadata.RequiresProductVersions
+	} else {
+		requirements, err = sp.stagedRequirements()
+		if err != nil {
+			return fmt.Errorf("failed to stage product: %s", err)
+		}
+	}
+
+	unmet, err := unmetDependencies(requirements, diagnosticReport)
+	if err != nil {
+		return fmt.Errorf("failed to stage product: %s", err)
+	}
+
+	if len(unmet) > 0 {
+		return fmt.Errorf("failed to stage product: %s %s requires products that are not staged or deployed:\n  %s", sp.Options.Product, sp.Options.Version, strings.Join(unmet, "\n  "))
+	}
+
 	sp.logger.Printf("staging %s %s", sp.Options.Product, sp.Options.Version)
 
 	err = sp.stagedProductsService.Stage(api.StageProductInput{
@@ -108,6 +151,149 @@ func (sp StageProduct) Execute(args []string) error {
 	return nil
 }
 
+func (sp StageProduct) resolveVersion(productName, versionConstraint string) (string, error) {
+	var constraint semver.Constraint
+	if versionConstraint != "latest" {
+		var err error
+		constraint, err = semver.NewConstraint(versionConstraint)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	availableProducts, err := sp.availableProductsService.List()
+	if err != nil {
+		return "", fmt.Errorf("cannot list available products: %s", err)
+	}
+
+	var (
+		available []string
+		latest    *semver.Version
+	)
+	for _, product := range availableProducts.ProductsList {
+		if product.Name != productName {
+			continue
+		}
+
+		available = append(available, product.Version)
+
+		version, err := semver.Parse(product.Version)
+		if err != nil {
+			continue
+		}
+
+		if versionConstraint != "latest" && !constraint.Check(version) {
+			continue
+		}
+
+		if latest == nil || version.Compare(*latest) > 0 {
+			latest = &version
+		}
+	}
+
+	if len(available) == 0 {
+		return "", fmt.Errorf("cannot find product %s", productName)
+	}
+
+	if latest == nil {
+		return "", fmt.Errorf("no version of %s matches %q; available versions: %s", productName, versionConstraint, strings.Join(available, ", "))
+	}
+
+	return latest.String(), nil
+}
+
+// stagedRequirements returns the products that Ops Manager reports the staged
+// version of the product to depend on. Ops Manager only reports the
+// dependencies of staged products, so there is nothing to check when the
+// product is not staged yet.
+func (sp StageProduct) stagedRequirements() ([]tile.ProductDependency, error) {
+	stagedProducts, err := sp.stagedProductsService.StagedProducts()
+	if err != nil {
+		return nil, fmt.Errorf("cannot list staged products: %s", err)
+	}
+
+	for _, product := range stagedProducts.Products {
+		if product.Type != sp.Options.Product {
+			continue
+		}
+
+		dependencies, err := sp.stagedProductsService.Dependencies(product.GUID)
+		if err != nil {
+			return nil, fmt.Errorf("cannot retrieve dependencies of %s: %s", product.Type, err)
+		}
+
+		var requirements []tile.ProductDependency
+		for _, dependency := range dependencies {
+			requirements = append(requirements, tile.ProductDependency{
+				Name:    dependency.Type,
+				Version: dependency.Version,
+			})
+		}
+
+		return requirements, nil
+	}
+
+	sp.logger.Printf("%s is not staged yet, so the products it requires can only be checked with --product-file", sp.Options.Product)
+
+	return nil, nil
+}
+
+// unmetDependencies returns a description of every requirement that no staged
+// or deployed product satisfies.
+func unmetDependencies(requirements []tile.ProductDependency, report api.DiagnosticReport) ([]string, error) {
+	var unmet []string
+	for _, requirement := range requirements {
+		constraint, err := semver.NewConstraint(requirement.Version)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse requirement of %s: %s", requirement.Name, err)
+		}
+
+		var found []string
+		satisfied := false
+		for _, product := range append(report.StagedProducts, report.DeployedProducts...) {
+			if product.Name != requirement.Name || contains(found, product.Version) {
+				continue
+			}
+
+			found = append(found, product.Version)
+
+			version, err := semver.Parse(product.Version)
+			if err == nil && constraint.Check(version) {
+				satisfied = true
+				break
+			}
+		}
+
+		switch {
+		case satisfied:
+		case len(found) == 0:
+			unmet = append(unmet, fmt.Sprintf("%s %s (not staged or deployed)", requirement.Name, requirement.Version))
+		default:
+			unmet = append(unmet, fmt.Sprintf("%s %s (found %s)", requirement.Name, requirement.Version, strings.Join(found, ", ")))
+		}
+	}
+
+	return unmet, nil
+}
+
+func isVersionConstraint(version string) bool {
+	if version == "latest" {
+		return true
+	}
+
+	if strings.ContainsAny(version, "<>=!~^,") {
+		return true
+	}
+
+	_, err := semver.NewConstraint(version)
+	if err != nil {
+		return false
+	}
+
+	_, err = semver.Parse(version)
+	return err != nil
+}
+
 func (sp StageProduct) Usage() commands.Usage {
 	return commands.Usage{
 		Description:      "This command attempts to stage a product in the Ops Manager",
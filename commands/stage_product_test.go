@@ -8,6 +8,7 @@ import (
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/commands"
 	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/extractor"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -19,6 +20,7 @@ var _ = Describe("StageProduct", func() {
 		deployedProductsService  *fakes.DeployedProductsLister
 		diagnosticService        *fakes.DiagnosticService
 		availableProductsService *fakes.AvailableProductChecker
+		metadataExtractor        *fakes.MetadataExtractor
 		logger                   *fakes.Logger
 	)
 
@@ -27,6 +29,7 @@ var _ = Describe("StageProduct", func() {
 		deployedProductsService = &fakes.DeployedProductsLister{}
 		availableProductsService = &fakes.AvailableProductChecker{}
 		diagnosticService = &fakes.DiagnosticService{}
+		metadataExtractor = &fakes.MetadataExtractor{}
 		logger = &fakes.Logger{}
 	})
 
@@ -34,7 +37,7 @@ var _ = Describe("StageProduct", func() {
 		availableProductsService.CheckProductAvailabilityReturns(true, nil)
 
 		command := commands.NewStageProduct(
-			stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, logger)
+			stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
 
 		deployedProductsService.DeployedProductsReturns([]api.DeployedProductOutput{
 			api.DeployedProductOutput{
@@ -60,9 +63,12 @@ var _ = Describe("StageProduct", func() {
 		Expect(deployedProductGUID).To(BeEmpty())
 
 		format, v := logger.PrintfArgsForCall(0)
-		Expect(fmt.Sprintf(format, v...)).To(Equal("staging some-product some-version"))
+		Expect(fmt.Sprintf(format, v...)).To(Equal("some-product is not staged yet, so the products it requires can only be checked with --product-file"))
 
 		format, v = logger.PrintfArgsForCall(1)
+		Expect(fmt.Sprintf(format, v...)).To(Equal("staging some-product some-version"))
+
+		format, v = logger.PrintfArgsForCall(2)
 		Expect(fmt.Sprintf(format, v...)).To(Equal("finished staging"))
 	})
 
@@ -71,7 +77,7 @@ var _ = Describe("StageProduct", func() {
 			availableProductsService.CheckProductAvailabilityReturns(true, nil)
 
 			command := commands.NewStageProduct(
-				stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, logger)
+				stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
 
 			deployedProductsService.DeployedProductsReturns([]api.DeployedProductOutput{
 				api.DeployedProductOutput{
@@ -101,9 +107,12 @@ var _ = Describe("StageProduct", func() {
 			Expect(deployedProductGUID).To(Equal("deployed-product-guid"))
 
 			format, v := logger.PrintfArgsForCall(0)
-			Expect(fmt.Sprintf(format, v...)).To(Equal("staging some-product some-version"))
+			Expect(fmt.Sprintf(format, v...)).To(Equal("some-product is not staged yet, so the products it requires can only be checked with --product-file"))
 
 			format, v = logger.PrintfArgsForCall(1)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("staging some-product some-version"))
+
+			format, v = logger.PrintfArgsForCall(2)
 			Expect(fmt.Sprintf(format, v...)).To(Equal("finished staging"))
 		})
 	})
@@ -121,7 +130,7 @@ var _ = Describe("StageProduct", func() {
 			}, nil)
 
 			command := commands.NewStageProduct(
-				stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, logger)
+				stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
 
 			err := command.Execute([]string{
 				"--product-name", "some-product",
@@ -136,11 +145,340 @@ var _ = Describe("StageProduct", func() {
 		})
 	})
 
+	Context("when a product file is provided", func() {
+		BeforeEach(func() {
+			availableProductsService.CheckProductAvailabilityReturns(true, nil)
+			metadataExtractor.ExtractProductMetadataReturns(extractor.Metadata{
+				Name:           "some-product",
+				ProductVersion: "some-version",
+				RequiresProductVersions: []extractor.ProductDependency{
+					{Name: "cf", Version: "~> 2.1"},
+					{Name: "p-mysql", Version: ">= 1.9.0"},
+				},
+			}, nil)
+		})
+
+		It("stages the product when its dependencies are staged or deployed", func() {
+			diagnosticService.ReportReturns(api.DiagnosticReport{
+				StagedProducts: []api.DiagnosticProduct{
+					{Name: "cf", Version: "2.1.4"},
+				},
+				DeployedProducts: []api.DiagnosticProduct{
+					{Name: "p-mysql", Version: "1.10.0"},
+				},
+			}, nil)
+
+			command := commands.NewStageProduct(
+				stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
+
+			err := command.Execute([]string{
+				"--product-name", "some-product",
+				"--product-version", "some-version",
+				"--product-file", "/path/to/some-product.pivotal",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(metadataExtractor.ExtractProductMetadataArgsForCall(0)).To(Equal("/path/to/some-product.pivotal"))
+			Expect(stagedProductsService.StageCallCount()).To(Equal(1))
+		})
+
+		It("refuses to stage the product when its dependencies are not met", func() {
+			diagnosticService.ReportReturns(api.DiagnosticReport{
+				StagedProducts: []api.DiagnosticProduct{
+					{Name: "cf", Version: "3.0.0"},
+				},
+				DeployedProducts: []api.DiagnosticProduct{
+					{Name: "cf", Version: "2.0.1"},
+				},
+			}, nil)
+
+			command := commands.NewStageProduct(
+				stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
+
+			err := command.Execute([]string{
+				"--product-name", "some-product",
+				"--product-version", "some-version",
+				"--product-file", "/path/to/some-product.pivotal",
+			})
+			Expect(err).To(MatchError(`failed to stage product: some-product some-version requires products that are not staged or deployed:
+  cf ~> 2.1 (found 3.0.0, 2.0.1)
+  p-mysql >= 1.9.0 (not staged or deployed)`))
+
+			Expect(stagedProductsService.StageCallCount()).To(Equal(0))
+		})
+
+		Context("when the metadata cannot be extracted", func() {
+			It("returns an error", func() {
+				metadataExtractor.ExtractProductMetadataReturns(extractor.Metadata{}, errors.New("some error"))
+
+				command := commands.NewStageProduct(
+					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
+
+				err := command.Execute([]string{
+					"--product-name", "some-product",
+					"--product-version", "some-version",
+					"--product-file", "/path/to/some-product.pivotal",
+				})
+				Expect(err).To(MatchError("failed to stage product: cannot extract product metadata: some error"))
+			})
+		})
+
+		Context("when a requirement cannot be parsed", func() {
+			It("returns an error", func() {
+				metadataExtractor.ExtractProductMetadataReturns(extractor.Metadata{
+					RequiresProductVersions: []extractor.ProductDependency{
+						{Name: "cf", Version: "not a constraint"},
+					},
+				}, nil)
+
+				command := commands.NewStageProduct(
+					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
+
+				err := command.Execute([]string{
+					"--product-name", "some-product",
+					"--product-version", "some-version",
+					"--product-file", "/path/to/some-product.pivotal",
+				})
+				Expect(err).To(MatchError(ContainSubstring("failed to stage product: cannot parse requirement of cf:")))
+			})
+		})
+	})
+
+	Context("when no product file is provided", func() {
+		BeforeEach(func() {
+			availableProductsService.CheckProductAvailabilityReturns(true, nil)
+			stagedProductsService.StagedProductsReturns(api.StagedProductsOutput{
+				Products: []api.StagedProduct{
+					{GUID: "other-product-guid", Type: "some-other-product"},
+					{GUID: "some-product-guid", Type: "some-product"},
+				},
+			}, nil)
+			stagedProductsService.DependenciesReturns([]api.ProductDependency{
+				{Type: "cf", Version: "~> 2.1"},
+				{Type: "p-mysql", Version: ">= 1.9.0"},
+			}, nil)
+		})
+
+		It("checks the dependencies that Ops Manager reports for the staged version of the product", func() {
+			diagnosticService.ReportReturns(api.DiagnosticReport{
+				StagedProducts: []api.DiagnosticProduct{
+					{Name: "cf", Version: "2.1.4"},
+				},
+				DeployedProducts: []api.DiagnosticProduct{
+					{Name: "p-mysql", Version: "1.10.0"},
+				},
+			}, nil)
+
+			command := commands.NewStageProduct(
+				stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
+
+			err := command.Execute([]string{
+				"--product-name", "some-product",
+				"--product-version", "some-version",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stagedProductsService.StageCallCount()).To(Equal(1))
+			Expect(stagedProductsService.DependenciesArgsForCall(0)).To(Equal("some-product-guid"))
+			Expect(metadataExtractor.ExtractProductMetadataCallCount()).To(Equal(0))
+		})
+
+		It("refuses to stage the product when its dependencies are not met", func() {
+			diagnosticService.ReportReturns(api.DiagnosticReport{
+				StagedProducts: []api.DiagnosticProduct{
+					{Name: "cf", Version: "2.0.1"},
+				},
+			}, nil)
+
+			command := commands.NewStageProduct(
+				stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
+
+			err := command.Execute([]string{
+				"--product-name", "some-product",
+				"--product-version", "some-version",
+			})
+			Expect(err).To(MatchError(`failed to stage product: some-product some-version requires products that are not staged or deployed:
+  cf ~> 2.1 (found 2.0.1)
+  p-mysql >= 1.9.0 (not staged or deployed)`))
+
+			Expect(stagedProductsService.StageCallCount()).To(Equal(0))
+		})
+
+		Context("when the product is not staged yet", func() {
+			It("stages the product without checking its dependencies", func() {
+				stagedProductsService.StagedProductsReturns(api.StagedProductsOutput{}, nil)
+
+				command := commands.NewStageProduct(
+					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
+
+				err := command.Execute([]string{
+					"--product-name", "some-product",
+					"--product-version", "some-version",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(stagedProductsService.DependenciesCallCount()).To(Equal(0))
+				Expect(stagedProductsService.StageCallCount()).To(Equal(1))
+
+				format, v := logger.PrintfArgsForCall(0)
+				Expect(fmt.Sprintf(format, v...)).To(Equal("some-product is not staged yet, so the products it requires can only be checked with --product-file"))
+			})
+		})
+
+		Context("when the staged products cannot be listed", func() {
+			It("returns an error", func() {
+				stagedProductsService.StagedProductsReturns(api.StagedProductsOutput{}, errors.New("some error"))
+
+				command := commands.NewStageProduct(
+					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
+
+				err := command.Execute([]string{
+					"--product-name", "some-product",
+					"--product-version", "some-version",
+				})
+				Expect(err).To(MatchError("failed to stage product: cannot list staged products: some error"))
+				Expect(stagedProductsService.StageCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the dependencies cannot be retrieved", func() {
+			It("returns an error", func() {
+				stagedProductsService.DependenciesReturns(nil, errors.New("some error"))
+
+				command := commands.NewStageProduct(
+					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
+
+				err := command.Execute([]string{
+					"--product-name", "some-product",
+					"--product-version", "some-version",
+				})
+				Expect(err).To(MatchError("failed to stage product: cannot retrieve dependencies of some-product: some error"))
+				Expect(stagedProductsService.StageCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("when the product version is latest or a constraint", func() {
+		BeforeEach(func() {
+			availableProductsService.CheckProductAvailabilityReturns(true, nil)
+			availableProductsService.ListReturns(api.AvailableProductsOutput{
+				ProductsList: []api.ProductInfo{
+					{Name: "some-product", Version: "2.0.4"},
+					{Name: "some-product", Version: "2.1.0-build.12"},
+					{Name: "some-product", Version: "2.1.3"},
+					{Name: "some-product", Version: "2.2.0-build.3"},
+					{Name: "some-product", Version: "not-semver"},
+					{Name: "some-other-product", Version: "9.0.0"},
+				},
+			}, nil)
+		})
+
+		It("stages the highest available version", func() {
+			command := commands.NewStageProduct(
+				stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
+
+			err := command.Execute([]string{
+				"--product-name", "some-product",
+				"--product-version", "latest",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			stageProductInput, _ := stagedProductsService.StageArgsForCall(0)
+			Expect(stageProductInput).To(Equal(api.StageProductInput{
+				ProductName:    "some-product",
+				ProductVersion: "2.2.0-build.3",
+			}))
+
+			productName, productVersion := availableProductsService.CheckProductAvailabilityArgsForCall(0)
+			Expect(productName).To(Equal("some-product"))
+			Expect(productVersion).To(Equal("2.2.0-build.3"))
+
+			format, v := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("resolved some-product latest to version 2.2.0-build.3"))
+
+			format, v = logger.PrintfArgsForCall(2)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("staging some-product 2.2.0-build.3"))
+		})
+
+		It("stages the highest version matching the constraint", func() {
+			command := commands.NewStageProduct(
+				stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
+
+			err := command.Execute([]string{
+				"--product-name", "some-product",
+				"--product-version", "~> 2.1.0",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			stageProductInput, _ := stagedProductsService.StageArgsForCall(0)
+			Expect(stageProductInput.ProductVersion).To(Equal("2.1.3"))
+
+			format, v := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("resolved some-product ~> 2.1.0 to version 2.1.3"))
+		})
+
+		Context("when no version matches the constraint", func() {
+			It("returns an error listing the available versions", func() {
+				command := commands.NewStageProduct(
+					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
+
+				err := command.Execute([]string{
+					"--product-name", "some-product",
+					"--product-version", ">= 3.0",
+				})
+				Expect(err).To(MatchError(`failed to stage product: no version of some-product matches ">= 3.0"; available versions: 2.0.4, 2.1.0-build.12, 2.1.3, 2.2.0-build.3, not-semver`))
+
+				Expect(stagedProductsService.StageCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the product has not been uploaded", func() {
+			It("returns an error", func() {
+				command := commands.NewStageProduct(
+					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
+
+				err := command.Execute([]string{
+					"--product-name", "missing-product",
+					"--product-version", "latest",
+				})
+				Expect(err).To(MatchError("failed to stage product: cannot find product missing-product"))
+			})
+		})
+
+		Context("when the constraint is invalid", func() {
+			It("returns an error", func() {
+				command := commands.NewStageProduct(
+					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
+
+				err := command.Execute([]string{
+					"--product-name", "some-product",
+					"--product-version", "~> banana",
+				})
+				Expect(err).To(MatchError(`failed to stage product: "~> banana" is not a valid version constraint: "banana" is not a valid version`))
+			})
+		})
+
+		Context("when the available products cannot be listed", func() {
+			It("returns an error", func() {
+				availableProductsService.ListReturns(api.AvailableProductsOutput{}, errors.New("some error"))
+
+				command := commands.NewStageProduct(
+					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
+
+				err := command.Execute([]string{
+					"--product-name", "some-product",
+					"--product-version", "latest",
+				})
+				Expect(err).To(MatchError("failed to stage product: cannot list available products: some error"))
+			})
+		})
+	})
+
 	Context("failure cases", func() {
 		Context("when an unknown flag is provided", func() {
 			It("returns an error", func() {
 				command := commands.NewStageProduct(
-					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, logger)
+					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
 				err := command.Execute([]string{"--badflag"})
 				Expect(err).To(MatchError("could not parse stage-product flags: flag provided but not defined: -badflag"))
 			})
@@ -149,7 +487,7 @@ var _ = Describe("StageProduct", func() {
 		Context("when the product-name flag is not provided", func() {
 			It("returns an error", func() {
 				command := commands.NewStageProduct(
-					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, logger)
+					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
 				err := command.Execute([]string{"--product-version", "1.0"})
 				Expect(err).To(MatchError("error: product-name is missing. Please see usage for more information."))
 			})
@@ -158,7 +496,7 @@ var _ = Describe("StageProduct", func() {
 		Context("when the product-version flag is not provided", func() {
 			It("returns an error", func() {
 				command := commands.NewStageProduct(
-					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, logger)
+					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
 				err := command.Execute([]string{"--product-name", "some-product"})
 				Expect(err).To(MatchError("error: product-version is missing. Please see usage for more information."))
 			})
@@ -171,7 +509,7 @@ var _ = Describe("StageProduct", func() {
 
 			It("returns an error", func() {
 				command := commands.NewStageProduct(
-					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, logger)
+					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
 
 				err := command.Execute([]string{
 					"--product-name", "some-product",
@@ -188,7 +526,7 @@ var _ = Describe("StageProduct", func() {
 
 			It("returns an error", func() {
 				command := commands.NewStageProduct(
-					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, logger)
+					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
 
 				err := command.Execute([]string{
 					"--product-name", "some-product",
@@ -201,7 +539,7 @@ var _ = Describe("StageProduct", func() {
 		Context("when the product cannot be staged", func() {
 			It("returns an error", func() {
 				command := commands.NewStageProduct(
-					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, logger)
+					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
 				availableProductsService.CheckProductAvailabilityReturns(true, nil)
 				stagedProductsService.StageReturns(errors.New("some product error"))
 
@@ -213,7 +551,7 @@ var _ = Describe("StageProduct", func() {
 		Context("when the diagnostic report cannot be fetched", func() {
 			It("returns an error", func() {
 				command := commands.NewStageProduct(
-					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, logger)
+					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
 				availableProductsService.CheckProductAvailabilityReturns(true, nil)
 				diagnosticService.ReportReturns(api.DiagnosticReport{}, errors.New("bad diagnostic report"))
 
@@ -231,7 +569,7 @@ var _ = Describe("StageProduct", func() {
 
 			It("returns an error", func() {
 				command := commands.NewStageProduct(
-					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, logger)
+					stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, metadataExtractor, logger)
 
 				err := command.Execute([]string{
 					"--product-name", "some-product",
@@ -245,7 +583,7 @@ var _ = Describe("StageProduct", func() {
 
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewStageProduct(nil, nil, nil, nil, nil)
+			command := commands.NewStageProduct(nil, nil, nil, nil, nil, nil)
 			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
 				Description:      "This command attempts to stage a product in the Ops Manager",
 				ShortDescription: "stages a given product in the Ops Manager targeted",
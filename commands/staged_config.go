@@ -0,0 +1,161 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/jhanda/flags"
+	"github.com/pivotal-cf/om/api"
+	yaml "gopkg.in/yaml.v2"
+)
+
+//go:generate counterfeiter -o ./fakes/staged_config_service.go --fake-name StagedConfigService . stagedConfigService
+type stagedConfigService interface {
+	Find(productName string) (api.StagedProductsFindOutput, error)
+	MaxInFlight(productGUID string) (map[string]interface{}, error)
+	SyslogConfiguration(productGUID string) (map[string]interface{}, error)
+}
+
+type StagedConfig struct {
+	service        stagedConfigService
+	jobsService    jobsConfigurer
+	errandsService errandsService
+	logger         logger
+	Options        struct {
+		ProductName string `short:"p" long:"product-name" description:"name of the staged product"`
+	}
+}
+
+func NewStagedConfig(service stagedConfigService, jobsService jobsConfigurer, errandsService errandsService, logger logger) StagedConfig {
+	return StagedConfig{
+		service:        service,
+		jobsService:    jobsService,
+		errandsService: errandsService,
+		logger:         logger,
+	}
+}
+
+func (sc StagedConfig) Execute(args []string) error {
+	_, err := flags.Parse(&sc.Options, args)
+	if err != nil {
+		return fmt.Errorf("could not parse staged-config flags: %s", err)
+	}
+
+	if sc.Options.ProductName == "" {
+		return errors.New("error: product-name is missing. Please see usage for more information.")
+	}
+
+	findOutput, err := sc.service.Find(sc.Options.ProductName)
+	if err != nil {
+		return fmt.Errorf("failed to find staged product %q: %s", sc.Options.ProductName, err)
+	}
+	productGUID := findOutput.Product.GUID
+
+	config := productConfiguration{ProductName: sc.Options.ProductName}
+
+	config.MaxInFlight, err = sc.maxInFlight(productGUID)
+	if err != nil {
+		return err
+	}
+
+	config.SyslogProperties, err = sc.service.SyslogConfiguration(productGUID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch syslog properties: %s", err)
+	}
+
+	config.ErrandConfig, err = sc.errandConfig(productGUID)
+	if err != nil {
+		return err
+	}
+
+	contents, err := yaml.Marshal(config)
+	if err != nil {
+		return err // cannot be tested
+	}
+
+	sc.logger.Printf("%s", contents)
+
+	return nil
+}
+
+// maxInFlight returns the staged max in flight by job name, as configure-product
+// takes it, rather than by the job GUIDs the api uses.
+func (sc StagedConfig) maxInFlight(productGUID string) (map[string]interface{}, error) {
+	stagedMaxInFlight, err := sc.service.MaxInFlight(productGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch max in flight: %s", err)
+	}
+
+	if len(stagedMaxInFlight) == 0 {
+		return nil, nil
+	}
+
+	jobs, err := sc.jobsService.Jobs(productGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch jobs: %s", err)
+	}
+
+	names := map[string]string{}
+	for name, guid := range jobs {
+		names[guid] = name
+	}
+
+	maxInFlight := map[string]interface{}{}
+	for guid, value := range stagedMaxInFlight {
+		name, ok := names[guid]
+		if !ok {
+			name = guid
+		}
+		maxInFlight[name] = value
+	}
+
+	return maxInFlight, nil
+}
+
+// errandConfig returns the state of each errand with the states that
+// configure-product accepts in its errand-config.
+func (sc StagedConfig) errandConfig(productGUID string) (map[string]interface{}, error) {
+	errandsOutput, err := sc.errandsService.List(productGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch errands: %s", err)
+	}
+
+	errandConfig := map[string]interface{}{}
+	for _, errand := range errandsOutput.Errands {
+		states := map[string]interface{}{}
+		if errand.PostDeploy != nil {
+			states["post-deploy-state"] = errandStateName(errand.PostDeploy)
+		}
+		if errand.PreDelete != nil {
+			states["pre-delete-state"] = errandStateName(errand.PreDelete)
+		}
+
+		if len(states) > 0 {
+			errandConfig[errand.Name] = states
+		}
+	}
+
+	return errandConfig, nil
+}
+
+// errandStateName returns the name that set-errand-state uses for an errand
+// state, which the api gives as a boolean, a string of a boolean or a name.
+func errandStateName(state interface{}) interface{} {
+	switch state {
+	case true, "true":
+		return "enabled"
+	case false, "false":
+		return "disabled"
+	}
+
+	return state
+}
+
+func (sc StagedConfig) Usage() commands.Usage {
+	return commands.Usage{
+		Description:      "This authenticated command writes the staged max in flight, syslog properties and errand states of a product in the format of a configure-product config file.",
+		ShortDescription: "writes the staged configuration of a product",
+		Flags:            sc.Options,
+	}
+}
@@ -0,0 +1,184 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+
+	jhandacommands "github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StagedConfig", func() {
+	var (
+		service        *fakes.StagedConfigService
+		jobsService    *fakes.JobsConfigurer
+		errandsService *fakes.ErrandsService
+		logger         *fakes.Logger
+		command        commands.StagedConfig
+	)
+
+	BeforeEach(func() {
+		service = &fakes.StagedConfigService{}
+		service.FindReturns(api.StagedProductsFindOutput{
+			Product: api.StagedProduct{GUID: "some-product-guid", Type: "some-product"},
+		}, nil)
+		service.MaxInFlightReturns(map[string]interface{}{
+			"some-job-guid":       "20%",
+			"some-other-job-guid": float64(1),
+		}, nil)
+		service.SyslogConfigurationReturns(map[string]interface{}{
+			"enabled": true,
+			"address": "example.com",
+		}, nil)
+
+		jobsService = &fakes.JobsConfigurer{}
+		jobsService.JobsReturns(map[string]string{
+			"some-job":       "some-job-guid",
+			"some-other-job": "some-other-job-guid",
+		}, nil)
+
+		errandsService = &fakes.ErrandsService{}
+		errandsService.ListReturns(api.ErrandsListOutput{
+			Errands: []api.Errand{
+				{Name: "some-errand", PostDeploy: true, PreDelete: "false"},
+				{Name: "some-other-errand", PostDeploy: "when-changed"},
+				{Name: "some-errand-without-state"},
+			},
+		}, nil)
+
+		logger = &fakes.Logger{}
+
+		command = commands.NewStagedConfig(service, jobsService, errandsService, logger)
+	})
+
+	Describe("Execute", func() {
+		It("writes the staged configuration in the configure-product config format", func() {
+			err := command.Execute([]string{"--product-name", "some-product"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.FindArgsForCall(0)).To(Equal("some-product"))
+			Expect(service.MaxInFlightArgsForCall(0)).To(Equal("some-product-guid"))
+			Expect(service.SyslogConfigurationArgsForCall(0)).To(Equal("some-product-guid"))
+			Expect(jobsService.JobsArgsForCall(0)).To(Equal("some-product-guid"))
+			Expect(errandsService.ListArgsForCall(0)).To(Equal("some-product-guid"))
+
+			Expect(logger.PrintfCallCount()).To(Equal(1))
+			format, content := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, content...)).To(MatchYAML(`
+product-name: some-product
+max-in-flight:
+  some-job: 20%
+  some-other-job: 1
+syslog-properties:
+  enabled: true
+  address: example.com
+errand-config:
+  some-errand:
+    post-deploy-state: enabled
+    pre-delete-state: disabled
+  some-other-errand:
+    post-deploy-state: when-changed
+`))
+		})
+
+		It("leaves out the sections that have nothing staged", func() {
+			service.MaxInFlightReturns(map[string]interface{}{}, nil)
+			service.SyslogConfigurationReturns(nil, nil)
+			errandsService.ListReturns(api.ErrandsListOutput{}, nil)
+
+			err := command.Execute([]string{"--product-name", "some-product"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(jobsService.JobsCallCount()).To(Equal(0))
+
+			format, content := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, content...)).To(MatchYAML(`product-name: some-product`))
+		})
+
+		It("keeps the guid of a job that is no longer listed", func() {
+			service.MaxInFlightReturns(map[string]interface{}{"some-removed-job-guid": "default"}, nil)
+
+			err := command.Execute([]string{"--product-name", "some-product"})
+			Expect(err).NotTo(HaveOccurred())
+
+			format, content := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, content...)).To(ContainSubstring("some-removed-job-guid: default"))
+		})
+
+		Context("failure cases", func() {
+			Context("when an unknown flag is provided", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--badflag"})
+					Expect(err).To(MatchError("could not parse staged-config flags: flag provided but not defined: -badflag"))
+				})
+			})
+
+			Context("when the product name is missing", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{})
+					Expect(err).To(MatchError("error: product-name is missing. Please see usage for more information."))
+				})
+			})
+
+			Context("when the product is not staged", func() {
+				It("returns an error", func() {
+					service.FindReturns(api.StagedProductsFindOutput{}, errors.New(`could not find product "some-product"`))
+
+					err := command.Execute([]string{"--product-name", "some-product"})
+					Expect(err).To(MatchError(`failed to find staged product "some-product": could not find product "some-product"`))
+				})
+			})
+
+			Context("when the max in flight cannot be fetched", func() {
+				It("returns an error", func() {
+					service.MaxInFlightReturns(nil, errors.New("some error"))
+
+					err := command.Execute([]string{"--product-name", "some-product"})
+					Expect(err).To(MatchError("failed to fetch max in flight: some error"))
+				})
+			})
+
+			Context("when the jobs cannot be fetched", func() {
+				It("returns an error", func() {
+					jobsService.JobsReturns(nil, errors.New("some error"))
+
+					err := command.Execute([]string{"--product-name", "some-product"})
+					Expect(err).To(MatchError("failed to fetch jobs: some error"))
+				})
+			})
+
+			Context("when the syslog properties cannot be fetched", func() {
+				It("returns an error", func() {
+					service.SyslogConfigurationReturns(nil, errors.New("some error"))
+
+					err := command.Execute([]string{"--product-name", "some-product"})
+					Expect(err).To(MatchError("failed to fetch syslog properties: some error"))
+				})
+			})
+
+			Context("when the errands cannot be fetched", func() {
+				It("returns an error", func() {
+					errandsService.ListReturns(api.ErrandsListOutput{}, errors.New("some error"))
+
+					err := command.Execute([]string{"--product-name", "some-product"})
+					Expect(err).To(MatchError("failed to fetch errands: some error"))
+				})
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
+				Description:      "This authenticated command writes the staged max in flight, syslog properties and errand states of a product in the format of a configure-product config file.",
+				ShortDescription: "writes the staged configuration of a product",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
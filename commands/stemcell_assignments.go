@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/presenters"
+)
+
+type StemcellAssignments struct {
+	service   stemcellAssignmentsService
+	presenter presenters.Presenter
+}
+
+//go:generate counterfeiter -o ./fakes/stemcell_assignments_service.go --fake-name StemcellAssignmentsService . stemcellAssignmentsService
+type stemcellAssignmentsService interface {
+	List() (api.StemcellAssignmentsOutput, error)
+	Assign(api.AssignStemcellInput) error
+}
+
+func NewStemcellAssignments(presenter presenters.Presenter, service stemcellAssignmentsService) StemcellAssignments {
+	return StemcellAssignments{
+		service:   service,
+		presenter: presenter,
+	}
+}
+
+func (sa StemcellAssignments) Execute(args []string) error {
+	output, err := sa.service.List()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve stemcell assignments: %s", err)
+	}
+
+	sa.presenter.PresentStemcellAssignments(output.Products)
+	return nil
+}
+
+func (sa StemcellAssignments) Usage() commands.Usage {
+	return commands.Usage{
+		Description:      "This authenticated command lists the stemcell assigned to each staged product and the stemcell versions available to it.",
+		ShortDescription: "lists stemcell assignments for staged products",
+	}
+}
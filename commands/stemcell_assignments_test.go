@@ -0,0 +1,66 @@
+package commands_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	jhandacommands "github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+var _ = Describe("StemcellAssignments", func() {
+	var (
+		presenter *fakes.Presenter
+		service   *fakes.StemcellAssignmentsService
+		command   commands.StemcellAssignments
+	)
+
+	BeforeEach(func() {
+		presenter = &fakes.Presenter{}
+		service = &fakes.StemcellAssignmentsService{}
+		command = commands.NewStemcellAssignments(presenter, service)
+	})
+
+	It("lists the stemcell assignments", func() {
+		assignments := []api.StemcellAssignment{
+			{
+				GUID:                  "cf-guid",
+				ProductName:           "cf",
+				StagedStemcellVersion: "3541.10",
+				RequiredStemcellOS:    "ubuntu-trusty",
+				AvailableVersions:     []string{"3541.10", "3541.12"},
+			},
+		}
+		service.ListReturns(api.StemcellAssignmentsOutput{Products: assignments}, nil)
+
+		err := command.Execute([]string{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(presenter.PresentStemcellAssignmentsCallCount()).To(Equal(1))
+		Expect(presenter.PresentStemcellAssignmentsArgsForCall(0)).To(Equal(assignments))
+	})
+
+	Context("failure cases", func() {
+		Context("when fetching the stemcell assignments fails", func() {
+			It("returns an error", func() {
+				service.ListReturns(api.StemcellAssignmentsOutput{}, errors.New("beep boop"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to retrieve stemcell assignments: beep boop"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			command := commands.NewStemcellAssignments(nil, nil)
+			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
+				Description:      "This authenticated command lists the stemcell assigned to each staged product and the stemcell versions available to it.",
+				ShortDescription: "lists stemcell assignments for staged products",
+			}))
+		})
+	})
+})
@@ -7,15 +7,19 @@ import (
 	"github.com/pivotal-cf/jhanda/commands"
 	"github.com/pivotal-cf/jhanda/flags"
 	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/formcontent"
+	"github.com/pivotal-cf/om/uploads"
 )
 
 type UploadProduct struct {
-	multipart       multipart
+	newForm         func() (uploads.Multipart, error)
 	logger          logger
 	productsService productUploader
+	uploads         productUploadPool
 	Options         struct {
-		Product         string `short:"p"   long:"product"  description:"path to product"`
-		PollingInterval int    `short:"pi"  long:"polling-interval" description:"interval (in seconds) at which to print status" default:"1"`
+		Product         flags.StringSlice `short:"p"   long:"product"  description:"path to product, or a glob matching several products; may be repeated"`
+		PollingInterval int               `short:"pi"  long:"polling-interval" description:"interval (in seconds) at which to print status" default:"1"`
+		Parallel        int               `long:"parallel"  description:"number of products to upload at the same time when uploading several products" default:"1"`
 	}
 	extractor extractor
 }
@@ -31,18 +35,19 @@ type extractor interface {
 	ExtractMetadata(string) (string, string, error)
 }
 
-func NewUploadProduct(multipart multipart, extractor extractor, productUploader productUploader, logger logger) UploadProduct {
+func NewUploadProduct(newForm func() (uploads.Multipart, error), extractor extractor, productUploader productUploader, uploadPool productUploadPool, logger logger) UploadProduct {
 	return UploadProduct{
-		multipart:       multipart,
+		newForm:         newForm,
 		logger:          logger,
 		productsService: productUploader,
+		uploads:         uploadPool,
 		extractor:       extractor,
 	}
 }
 
 func (up UploadProduct) Usage() commands.Usage {
 	return commands.Usage{
-		Description:      "This command attempts to upload a product to the Ops Manager",
+		Description:      "This command attempts to upload one or more products to the Ops Manager. Several products are uploaded concurrently and summarized once every upload has finished.",
 		ShortDescription: "uploads a given product to the Ops Manager targeted",
 		Flags:            up.Options,
 	}
@@ -54,11 +59,24 @@ func (up UploadProduct) Execute(args []string) error {
 		return fmt.Errorf("could not parse upload-product flags: %s", err)
 	}
 
-	if up.Options.Product == "" {
+	if len(up.Options.Product) == 0 {
 		return errors.New("error: product is missing. Please see usage for more information.")
 	}
 
-	productName, productVersion, err := up.extractor.ExtractMetadata(up.Options.Product)
+	if up.Options.Parallel < 1 {
+		return errors.New("error: parallel must be at least 1. Please see usage for more information.")
+	}
+
+	paths, err := expandPaths(up.Options.Product)
+	if err != nil {
+		return err
+	}
+
+	if len(paths) > 1 {
+		return up.uploadProducts(paths)
+	}
+
+	productName, productVersion, err := up.extractor.ExtractMetadata(paths[0])
 	if err != nil {
 		return fmt.Errorf("failed to extract product metadata: %s", err)
 	}
@@ -74,19 +92,99 @@ func (up UploadProduct) Execute(args []string) error {
 	}
 
 	up.logger.Printf("processing product")
-	err = up.multipart.AddFile("product[file]", up.Options.Product)
+	form, err := up.newForm()
 	if err != nil {
-		return fmt.Errorf("failed to load product: %s", err)
+		return fmt.Errorf("failed to create multipart form: %s", err)
 	}
 
-	submission, err := up.multipart.Finalize()
+	submission, err := loadProduct(form, paths[0])
 	if err != nil {
-		return fmt.Errorf("failed to create multipart form: %s", err)
+		return err
 	}
 
 	up.logger.Printf("beginning product upload to Ops Manager")
 
-	_, err = up.productsService.Upload(api.UploadProductInput{
+	err = up.upload(up.productsService, submission)
+	if err != nil {
+		return err
+	}
+
+	up.logger.Printf("finished upload")
+
+	return nil
+}
+
+func (up UploadProduct) uploadProducts(paths []string) error {
+	results := make([]uploadResult, len(paths))
+	forms := make([]uploads.Multipart, len(paths))
+	services := make([]uploads.ProductService, len(paths))
+
+	var pending []int
+	for i, path := range paths {
+		results[i].path = path
+
+		productName, productVersion, err := up.extractor.ExtractMetadata(path)
+		if err != nil {
+			results[i].err = fmt.Errorf("failed to extract product metadata: %s", err)
+			continue
+		}
+
+		prodAvailable, err := up.productsService.CheckProductAvailability(productName, productVersion)
+		if err != nil {
+			results[i].err = fmt.Errorf("failed to check product availability: %s", err)
+			continue
+		}
+
+		if prodAvailable {
+			results[i].skipped = fmt.Sprintf("product %s %s is already uploaded", productName, productVersion)
+			continue
+		}
+
+		forms[i], err = up.newForm()
+		if err != nil {
+			results[i].err = fmt.Errorf("failed to create multipart form: %s", err)
+			continue
+		}
+		services[i] = up.uploads.New(path)
+
+		pending = append(pending, i)
+	}
+
+	if len(pending) > 0 {
+		up.logger.Printf("uploading %d products to Ops Manager, %d at a time", len(pending), up.Options.Parallel)
+
+		up.uploads.Start()
+		uploadConcurrently(pending, up.Options.Parallel, func(i int) {
+			submission, err := loadProduct(forms[i], paths[i])
+			if err != nil {
+				results[i].err = err
+				return
+			}
+
+			results[i].err = up.upload(services[i], submission)
+		})
+		up.uploads.Stop()
+	}
+
+	return summarizeUploads(up.logger, "products", results)
+}
+
+func loadProduct(multipart multipart, path string) (formcontent.ContentSubmission, error) {
+	err := multipart.AddFile("product[file]", path)
+	if err != nil {
+		return formcontent.ContentSubmission{}, fmt.Errorf("failed to load product: %s", err)
+	}
+
+	submission, err := multipart.Finalize()
+	if err != nil {
+		return formcontent.ContentSubmission{}, fmt.Errorf("failed to create multipart form: %s", err)
+	}
+
+	return submission, nil
+}
+
+func (up UploadProduct) upload(service productUploader, submission formcontent.ContentSubmission) error {
+	_, err := service.Upload(api.UploadProductInput{
 		ContentLength:   submission.Length,
 		Product:         submission.Content,
 		ContentType:     submission.ContentType,
@@ -96,7 +194,5 @@ func (up UploadProduct) Execute(args []string) error {
 		return fmt.Errorf("failed to upload product: %s", err)
 	}
 
-	up.logger.Printf("finished upload")
-
 	return nil
 }
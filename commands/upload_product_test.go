@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 
 	jhandacommands "github.com/pivotal-cf/jhanda/commands"
@@ -11,6 +13,7 @@ import (
 	"github.com/pivotal-cf/om/commands"
 	"github.com/pivotal-cf/om/commands/fakes"
 	"github.com/pivotal-cf/om/formcontent"
+	"github.com/pivotal-cf/om/uploads"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -21,11 +24,17 @@ var _ = Describe("UploadProduct", func() {
 		productsService *fakes.ProductUploader
 		extractor       *fakes.Extractor
 		multipart       *fakes.Multipart
+		newForm         func() (uploads.Multipart, error)
+		uploadPool      *fakes.ProductUploadPool
 		logger          *fakes.Logger
 	)
 
 	BeforeEach(func() {
 		multipart = &fakes.Multipart{}
+		newForm = func() (uploads.Multipart, error) {
+			return multipart, nil
+		}
+		uploadPool = &fakes.ProductUploadPool{}
 		productsService = &fakes.ProductUploader{}
 		extractor = &fakes.Extractor{}
 		logger = &fakes.Logger{}
@@ -39,7 +48,7 @@ var _ = Describe("UploadProduct", func() {
 		}
 		multipart.FinalizeReturns(submission, nil)
 
-		command := commands.NewUploadProduct(multipart, extractor, productsService, logger)
+		command := commands.NewUploadProduct(newForm, extractor, productsService, uploadPool, logger)
 
 		err := command.Execute([]string{
 			"--product", "/path/to/some-product.tgz",
@@ -70,7 +79,7 @@ var _ = Describe("UploadProduct", func() {
 
 	Context("when the polling interval is provided", func() {
 		It("passes the value to the products service", func() {
-			command := commands.NewUploadProduct(multipart, extractor, productsService, logger)
+			command := commands.NewUploadProduct(newForm, extractor, productsService, uploadPool, logger)
 			err := command.Execute([]string{
 				"--product", "/path/to/some-product.tgz",
 				"--polling-interval", "48",
@@ -82,7 +91,7 @@ var _ = Describe("UploadProduct", func() {
 
 	Context("when the same product is already present", func() {
 		It("does nothing and exits gracefully", func() {
-			command := commands.NewUploadProduct(multipart, extractor, productsService, logger)
+			command := commands.NewUploadProduct(newForm, extractor, productsService, uploadPool, logger)
 			extractor.ExtractMetadataReturns("cf", "1.5.0", nil)
 			productsService.CheckProductAvailabilityStub = func(name, version string) (bool, error) {
 				if name == "cf" && version == "1.5.0" {
@@ -103,10 +112,141 @@ var _ = Describe("UploadProduct", func() {
 		})
 	})
 
+	Context("when several products are provided", func() {
+		var (
+			uploaders  map[string]*fakes.ProductUploader
+			multiparts map[string]*fakes.Multipart
+			dir        string
+		)
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "")
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, name := range []string{"cf.pivotal", "mysql.pivotal", "redis.pivotal"} {
+				Expect(ioutil.WriteFile(filepath.Join(dir, name), []byte("contents"), 0644)).To(Succeed())
+			}
+
+			extractor.ExtractMetadataStub = func(path string) (string, string, error) {
+				return strings.TrimSuffix(filepath.Base(path), ".pivotal"), "1.0.0", nil
+			}
+
+			productsService.CheckProductAvailabilityStub = func(name, version string) (bool, error) {
+				return name == "mysql", nil
+			}
+
+			uploaders = map[string]*fakes.ProductUploader{}
+			multiparts = map[string]*fakes.Multipart{}
+			var form *fakes.Multipart
+			newForm = func() (uploads.Multipart, error) {
+				form = &fakes.Multipart{}
+				form.FinalizeReturns(formcontent.ContentSubmission{Length: 8, ContentType: "some content-type"}, nil)
+				return form, nil
+			}
+			uploadPool.NewStub = func(path string) uploads.ProductService {
+				uploaders[path] = &fakes.ProductUploader{}
+				multiparts[path] = form
+				return uploaders[path]
+			}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("uploads the products that are not already uploaded concurrently and summarizes the results", func() {
+			command := commands.NewUploadProduct(newForm, extractor, productsService, uploadPool, logger)
+
+			err := command.Execute([]string{
+				"--product", filepath.Join(dir, "*.pivotal"),
+				"--product", filepath.Join(dir, "cf.pivotal"),
+				"--parallel", "2",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			cf := filepath.Join(dir, "cf.pivotal")
+			mysql := filepath.Join(dir, "mysql.pivotal")
+			redis := filepath.Join(dir, "redis.pivotal")
+
+			Expect(uploadPool.NewCallCount()).To(Equal(2))
+			Expect(uploadPool.StartCallCount()).To(Equal(1))
+			Expect(uploadPool.StopCallCount()).To(Equal(1))
+			Expect(productsService.UploadCallCount()).To(Equal(0))
+
+			for _, path := range []string{cf, redis} {
+				key, file := multiparts[path].AddFileArgsForCall(0)
+				Expect(key).To(Equal("product[file]"))
+				Expect(file).To(Equal(path))
+
+				Expect(uploaders[path].UploadCallCount()).To(Equal(1))
+				Expect(uploaders[path].UploadArgsForCall(0)).To(Equal(api.UploadProductInput{
+					ContentLength:   8,
+					ContentType:     "some content-type",
+					PollingInterval: 1,
+				}))
+			}
+
+			var lines []string
+			for i := 0; i < logger.PrintfCallCount(); i++ {
+				format, v := logger.PrintfArgsForCall(i)
+				lines = append(lines, fmt.Sprintf(format, v...))
+			}
+
+			Expect(lines).To(Equal([]string{
+				"uploading 2 products to Ops Manager, 2 at a time",
+				"upload summary:",
+				fmt.Sprintf("  %s: uploaded", cf),
+				fmt.Sprintf("  %s: skipped: product mysql 1.0.0 is already uploaded", mysql),
+				fmt.Sprintf("  %s: uploaded", redis),
+			}))
+		})
+
+		Context("when some uploads fail", func() {
+			It("uploads the rest and returns an error", func() {
+				extractor.ExtractMetadataStub = func(path string) (string, string, error) {
+					if strings.HasSuffix(path, "cf.pivotal") {
+						return "", "", errors.New("some error")
+					}
+					return strings.TrimSuffix(filepath.Base(path), ".pivotal"), "1.0.0", nil
+				}
+
+				command := commands.NewUploadProduct(newForm, extractor, productsService, uploadPool, logger)
+
+				err := command.Execute([]string{"--product", filepath.Join(dir, "*.pivotal")})
+				Expect(err).To(MatchError("failed to upload 1 of 3 products"))
+
+				Expect(uploadPool.NewCallCount()).To(Equal(1))
+				Expect(uploaders[filepath.Join(dir, "redis.pivotal")].UploadCallCount()).To(Equal(1))
+
+				format, v := logger.PrintfArgsForCall(2)
+				Expect(fmt.Sprintf(format, v...)).To(Equal(fmt.Sprintf("  %s: failed: failed to extract product metadata: some error", filepath.Join(dir, "cf.pivotal"))))
+			})
+		})
+
+		Context("when a glob matches nothing", func() {
+			It("returns an error", func() {
+				command := commands.NewUploadProduct(newForm, extractor, productsService, uploadPool, logger)
+
+				err := command.Execute([]string{"--product", filepath.Join(dir, "*.tgz")})
+				Expect(err).To(MatchError(fmt.Sprintf("no files match %q", filepath.Join(dir, "*.tgz"))))
+			})
+		})
+
+		Context("when parallel is less than one", func() {
+			It("returns an error", func() {
+				command := commands.NewUploadProduct(newForm, extractor, productsService, uploadPool, logger)
+
+				err := command.Execute([]string{"--product", filepath.Join(dir, "*.pivotal"), "--parallel", "0"})
+				Expect(err).To(MatchError("error: parallel must be at least 1. Please see usage for more information."))
+			})
+		})
+	})
+
 	Context("failure cases", func() {
 		Context("when an unkwown flag is provided", func() {
 			It("returns an error", func() {
-				command := commands.NewUploadProduct(multipart, extractor, productsService, logger)
+				command := commands.NewUploadProduct(newForm, extractor, productsService, uploadPool, logger)
 				err := command.Execute([]string{"--badflag"})
 				Expect(err).To(MatchError("could not parse upload-product flags: flag provided but not defined: -badflag"))
 			})
@@ -114,7 +254,7 @@ var _ = Describe("UploadProduct", func() {
 
 		Context("when the product flag is not provided", func() {
 			It("returns an error", func() {
-				command := commands.NewUploadProduct(multipart, extractor, productsService, logger)
+				command := commands.NewUploadProduct(newForm, extractor, productsService, uploadPool, logger)
 				err := command.Execute([]string{})
 				Expect(err).To(MatchError("error: product is missing. Please see usage for more information."))
 			})
@@ -123,7 +263,7 @@ var _ = Describe("UploadProduct", func() {
 		Context("when extracting the product metadata returns an error", func() {
 			It("returns an error", func() {
 				extractor.ExtractMetadataReturns("", "", errors.New("some error"))
-				command := commands.NewUploadProduct(multipart, extractor, productsService, logger)
+				command := commands.NewUploadProduct(newForm, extractor, productsService, uploadPool, logger)
 				err := command.Execute([]string{"--product", "/some/path"})
 				Expect(err).To(MatchError("failed to extract product metadata: some error"))
 			})
@@ -132,15 +272,27 @@ var _ = Describe("UploadProduct", func() {
 		Context("when checking for product availability returns an error", func() {
 			It("returns an error", func() {
 				productsService.CheckProductAvailabilityReturns(true, errors.New("some error"))
-				command := commands.NewUploadProduct(multipart, extractor, productsService, logger)
+				command := commands.NewUploadProduct(newForm, extractor, productsService, uploadPool, logger)
 				err := command.Execute([]string{"--product", "/some/path"})
 				Expect(err).To(MatchError("failed to check product availability: some error"))
 			})
 		})
 
+		Context("when the form cannot be created", func() {
+			It("returns an error", func() {
+				newForm = func() (uploads.Multipart, error) {
+					return nil, errors.New("no space left on device")
+				}
+				command := commands.NewUploadProduct(newForm, extractor, productsService, uploadPool, logger)
+
+				err := command.Execute([]string{"--product", "/some/path"})
+				Expect(err).To(MatchError("failed to create multipart form: no space left on device"))
+			})
+		})
+
 		Context("when adding the file fails", func() {
 			It("returns an error", func() {
-				command := commands.NewUploadProduct(multipart, extractor, productsService, logger)
+				command := commands.NewUploadProduct(newForm, extractor, productsService, uploadPool, logger)
 				multipart.AddFileReturns(errors.New("bad file"))
 
 				err := command.Execute([]string{"--product", "/some/path"})
@@ -150,7 +302,7 @@ var _ = Describe("UploadProduct", func() {
 
 		Context("when the product cannot be uploaded", func() {
 			It("returns and error", func() {
-				command := commands.NewUploadProduct(multipart, extractor, productsService, logger)
+				command := commands.NewUploadProduct(newForm, extractor, productsService, uploadPool, logger)
 				productsService.UploadReturns(api.UploadProductOutput{}, errors.New("some product error"))
 
 				err := command.Execute([]string{"--product", "/some/path"})
@@ -161,9 +313,9 @@ var _ = Describe("UploadProduct", func() {
 
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewUploadProduct(nil, nil, nil, nil)
+			command := commands.NewUploadProduct(nil, nil, nil, nil, nil)
 			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
-				Description:      "This command attempts to upload a product to the Ops Manager",
+				Description:      "This command attempts to upload one or more products to the Ops Manager. Several products are uploaded concurrently and summarized once every upload has finished.",
 				ShortDescription: "uploads a given product to the Ops Manager targeted",
 				Flags:            command.Options,
 			}))
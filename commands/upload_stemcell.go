@@ -1,23 +1,31 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/pivotal-cf/jhanda/commands"
 	"github.com/pivotal-cf/jhanda/flags"
 	"github.com/pivotal-cf/om/api"
+	tile "github.com/pivotal-cf/om/extractor"
 	"github.com/pivotal-cf/om/formcontent"
+	"github.com/pivotal-cf/om/uploads"
 )
 
 type UploadStemcell struct {
-	multipart         multipart
+	newForm           func() (uploads.Multipart, error)
 	logger            logger
 	stemcellService   stemcellService
 	diagnosticService diagnosticService
+	stemcellLibrary   stemcellAssignmentsService
+	stemcellExtractor stemcellExtractor
+	uploads           stemcellUploadPool
 	Options           struct {
-		Stemcell string `short:"s"  long:"stemcell"  description:"path to stemcell"`
-		Force    bool   `short:"f"  long:"force"  description:"upload stemcell even if it already exists on the target Ops Manager"`
+		Stemcell flags.StringSlice `short:"s"  long:"stemcell"  description:"path to stemcell, or a glob matching several stemcells; may be repeated"`
+		Force    bool              `short:"f"  long:"force"  description:"upload stemcell even if it already exists on the target Ops Manager or does not match its infrastructure"`
+		Parallel int               `long:"parallel"  description:"number of stemcells to upload at the same time when uploading several stemcells" default:"1"`
 	}
 }
 
@@ -38,18 +46,26 @@ type diagnosticService interface {
 	Report() (api.DiagnosticReport, error)
 }
 
-func NewUploadStemcell(multipart multipart, stemcellService stemcellService, diagnosticService diagnosticService, logger logger) UploadStemcell {
+//go:generate counterfeiter -o ./fakes/stemcell_extractor.go --fake-name StemcellExtractor . stemcellExtractor
+type stemcellExtractor interface {
+	ExtractStemcellManifest(string) (tile.StemcellManifest, error)
+}
+
+func NewUploadStemcell(newForm func() (uploads.Multipart, error), stemcellService stemcellService, diagnosticService diagnosticService, stemcellLibrary stemcellAssignmentsService, stemcellExtractor stemcellExtractor, uploadPool stemcellUploadPool, logger logger) UploadStemcell {
 	return UploadStemcell{
-		multipart:         multipart,
+		newForm:           newForm,
 		logger:            logger,
 		stemcellService:   stemcellService,
 		diagnosticService: diagnosticService,
+		stemcellLibrary:   stemcellLibrary,
+		stemcellExtractor: stemcellExtractor,
+		uploads:           uploadPool,
 	}
 }
 
 func (us UploadStemcell) Usage() commands.Usage {
 	return commands.Usage{
-		Description:      "This command will upload a stemcell to the target Ops Manager. Unless the force flag is used, if the stemcell already exists that upload will be skipped",
+		Description:      "This command will upload one or more stemcells to the target Ops Manager. Unless the force flag is used, if a stemcell already exists that upload will be skipped. Several stemcells are uploaded concurrently and summarized once every upload has finished.",
 		ShortDescription: "uploads a given stemcell to the Ops Manager targeted",
 		Flags:            us.Options,
 	}
@@ -61,9 +77,23 @@ func (us UploadStemcell) Execute(args []string) error {
 		return fmt.Errorf("could not parse upload-stemcell flags: %s", err)
 	}
 
+	if len(us.Options.Stemcell) == 0 {
+		return errors.New("error: stemcell is missing. Please see usage for more information.")
+	}
+
+	if us.Options.Parallel < 1 {
+		return errors.New("error: parallel must be at least 1. Please see usage for more information.")
+	}
+
+	paths, err := expandPaths(us.Options.Stemcell)
+	if err != nil {
+		return err
+	}
+
+	var report api.DiagnosticReport
 	if !us.Options.Force {
 		us.logger.Printf("processing stemcell")
-		report, err := us.diagnosticService.Report()
+		report, err = us.diagnosticService.Report()
 		if err != nil {
 			switch err.(type) {
 			case api.DiagnosticReportUnavailable:
@@ -72,28 +102,139 @@ func (us UploadStemcell) Execute(args []string) error {
 				return fmt.Errorf("failed to get diagnostic report: %s", err)
 			}
 		}
+	}
 
-		for _, stemcell := range report.Stemcells {
-			if stemcell == filepath.Base(us.Options.Stemcell) {
-				us.logger.Printf("stemcell has already been uploaded")
-				return nil
-			}
+	var library []api.LibraryStemcell
+	if !us.Options.Force {
+		assignments, err := us.stemcellLibrary.List()
+		if err != nil {
+			us.logger.Printf("could not list the uploaded stemcells, falling back to the file names: %s", err)
 		}
+		library = assignments.StemcellLibrary
 	}
 
-	err = us.multipart.AddFile("stemcell[file]", us.Options.Stemcell)
-	if err != nil {
-		return fmt.Errorf("failed to load stemcell: %s", err)
+	if len(paths) > 1 {
+		return us.uploadStemcells(paths, report, library)
+	}
+
+	if !us.Options.Force {
+		skipped, err := us.checkStemcell(paths[0], report, library)
+		if err != nil {
+			return err
+		}
+
+		if skipped {
+			us.logger.Printf("stemcell has already been uploaded")
+			return nil
+		}
 	}
 
-	submission, err := us.multipart.Finalize()
+	form, err := us.newForm()
 	if err != nil {
 		return fmt.Errorf("failed to create multipart form: %s", err)
 	}
 
+	submission, err := loadStemcell(form, paths[0])
+	if err != nil {
+		return err
+	}
+
 	us.logger.Printf("beginning stemcell upload to Ops Manager")
 
-	_, err = us.stemcellService.Upload(api.StemcellUploadInput{
+	err = uploadStemcell(us.stemcellService, submission)
+	if err != nil {
+		return err
+	}
+
+	us.logger.Printf("finished upload")
+
+	return nil
+}
+
+func (us UploadStemcell) uploadStemcells(paths []string, report api.DiagnosticReport, library []api.LibraryStemcell) error {
+	results := make([]uploadResult, len(paths))
+	forms := make([]uploads.Multipart, len(paths))
+	services := make([]uploads.StemcellService, len(paths))
+
+	var pending []int
+	for i, path := range paths {
+		results[i].path = path
+
+		if !us.Options.Force {
+			skipped, err := us.checkStemcell(path, report, library)
+			if err != nil {
+				results[i].err = err
+				continue
+			}
+
+			if skipped {
+				results[i].skipped = "stemcell has already been uploaded"
+				continue
+			}
+		}
+
+		var err error
+		forms[i], err = us.newForm()
+		if err != nil {
+			results[i].err = fmt.Errorf("failed to create multipart form: %s", err)
+			continue
+		}
+		services[i] = us.uploads.New(path)
+
+		pending = append(pending, i)
+	}
+
+	if len(pending) > 0 {
+		us.logger.Printf("uploading %d stemcells to Ops Manager, %d at a time", len(pending), us.Options.Parallel)
+
+		us.uploads.Start()
+		uploadConcurrently(pending, us.Options.Parallel, func(i int) {
+			submission, err := loadStemcell(forms[i], paths[i])
+			if err != nil {
+				results[i].err = err
+				return
+			}
+
+			results[i].err = uploadStemcell(services[i], submission)
+		})
+		us.uploads.Stop()
+	}
+
+	return summarizeUploads(us.logger, "stemcells", results)
+}
+
+// checkStemcell reports whether the stemcell has already been uploaded, and
+// returns an error when it was built for a different infrastructure.
+func (us UploadStemcell) checkStemcell(path string, report api.DiagnosticReport, library []api.LibraryStemcell) (bool, error) {
+	manifest, err := us.stemcellExtractor.ExtractStemcellManifest(path)
+	if err != nil {
+		us.logger.Printf("could not read stemcell manifest, falling back to the file name: %s", err)
+	}
+
+	infrastructure := manifest.CloudProperties.Infrastructure
+	if infrastructure != "" && report.InfrastructureType != "" && !strings.EqualFold(infrastructure, report.InfrastructureType) {
+		return false, fmt.Errorf("stemcell %s %s is for the %s infrastructure, but Ops Manager is running on %s", manifest.Name, manifest.Version, infrastructure, report.InfrastructureType)
+	}
+
+	return stemcellUploaded(path, manifest, library, report.Stemcells), nil
+}
+
+func loadStemcell(multipart multipart, path string) (formcontent.ContentSubmission, error) {
+	err := multipart.AddFile("stemcell[file]", path)
+	if err != nil {
+		return formcontent.ContentSubmission{}, fmt.Errorf("failed to load stemcell: %s", err)
+	}
+
+	submission, err := multipart.Finalize()
+	if err != nil {
+		return formcontent.ContentSubmission{}, fmt.Errorf("failed to create multipart form: %s", err)
+	}
+
+	return submission, nil
+}
+
+func uploadStemcell(service stemcellService, submission formcontent.ContentSubmission) error {
+	_, err := service.Upload(api.StemcellUploadInput{
 		ContentLength: submission.Length,
 		Stemcell:      submission.Content,
 		ContentType:   submission.ContentType,
@@ -102,7 +243,23 @@ func (us UploadStemcell) Execute(args []string) error {
 		return fmt.Errorf("failed to upload stemcell: %s", err)
 	}
 
-	us.logger.Printf("finished upload")
-
 	return nil
 }
+
+// stemcellUploaded compares the version and operating system in the manifest
+// of a stemcell with the stemcells in the library of the Ops Manager. Only
+// when either of them is unknown is the file name compared with the file
+// names of the uploaded stemcells instead.
+func stemcellUploaded(path string, manifest tile.StemcellManifest, library []api.LibraryStemcell, files []string) bool {
+	if library == nil || manifest.Version == "" || manifest.OperatingSystem == "" {
+		return contains(files, filepath.Base(path))
+	}
+
+	for _, stemcell := range library {
+		if stemcell.Version == manifest.Version && stemcell.OS == manifest.OperatingSystem {
+			return true
+		}
+	}
+
+	return false
+}
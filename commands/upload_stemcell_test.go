@@ -10,7 +10,9 @@ import (
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/commands"
 	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/extractor"
 	"github.com/pivotal-cf/om/formcontent"
+	"github.com/pivotal-cf/om/uploads"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -20,14 +22,24 @@ var _ = Describe("UploadStemcell", func() {
 	var (
 		stemcellService   *fakes.StemcellService
 		diagnosticService *fakes.DiagnosticService
+		stemcellLibrary   *fakes.StemcellAssignmentsService
+		stemcellExtractor *fakes.StemcellExtractor
 		multipart         *fakes.Multipart
+		newForm           func() (uploads.Multipart, error)
+		uploadPool        *fakes.StemcellUploadPool
 		logger            *fakes.Logger
 	)
 
 	BeforeEach(func() {
 		multipart = &fakes.Multipart{}
+		newForm = func() (uploads.Multipart, error) {
+			return multipart, nil
+		}
+		uploadPool = &fakes.StemcellUploadPool{}
 		stemcellService = &fakes.StemcellService{}
 		diagnosticService = &fakes.DiagnosticService{}
+		stemcellLibrary = &fakes.StemcellAssignmentsService{}
+		stemcellExtractor = &fakes.StemcellExtractor{}
 		logger = &fakes.Logger{}
 	})
 
@@ -41,7 +53,7 @@ var _ = Describe("UploadStemcell", func() {
 
 		diagnosticService.ReportReturns(api.DiagnosticReport{Stemcells: []string{}}, nil)
 
-		command := commands.NewUploadStemcell(multipart, stemcellService, diagnosticService, logger)
+		command := commands.NewUploadStemcell(newForm, stemcellService, diagnosticService, stemcellLibrary, stemcellExtractor, uploadPool, logger)
 
 		err := command.Execute([]string{
 			"--stemcell", "/path/to/stemcell.tgz",
@@ -83,7 +95,7 @@ var _ = Describe("UploadStemcell", func() {
 					Stemcells: []string{"stemcell.tgz"},
 				}, nil)
 
-				command := commands.NewUploadStemcell(multipart, stemcellService, diagnosticService, logger)
+				command := commands.NewUploadStemcell(newForm, stemcellService, diagnosticService, stemcellLibrary, stemcellExtractor, uploadPool, logger)
 
 				err := command.Execute([]string{
 					"--stemcell", "/path/to/stemcell.tgz",
@@ -108,7 +120,7 @@ var _ = Describe("UploadStemcell", func() {
 					Stemcells: []string{"stemcell.tgz"},
 				}, nil)
 
-				command := commands.NewUploadStemcell(multipart, stemcellService, diagnosticService, logger)
+				command := commands.NewUploadStemcell(newForm, stemcellService, diagnosticService, stemcellLibrary, stemcellExtractor, uploadPool, logger)
 
 				err := command.Execute([]string{
 					"--stemcell", "/path/to/stemcell.tgz",
@@ -136,6 +148,151 @@ var _ = Describe("UploadStemcell", func() {
 		})
 	})
 
+	Context("when the stemcell manifest can be read", func() {
+		BeforeEach(func() {
+			stemcellExtractor.ExtractStemcellManifestReturns(extractor.StemcellManifest{
+				Name:            "bosh-vsphere-esxi-ubuntu-trusty-go_agent",
+				Version:         "3445.11",
+				OperatingSystem: "ubuntu-trusty",
+				CloudProperties: extractor.StemcellCloudProperties{Infrastructure: "vsphere"},
+			}, nil)
+		})
+
+		It("skips a stemcell whose version and operating system have been uploaded", func() {
+			diagnosticService.ReportReturns(api.DiagnosticReport{
+				InfrastructureType: "vsphere",
+				Stemcells:          []string{"bosh-stemcell-3445.11-vsphere-esxi-ubuntu-trusty-go_agent.tgz"},
+			}, nil)
+			stemcellLibrary.ListReturns(api.StemcellAssignmentsOutput{
+				StemcellLibrary: []api.LibraryStemcell{
+					{Version: "3445.10", OS: "ubuntu-trusty"},
+					{Version: "3445.11", OS: "ubuntu-trusty"},
+				},
+			}, nil)
+
+			command := commands.NewUploadStemcell(newForm, stemcellService, diagnosticService, stemcellLibrary, stemcellExtractor, uploadPool, logger)
+
+			err := command.Execute([]string{"--stemcell", "/path/to/renamed-stemcell.tgz"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stemcellExtractor.ExtractStemcellManifestArgsForCall(0)).To(Equal("/path/to/renamed-stemcell.tgz"))
+			Expect(stemcellService.UploadCallCount()).To(Equal(0))
+
+			format, v := logger.PrintfArgsForCall(1)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("stemcell has already been uploaded"))
+		})
+
+		It("uploads a stemcell whose version has only been uploaded for another operating system", func() {
+			diagnosticService.ReportReturns(api.DiagnosticReport{
+				InfrastructureType: "vsphere",
+				Stemcells:          []string{"stemcell.tgz"},
+			}, nil)
+			stemcellLibrary.ListReturns(api.StemcellAssignmentsOutput{
+				StemcellLibrary: []api.LibraryStemcell{
+					{Version: "3445.11", OS: "windows2016"},
+				},
+			}, nil)
+
+			command := commands.NewUploadStemcell(newForm, stemcellService, diagnosticService, stemcellLibrary, stemcellExtractor, uploadPool, logger)
+
+			err := command.Execute([]string{"--stemcell", "/path/to/stemcell.tgz"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stemcellService.UploadCallCount()).To(Equal(1))
+		})
+
+		It("uploads a stemcell whose version has not been uploaded", func() {
+			diagnosticService.ReportReturns(api.DiagnosticReport{
+				InfrastructureType: "vsphere",
+			}, nil)
+			stemcellLibrary.ListReturns(api.StemcellAssignmentsOutput{
+				StemcellLibrary: []api.LibraryStemcell{
+					{Version: "3445.10", OS: "ubuntu-trusty"},
+				},
+			}, nil)
+
+			command := commands.NewUploadStemcell(newForm, stemcellService, diagnosticService, stemcellLibrary, stemcellExtractor, uploadPool, logger)
+
+			err := command.Execute([]string{"--stemcell", "/path/to/stemcell.tgz"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stemcellService.UploadCallCount()).To(Equal(1))
+		})
+
+		Context("when the uploaded stemcells cannot be listed", func() {
+			It("falls back to matching the file name", func() {
+				diagnosticService.ReportReturns(api.DiagnosticReport{
+					InfrastructureType: "vsphere",
+					Stemcells:          []string{"stemcell.tgz"},
+				}, nil)
+				stemcellLibrary.ListReturns(api.StemcellAssignmentsOutput{}, errors.New("not found"))
+
+				command := commands.NewUploadStemcell(newForm, stemcellService, diagnosticService, stemcellLibrary, stemcellExtractor, uploadPool, logger)
+
+				err := command.Execute([]string{"--stemcell", "/path/to/stemcell.tgz"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(stemcellService.UploadCallCount()).To(Equal(0))
+
+				format, v := logger.PrintfArgsForCall(1)
+				Expect(fmt.Sprintf(format, v...)).To(Equal("could not list the uploaded stemcells, falling back to the file names: not found"))
+
+				format, v = logger.PrintfArgsForCall(2)
+				Expect(fmt.Sprintf(format, v...)).To(Equal("stemcell has already been uploaded"))
+			})
+		})
+
+		Context("when the stemcell does not match the Ops Manager infrastructure", func() {
+			BeforeEach(func() {
+				diagnosticService.ReportReturns(api.DiagnosticReport{
+					InfrastructureType: "aws",
+				}, nil)
+			})
+
+			It("returns an error without uploading", func() {
+				command := commands.NewUploadStemcell(newForm, stemcellService, diagnosticService, stemcellLibrary, stemcellExtractor, uploadPool, logger)
+
+				err := command.Execute([]string{"--stemcell", "/path/to/stemcell.tgz"})
+				Expect(err).To(MatchError("stemcell bosh-vsphere-esxi-ubuntu-trusty-go_agent 3445.11 is for the vsphere infrastructure, but Ops Manager is running on aws"))
+
+				Expect(multipart.AddFileCallCount()).To(Equal(0))
+				Expect(stemcellService.UploadCallCount()).To(Equal(0))
+			})
+
+			It("uploads the stemcell when force is specified", func() {
+				command := commands.NewUploadStemcell(newForm, stemcellService, diagnosticService, stemcellLibrary, stemcellExtractor, uploadPool, logger)
+
+				err := command.Execute([]string{"--stemcell", "/path/to/stemcell.tgz", "--force"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(stemcellExtractor.ExtractStemcellManifestCallCount()).To(Equal(0))
+				Expect(stemcellService.UploadCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Context("when the stemcell manifest cannot be read", func() {
+		It("falls back to matching the file name", func() {
+			stemcellExtractor.ExtractStemcellManifestReturns(extractor.StemcellManifest{}, errors.New("no stemcell.MF file was found in provided stemcell"))
+
+			diagnosticService.ReportReturns(api.DiagnosticReport{
+				InfrastructureType: "aws",
+				Stemcells:          []string{"stemcell.tgz"},
+			}, nil)
+
+			command := commands.NewUploadStemcell(newForm, stemcellService, diagnosticService, stemcellLibrary, stemcellExtractor, uploadPool, logger)
+
+			err := command.Execute([]string{"--stemcell", "/path/to/stemcell.tgz"})
+			Expect(err).NotTo(HaveOccurred())
+
+			format, v := logger.PrintfArgsForCall(1)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("could not read stemcell manifest, falling back to the file name: no stemcell.MF file was found in provided stemcell"))
+
+			format, v = logger.PrintfArgsForCall(2)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("stemcell has already been uploaded"))
+		})
+	})
+
 	Context("when the diagnostic report is unavailable", func() {
 		It("uploads the stemcell", func() {
 			submission := formcontent.ContentSubmission{
@@ -147,7 +304,7 @@ var _ = Describe("UploadStemcell", func() {
 
 			diagnosticService.ReportReturns(api.DiagnosticReport{}, api.DiagnosticReportUnavailable{})
 
-			command := commands.NewUploadStemcell(multipart, stemcellService, diagnosticService, logger)
+			command := commands.NewUploadStemcell(newForm, stemcellService, diagnosticService, stemcellLibrary, stemcellExtractor, uploadPool, logger)
 
 			err := command.Execute([]string{
 				"--stemcell", "/path/to/stemcell.tgz",
@@ -179,18 +336,145 @@ var _ = Describe("UploadStemcell", func() {
 		})
 	})
 
+	Context("when several stemcells are provided", func() {
+		var (
+			services   map[string]*fakes.StemcellService
+			multiparts map[string]*fakes.Multipart
+		)
+
+		BeforeEach(func() {
+			diagnosticService.ReportReturns(api.DiagnosticReport{
+				InfrastructureType: "vsphere",
+				Stemcells:          []string{"bosh-stemcell-3468.21-vsphere-esxi-ubuntu-trusty-go_agent.tgz"},
+			}, nil)
+
+			stemcellExtractor.ExtractStemcellManifestStub = func(path string) (extractor.StemcellManifest, error) {
+				if strings.Contains(path, "aws") {
+					return extractor.StemcellManifest{
+						Name:            "bosh-aws-xen-hvm-ubuntu-trusty-go_agent",
+						Version:         "3468.21",
+						CloudProperties: extractor.StemcellCloudProperties{Infrastructure: "aws"},
+					}, nil
+				}
+				return extractor.StemcellManifest{}, errors.New("no manifest")
+			}
+
+			services = map[string]*fakes.StemcellService{}
+			multiparts = map[string]*fakes.Multipart{}
+			var form *fakes.Multipart
+			newForm = func() (uploads.Multipart, error) {
+				form = &fakes.Multipart{}
+				form.FinalizeReturns(formcontent.ContentSubmission{Length: 8, ContentType: "some content-type"}, nil)
+				return form, nil
+			}
+			uploadPool.NewStub = func(path string) uploads.StemcellService {
+				services[path] = &fakes.StemcellService{}
+				multiparts[path] = form
+				return services[path]
+			}
+		})
+
+		It("uploads the new stemcells concurrently and summarizes the results", func() {
+			command := commands.NewUploadStemcell(newForm, stemcellService, diagnosticService, stemcellLibrary, stemcellExtractor, uploadPool, logger)
+
+			err := command.Execute([]string{
+				"--stemcell", "/path/to/bosh-stemcell-3468.21-vsphere-esxi-ubuntu-trusty-go_agent.tgz",
+				"--stemcell", "/path/to/bosh-stemcell-3468.25-vsphere-esxi-ubuntu-trusty-go_agent.tgz",
+				"--stemcell", "/path/to/bosh-stemcell-3541.10-vsphere-esxi-ubuntu-trusty-go_agent.tgz",
+				"--stemcell", "/path/to/light-bosh-stemcell-3468.21-aws-xen-hvm-ubuntu-trusty-go_agent.tgz",
+				"--parallel", "3",
+			})
+			Expect(err).To(MatchError("failed to upload 1 of 4 stemcells"))
+
+			Expect(diagnosticService.ReportCallCount()).To(Equal(1))
+			Expect(uploadPool.NewCallCount()).To(Equal(2))
+			Expect(uploadPool.StartCallCount()).To(Equal(1))
+			Expect(uploadPool.StopCallCount()).To(Equal(1))
+			Expect(stemcellService.UploadCallCount()).To(Equal(0))
+
+			for _, path := range []string{
+				"/path/to/bosh-stemcell-3468.25-vsphere-esxi-ubuntu-trusty-go_agent.tgz",
+				"/path/to/bosh-stemcell-3541.10-vsphere-esxi-ubuntu-trusty-go_agent.tgz",
+			} {
+				key, file := multiparts[path].AddFileArgsForCall(0)
+				Expect(key).To(Equal("stemcell[file]"))
+				Expect(file).To(Equal(path))
+
+				Expect(services[path].UploadCallCount()).To(Equal(1))
+			}
+
+			var lines []string
+			for i := 0; i < logger.PrintfCallCount(); i++ {
+				format, v := logger.PrintfArgsForCall(i)
+				lines = append(lines, fmt.Sprintf(format, v...))
+			}
+
+			Expect(lines[len(lines)-5:]).To(Equal([]string{
+				"upload summary:",
+				"  /path/to/bosh-stemcell-3468.21-vsphere-esxi-ubuntu-trusty-go_agent.tgz: skipped: stemcell has already been uploaded",
+				"  /path/to/bosh-stemcell-3468.25-vsphere-esxi-ubuntu-trusty-go_agent.tgz: uploaded",
+				"  /path/to/bosh-stemcell-3541.10-vsphere-esxi-ubuntu-trusty-go_agent.tgz: uploaded",
+				"  /path/to/light-bosh-stemcell-3468.21-aws-xen-hvm-ubuntu-trusty-go_agent.tgz: failed: stemcell bosh-aws-xen-hvm-ubuntu-trusty-go_agent 3468.21 is for the aws infrastructure, but Ops Manager is running on vsphere",
+			}))
+		})
+
+		Context("when an upload fails", func() {
+			It("reports the failure in the summary", func() {
+				uploadPool.NewStub = func(path string) uploads.StemcellService {
+					service := &fakes.StemcellService{}
+					service.UploadReturns(api.StemcellUploadOutput{}, errors.New("some stemcell error"))
+					return service
+				}
+
+				command := commands.NewUploadStemcell(newForm, stemcellService, diagnosticService, stemcellLibrary, stemcellExtractor, uploadPool, logger)
+
+				err := command.Execute([]string{
+					"--stemcell", "/path/to/bosh-stemcell-3468.25-vsphere-esxi-ubuntu-trusty-go_agent.tgz",
+					"--stemcell", "/path/to/bosh-stemcell-3541.10-vsphere-esxi-ubuntu-trusty-go_agent.tgz",
+					"--force",
+				})
+				Expect(err).To(MatchError("failed to upload 2 of 2 stemcells"))
+
+				Expect(diagnosticService.ReportCallCount()).To(Equal(0))
+
+				format, v := logger.PrintfArgsForCall(2)
+				Expect(fmt.Sprintf(format, v...)).To(Equal("  /path/to/bosh-stemcell-3468.25-vsphere-esxi-ubuntu-trusty-go_agent.tgz: failed: failed to upload stemcell: some stemcell error"))
+			})
+		})
+	})
+
 	Context("failure cases", func() {
 		Context("when an unkwown flag is provided", func() {
 			It("returns an error", func() {
-				command := commands.NewUploadStemcell(multipart, stemcellService, diagnosticService, logger)
+				command := commands.NewUploadStemcell(newForm, stemcellService, diagnosticService, stemcellLibrary, stemcellExtractor, uploadPool, logger)
 				err := command.Execute([]string{"--badflag"})
 				Expect(err).To(MatchError("could not parse upload-stemcell flags: flag provided but not defined: -badflag"))
 			})
 		})
 
+		Context("when the stemcell flag is not provided", func() {
+			It("returns an error", func() {
+				command := commands.NewUploadStemcell(newForm, stemcellService, diagnosticService, stemcellLibrary, stemcellExtractor, uploadPool, logger)
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("error: stemcell is missing. Please see usage for more information."))
+			})
+		})
+
+		Context("when the form cannot be created", func() {
+			It("returns an error", func() {
+				newForm = func() (uploads.Multipart, error) {
+					return nil, errors.New("no space left on device")
+				}
+				command := commands.NewUploadStemcell(newForm, stemcellService, diagnosticService, stemcellLibrary, stemcellExtractor, uploadPool, logger)
+
+				err := command.Execute([]string{"--stemcell", "/some/path"})
+				Expect(err).To(MatchError("failed to create multipart form: no space left on device"))
+			})
+		})
+
 		Context("when the file cannot be opened", func() {
 			It("returns an error", func() {
-				command := commands.NewUploadStemcell(multipart, stemcellService, diagnosticService, logger)
+				command := commands.NewUploadStemcell(newForm, stemcellService, diagnosticService, stemcellLibrary, stemcellExtractor, uploadPool, logger)
 				multipart.AddFileReturns(errors.New("bad file"))
 
 				err := command.Execute([]string{"--stemcell", "/some/path"})
@@ -200,7 +484,7 @@ var _ = Describe("UploadStemcell", func() {
 
 		Context("when the stemcell cannot be uploaded", func() {
 			It("returns and error", func() {
-				command := commands.NewUploadStemcell(multipart, stemcellService, diagnosticService, logger)
+				command := commands.NewUploadStemcell(newForm, stemcellService, diagnosticService, stemcellLibrary, stemcellExtractor, uploadPool, logger)
 				stemcellService.UploadReturns(api.StemcellUploadOutput{}, errors.New("some stemcell error"))
 
 				err := command.Execute([]string{"--stemcell", "/some/path"})
@@ -210,7 +494,7 @@ var _ = Describe("UploadStemcell", func() {
 
 		Context("when the diagnostic report cannot be fetched", func() {
 			It("returns an error", func() {
-				command := commands.NewUploadStemcell(multipart, stemcellService, diagnosticService, logger)
+				command := commands.NewUploadStemcell(newForm, stemcellService, diagnosticService, stemcellLibrary, stemcellExtractor, uploadPool, logger)
 				diagnosticService.ReportReturns(api.DiagnosticReport{}, errors.New("some diagnostic error"))
 
 				err := command.Execute([]string{"--stemcell", "/some/path"})
@@ -221,9 +505,9 @@ var _ = Describe("UploadStemcell", func() {
 
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewUploadStemcell(nil, nil, nil, nil)
+			command := commands.NewUploadStemcell(nil, nil, nil, nil, nil, nil, nil)
 			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
-				Description:      "This command will upload a stemcell to the target Ops Manager. Unless the force flag is used, if the stemcell already exists that upload will be skipped",
+				Description:      "This command will upload one or more stemcells to the target Ops Manager. Unless the force flag is used, if a stemcell already exists that upload will be skipped. Several stemcells are uploaded concurrently and summarized once every upload has finished.",
 				ShortDescription: "uploads a given stemcell to the Ops Manager targeted",
 				Flags:            command.Options,
 			}))
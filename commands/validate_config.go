@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/jhanda/flags"
+	tile "github.com/pivotal-cf/om/extractor"
+)
+
+type ValidateConfig struct {
+	extractor metadataExtractor
+	logger    logger
+	Options   struct {
+		Product    string `short:"p"  long:"product"  description:"path to product"`
+		ConfigFile string `short:"c"  long:"config"  description:"path to yml file containing the product configuration"`
+		VarsFile   string `long:"vars-file"  description:"path to yml file containing values for ((placeholders)) in the config file"`
+	}
+}
+
+func NewValidateConfig(extractor metadataExtractor, logger logger) ValidateConfig {
+	return ValidateConfig{
+		extractor: extractor,
+		logger:    logger,
+	}
+}
+
+func (vc ValidateConfig) Execute(args []string) error {
+	_, err := flags.Parse(&vc.Options, args)
+	if err != nil {
+		return fmt.Errorf("could not parse validate-config flags: %s", err)
+	}
+
+	if vc.Options.Product == "" {
+		return errors.New("error: product is missing. Please see usage for more information.")
+	}
+
+	if vc.Options.ConfigFile == "" {
+		return errors.New("error: config is missing. Please see usage for more information.")
+	}
+
+	metadata, err := vc.extractor.ExtractProductMetadata(vc.Options.Product)
+	if err != nil {
+		return fmt.Errorf("failed to extract product metadata: %s", err)
+	}
+
+	config, err := loadProductConfiguration(vc.Options.ConfigFile, vc.Options.VarsFile)
+	if err != nil {
+		return err
+	}
+
+	err = validateProductConfiguration(metadata, config, true)
+	if err != nil {
+		return err
+	}
+
+	vc.logger.Printf("config file is valid for %s %s", metadata.Name, metadata.ProductVersion)
+
+	return nil
+}
+
+func (vc ValidateConfig) Usage() commands.Usage {
+	return commands.Usage{
+		Description:      "This command validates a configure-product config file against the property blueprints of a product file without contacting Ops Manager",
+		ShortDescription: "validates a product config file against a product file",
+		Flags:            vc.Options,
+	}
+}
+
+// validateProductConfiguration checks a configuration against the metadata of
+// a product. A complete configuration, which is what validate-config checks,
+// must also give every required property, while configure-product may set only
+// some of the properties of a staged product.
+func validateProductConfiguration(metadata tile.Metadata, config productConfiguration, complete bool) error {
+	var errs []string
+
+	if config.ProductName != "" && config.ProductName != metadata.Name {
+		errs = append(errs, fmt.Sprintf("product-name: expected %q, got %q", metadata.Name, config.ProductName))
+	}
+
+	schema := schemaFromMetadata(metadata)
+	errs = append(errs, schema.validate(config.ProductProperties)...)
+	if complete {
+		errs = append(errs, schema.missing(config.ProductProperties)...)
+	}
+
+	jobs := map[string]bool{}
+	for _, job := range metadata.JobTypes {
+		jobs[job.Name] = true
+	}
+
+	for _, job := range sortedKeys(config.ResourceConfig) {
+		if !jobs[job] {
+			errs = append(errs, fmt.Sprintf("resource-config.%s: unknown job", job))
+		}
+	}
+
+	return validationError(errs)
+}
+
+func validationError(errs []string) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("product configuration is invalid:\n%s", strings.Join(errs, "\n"))
+}
@@ -0,0 +1,272 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	jhandacommands "github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/extractor"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateConfig", func() {
+	var (
+		metadataExtractor *fakes.MetadataExtractor
+		logger            *fakes.Logger
+		configFile        *os.File
+	)
+
+	writeConfig := func(contents string) {
+		_, err := configFile.WriteString(contents)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configFile.Close()).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		metadataExtractor = &fakes.MetadataExtractor{}
+		logger = &fakes.Logger{}
+
+		metadataExtractor.ExtractProductMetadataReturns(extractor.Metadata{
+			Name:           "some-product",
+			ProductVersion: "1.2.3",
+			PropertyBlueprints: []extractor.PropertyBlueprint{
+				{Name: "some-string", Type: "string", Configurable: true},
+				{Name: "some-port", Type: "port", Configurable: true, Default: 8080},
+				{Name: "some-optional", Type: "boolean", Configurable: true, Optional: true},
+				{Name: "not-configurable", Type: "string"},
+				{
+					Name:         "some-selector",
+					Type:         "selector",
+					Configurable: true,
+					Default:      "internal",
+					OptionTemplates: []extractor.OptionTemplate{
+						{
+							Name:        "internal",
+							SelectValue: "internal",
+							PropertyBlueprints: []extractor.PropertyBlueprint{
+								{Name: "some-size", Type: "integer", Configurable: true},
+							},
+						},
+						{
+							Name:        "external",
+							SelectValue: "external",
+							PropertyBlueprints: []extractor.PropertyBlueprint{
+								{Name: "some-address", Type: "string", Configurable: true},
+							},
+						},
+					},
+				},
+				{Name: "some-certificate", Type: "rsa_cert_credentials", Configurable: true, Optional: true},
+				{
+					Name:         "some-collection",
+					Type:         "collection",
+					Configurable: true,
+					Optional:     true,
+					PropertyBlueprints: []extractor.PropertyBlueprint{
+						{Name: "name", Type: "string", Configurable: true},
+						{Name: "enabled", Type: "boolean", Configurable: true, Default: true},
+					},
+				},
+			},
+			JobTypes: []extractor.JobType{
+				{Name: "some-job"},
+			},
+		}, nil)
+
+		var err error
+		configFile, err = ioutil.TempFile("", "config")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.Remove(configFile.Name())
+	})
+
+	Describe("Execute", func() {
+		It("validates the config file against the product metadata", func() {
+			writeConfig(`---
+product-name: some-product
+product-properties:
+  .properties.some-string:
+    value: ((some-string))
+  .properties.some-port:
+    value: "443"
+  .properties.some-selector.internal.some-size:
+    value: 3
+  .properties.some-certificate:
+    value:
+      cert_pem: some-cert
+      private_key_pem: some-key
+  .properties.some-collection:
+    value:
+    - name: some-name
+      guid: some-guid
+resource-config:
+  some-job:
+    instances: 1
+`)
+
+			varsFile, err := ioutil.TempFile("", "vars")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.Remove(varsFile.Name())
+
+			_, err = varsFile.WriteString("some-string: some-value\n")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(varsFile.Close()).To(Succeed())
+
+			command := commands.NewValidateConfig(metadataExtractor, logger)
+
+			err = command.Execute([]string{
+				"--product", "/path/to/some-product.pivotal",
+				"--config", configFile.Name(),
+				"--vars-file", varsFile.Name(),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(metadataExtractor.ExtractProductMetadataArgsForCall(0)).To(Equal("/path/to/some-product.pivotal"))
+
+			format, content := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("config file is valid for some-product 1.2.3"))
+		})
+
+		Context("when the config file does not match the product", func() {
+			It("returns every error", func() {
+				writeConfig(`---
+product-name: other-product
+product-properties:
+  .properties.unknown:
+    value: foo
+  .properties.not-configurable:
+    value: foo
+  .properties.some-port:
+    value: eighty
+  .properties.some-optional:
+    value: "yes"
+  .properties.some-selector:
+    value: external
+  .properties.some-certificate:
+    value: some-cert
+  .properties.some-collection:
+    value:
+    - enabled: nope
+      extra: foo
+resource-config:
+  other-job:
+    instances: 1
+`)
+
+				command := commands.NewValidateConfig(metadataExtractor, logger)
+
+				err := command.Execute([]string{
+					"--product", "/path/to/some-product.pivotal",
+					"--config", configFile.Name(),
+				})
+				Expect(err).To(MatchError(`product configuration is invalid:
+product-name: expected "some-product", got "other-product"
+.properties.not-configurable: property is not configurable
+.properties.some-certificate: expected a map with keys [cert_pem private_key_pem], got "some-cert"
+.properties.some-collection[0].enabled: expected a boolean, got "nope"
+.properties.some-collection[0].extra: unknown property
+.properties.some-collection[0].name: required property is missing
+.properties.some-optional: expected a boolean, got "yes"
+.properties.some-port: expected an integer, got "eighty"
+.properties.unknown: unknown property
+.properties.some-selector.external.some-address: required property is missing
+.properties.some-string: required property is missing
+resource-config.other-job: unknown job`))
+
+				Expect(logger.PrintfCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when a selector is set to an unknown option", func() {
+			It("returns an error", func() {
+				writeConfig(`---
+product-properties:
+  .properties.some-string:
+    value: foo
+  .properties.some-selector:
+    value: elsewhere
+`)
+
+				command := commands.NewValidateConfig(metadataExtractor, logger)
+
+				err := command.Execute([]string{
+					"--product", "/path/to/some-product.pivotal",
+					"--config", configFile.Name(),
+				})
+				Expect(err).To(MatchError(`product configuration is invalid:
+.properties.some-selector: expected one of [internal external], got "elsewhere"`))
+			})
+		})
+
+		Context("failure cases", func() {
+			Context("when an unknown flag is provided", func() {
+				It("returns an error", func() {
+					command := commands.NewValidateConfig(metadataExtractor, logger)
+					err := command.Execute([]string{"--badflag"})
+					Expect(err).To(MatchError("could not parse validate-config flags: flag provided but not defined: -badflag"))
+				})
+			})
+
+			Context("when the product flag is not provided", func() {
+				It("returns an error", func() {
+					command := commands.NewValidateConfig(metadataExtractor, logger)
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError("error: product is missing. Please see usage for more information."))
+				})
+			})
+
+			Context("when the config flag is not provided", func() {
+				It("returns an error", func() {
+					command := commands.NewValidateConfig(metadataExtractor, logger)
+					err := command.Execute([]string{"--product", "/path/to/some-product.pivotal"})
+					Expect(err).To(MatchError("error: config is missing. Please see usage for more information."))
+				})
+			})
+
+			Context("when the metadata cannot be extracted", func() {
+				It("returns an error", func() {
+					metadataExtractor.ExtractProductMetadataReturns(extractor.Metadata{}, errors.New("some error"))
+
+					command := commands.NewValidateConfig(metadataExtractor, logger)
+					err := command.Execute([]string{
+						"--product", "/path/to/some-product.pivotal",
+						"--config", configFile.Name(),
+					})
+					Expect(err).To(MatchError("failed to extract product metadata: some error"))
+				})
+			})
+
+			Context("when the config file cannot be parsed", func() {
+				It("returns an error", func() {
+					writeConfig("%%%")
+
+					command := commands.NewValidateConfig(metadataExtractor, logger)
+					err := command.Execute([]string{
+						"--product", "/path/to/some-product.pivotal",
+						"--config", configFile.Name(),
+					})
+					Expect(err).To(MatchError(ContainSubstring("could not parse config file")))
+				})
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			command := commands.NewValidateConfig(nil, nil)
+			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
+				Description:      "This command validates a configure-product config file against the property blueprints of a product file without contacting Ops Manager",
+				ShortDescription: "validates a product config file against a product file",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
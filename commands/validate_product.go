@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/jhanda/flags"
+)
+
+type ValidateProduct struct {
+	validator productValidator
+	logger    logger
+	Options   struct {
+		Product string `short:"p"  long:"product"  description:"path to product"`
+	}
+}
+
+//go:generate counterfeiter -o ./fakes/product_validator.go --fake-name ProductValidator . productValidator
+type productValidator interface {
+	ValidateProduct(productPath string) ([]string, error)
+}
+
+func NewValidateProduct(validator productValidator, logger logger) ValidateProduct {
+	return ValidateProduct{
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (vp ValidateProduct) Execute(args []string) error {
+	_, err := flags.Parse(&vp.Options, args)
+	if err != nil {
+		return fmt.Errorf("could not parse validate-product flags: %s", err)
+	}
+
+	if vp.Options.Product == "" {
+		return errors.New("error: product is missing. Please see usage for more information.")
+	}
+
+	problems, err := vp.validator.ValidateProduct(vp.Options.Product)
+	if err != nil {
+		return fmt.Errorf("failed to validate product: %s", err)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("product file is invalid:\n%s", strings.Join(problems, "\n"))
+	}
+
+	vp.logger.Printf("product file %s is valid", vp.Options.Product)
+
+	return nil
+}
+
+func (vp ValidateProduct) Usage() commands.Usage {
+	return commands.Usage{
+		Description:      "This command checks a product file without contacting Ops Manager. It verifies that every release listed in the metadata is present and matches its SHA1, that the metadata has the required top-level keys, and that release, job and property names are unique.",
+		ShortDescription: "checks the integrity of a product file",
+		Flags:            vp.Options,
+	}
+}
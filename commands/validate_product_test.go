@@ -0,0 +1,96 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+
+	jhandacommands "github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateProduct", func() {
+	var (
+		validator *fakes.ProductValidator
+		logger    *fakes.Logger
+	)
+
+	BeforeEach(func() {
+		validator = &fakes.ProductValidator{}
+		logger = &fakes.Logger{}
+	})
+
+	Describe("Execute", func() {
+		It("reports that the product file is valid", func() {
+			command := commands.NewValidateProduct(validator, logger)
+
+			err := command.Execute([]string{"--product", "/path/to/some-product.pivotal"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(validator.ValidateProductArgsForCall(0)).To(Equal("/path/to/some-product.pivotal"))
+
+			format, content := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("product file /path/to/some-product.pivotal is valid"))
+		})
+
+		Context("when the product file has problems", func() {
+			It("returns an error listing every problem", func() {
+				validator.ValidateProductReturns([]string{
+					`metadata is missing required key "label"`,
+					"release some-release: releases/some-release.tgz is missing from the product file",
+				}, nil)
+
+				command := commands.NewValidateProduct(validator, logger)
+
+				err := command.Execute([]string{"--product", "/path/to/some-product.pivotal"})
+				Expect(err).To(MatchError(`product file is invalid:
+metadata is missing required key "label"
+release some-release: releases/some-release.tgz is missing from the product file`))
+
+				Expect(logger.PrintfCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("failure cases", func() {
+			Context("when an unknown flag is provided", func() {
+				It("returns an error", func() {
+					command := commands.NewValidateProduct(validator, logger)
+					err := command.Execute([]string{"--badflag"})
+					Expect(err).To(MatchError("could not parse validate-product flags: flag provided but not defined: -badflag"))
+				})
+			})
+
+			Context("when the product flag is not provided", func() {
+				It("returns an error", func() {
+					command := commands.NewValidateProduct(validator, logger)
+					err := command.Execute([]string{})
+					Expect(err).To(MatchError("error: product is missing. Please see usage for more information."))
+				})
+			})
+
+			Context("when the product file cannot be read", func() {
+				It("returns an error", func() {
+					validator.ValidateProductReturns(nil, errors.New("some error"))
+
+					command := commands.NewValidateProduct(validator, logger)
+					err := command.Execute([]string{"--product", "/path/to/some-product.pivotal"})
+					Expect(err).To(MatchError("failed to validate product: some error"))
+				})
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			command := commands.NewValidateProduct(nil, nil)
+			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
+				Description:      "This command checks a product file without contacting Ops Manager. It verifies that every release listed in the metadata is present and matches its SHA1, that the metadata has the required top-level keys, and that release, job and property names are unique.",
+				ShortDescription: "checks the integrity of a product file",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
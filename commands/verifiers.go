@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/jhanda/flags"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/presenters"
+)
+
+//go:generate counterfeiter -o ./fakes/verifiers_service.go --fake-name VerifiersService . verifiersService
+type verifiersService interface {
+	Verifiers(productGUID string) ([]api.Verifier, error)
+	SetVerifierEnabled(productGUID, verifierType string, enabled bool) error
+	RunVerifiers(productGUID string) ([]api.VerifierResult, error)
+}
+
+type Verifiers struct {
+	service              verifiersService
+	stagedProductsFinder stagedProductsFinder
+	presenter            presenters.Presenter
+	Options              struct {
+		ProductName string `short:"p" long:"product-name" description:"name of the staged product (default: the director)"`
+	}
+}
+
+func NewVerifiers(service verifiersService, stagedProductsFinder stagedProductsFinder, presenter presenters.Presenter) Verifiers {
+	return Verifiers{
+		service:              service,
+		stagedProductsFinder: stagedProductsFinder,
+		presenter:            presenter,
+	}
+}
+
+func (v Verifiers) Execute(args []string) error {
+	_, err := flags.Parse(&v.Options, args)
+	if err != nil {
+		return fmt.Errorf("could not parse verifiers flags: %s", err)
+	}
+
+	productGUID, err := verifiersProductGUID(v.stagedProductsFinder, v.Options.ProductName)
+	if err != nil {
+		return err
+	}
+
+	verifiers, err := v.service.Verifiers(productGUID)
+	if err != nil {
+		return fmt.Errorf("failed to list verifiers: %s", err)
+	}
+
+	v.presenter.PresentVerifiers(verifiers)
+
+	return nil
+}
+
+// verifiersProductGUID finds the guid of the staged product, or returns an
+// empty guid for the director, whose verifiers have their own endpoints.
+func verifiersProductGUID(finder stagedProductsFinder, productName string) (string, error) {
+	if productName == "" || productName == boshProductName {
+		return "", nil
+	}
+
+	findOutput, err := finder.Find(productName)
+	if err != nil {
+		return "", fmt.Errorf("failed to find staged product %q: %s", productName, err)
+	}
+
+	return findOutput.Product.GUID, nil
+}
+
+func (v Verifiers) Usage() commands.Usage {
+	return commands.Usage{
+		Description:      "This authenticated command lists the install time verifiers of the director or of a staged product, and whether they are enabled.",
+		ShortDescription: "lists verifiers",
+		Flags:            v.Options,
+	}
+}
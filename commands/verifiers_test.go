@@ -0,0 +1,94 @@
+package commands_test
+
+import (
+	"errors"
+
+	jhandacommands "github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Verifiers", func() {
+	var (
+		service              *fakes.VerifiersService
+		stagedProductsFinder *fakes.StagedProductsFinder
+		presenter            *fakes.Presenter
+		command              commands.Verifiers
+	)
+
+	BeforeEach(func() {
+		service = &fakes.VerifiersService{}
+		stagedProductsFinder = &fakes.StagedProductsFinder{}
+		presenter = &fakes.Presenter{}
+		command = commands.NewVerifiers(service, stagedProductsFinder, presenter)
+
+		service.VerifiersReturns([]api.Verifier{
+			{Type: "IaasConfigurationVerifier", Enabled: true},
+			{Type: "NetworksPingableVerifier", Enabled: false},
+		}, nil)
+	})
+
+	Describe("Execute", func() {
+		It("presents the verifiers of the director", func() {
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stagedProductsFinder.FindCallCount()).To(Equal(0))
+			Expect(service.VerifiersArgsForCall(0)).To(Equal(""))
+
+			Expect(presenter.PresentVerifiersCallCount()).To(Equal(1))
+			Expect(presenter.PresentVerifiersArgsForCall(0)).To(Equal([]api.Verifier{
+				{Type: "IaasConfigurationVerifier", Enabled: true},
+				{Type: "NetworksPingableVerifier", Enabled: false},
+			}))
+		})
+
+		It("presents the verifiers of a staged product", func() {
+			stagedProductsFinder.FindReturns(api.StagedProductsFindOutput{
+				Product: api.StagedProduct{Type: "cf", GUID: "cf-guid"},
+			}, nil)
+
+			err := command.Execute([]string{"--product-name", "cf"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stagedProductsFinder.FindArgsForCall(0)).To(Equal("cf"))
+			Expect(service.VerifiersArgsForCall(0)).To(Equal("cf-guid"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the flags cannot be parsed", func() {
+				err := command.Execute([]string{"--badflag"})
+				Expect(err).To(MatchError("could not parse verifiers flags: flag provided but not defined: -badflag"))
+			})
+
+			It("returns an error when the product is not staged", func() {
+				stagedProductsFinder.FindReturns(api.StagedProductsFindOutput{}, errors.New("could not find product"))
+
+				err := command.Execute([]string{"--product-name", "cf"})
+				Expect(err).To(MatchError(`failed to find staged product "cf": could not find product`))
+			})
+
+			It("returns an error when the verifiers cannot be listed", func() {
+				service.VerifiersReturns(nil, errors.New("some error"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to list verifiers: some error"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			command := commands.NewVerifiers(nil, nil, nil)
+			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
+				Description:      "This authenticated command lists the install time verifiers of the director or of a staged product, and whether they are enabled.",
+				ShortDescription: "lists verifiers",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/presenters"
+)
+
+type VMExtensions struct {
+	service   vmExtensionsLister
+	presenter presenters.Presenter
+}
+
+//go:generate counterfeiter -o ./fakes/vm_extensions_lister.go --fake-name VMExtensionsLister . vmExtensionsLister
+type vmExtensionsLister interface {
+	VMExtensions() ([]api.VMExtension, error)
+}
+
+func NewVMExtensions(service vmExtensionsLister, presenter presenters.Presenter) VMExtensions {
+	return VMExtensions{
+		service:   service,
+		presenter: presenter,
+	}
+}
+
+func (v VMExtensions) Execute(args []string) error {
+	vmExtensions, err := v.service.VMExtensions()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve vm extensions: %s", err)
+	}
+
+	v.presenter.PresentVMExtensions(vmExtensions)
+	return nil
+}
+
+func (v VMExtensions) Usage() commands.Usage {
+	return commands.Usage{
+		Description:      "This authenticated command lists the staged VM extensions and their cloud properties.",
+		ShortDescription: "lists VM extensions",
+	}
+}
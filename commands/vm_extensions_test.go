@@ -0,0 +1,62 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	jhandacommands "github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VMExtensions", func() {
+	var (
+		service   *fakes.VMExtensionsLister
+		presenter *fakes.Presenter
+	)
+
+	BeforeEach(func() {
+		service = &fakes.VMExtensionsLister{}
+		presenter = &fakes.Presenter{}
+	})
+
+	Describe("Execute", func() {
+		It("presents the vm extensions", func() {
+			vmExtensions := []api.VMExtension{
+				{Name: "some-extension", CloudProperties: json.RawMessage(`{"elbs": ["some-elb"]}`)},
+			}
+			service.VMExtensionsReturns(vmExtensions, nil)
+
+			command := commands.NewVMExtensions(service, presenter)
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(presenter.PresentVMExtensionsCallCount()).To(Equal(1))
+			Expect(presenter.PresentVMExtensionsArgsForCall(0)).To(Equal(vmExtensions))
+		})
+
+		Context("when the vm extensions cannot be retrieved", func() {
+			It("returns an error", func() {
+				service.VMExtensionsReturns(nil, errors.New("some error"))
+
+				command := commands.NewVMExtensions(service, presenter)
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to retrieve vm extensions: some error"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			command := commands.NewVMExtensions(nil, nil)
+			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
+				Description:      "This authenticated command lists the staged VM extensions and their cloud properties.",
+				ShortDescription: "lists VM extensions",
+			}))
+		})
+	})
+})
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/presenters"
+)
+
+type VMTypes struct {
+	service   vmTypesLister
+	presenter presenters.Presenter
+}
+
+//go:generate counterfeiter -o ./fakes/vm_types_lister.go --fake-name VMTypesLister . vmTypesLister
+type vmTypesLister interface {
+	VMTypes() ([]api.VMType, error)
+}
+
+func NewVMTypes(service vmTypesLister, presenter presenters.Presenter) VMTypes {
+	return VMTypes{
+		service:   service,
+		presenter: presenter,
+	}
+}
+
+func (v VMTypes) Execute(args []string) error {
+	vmTypes, err := v.service.VMTypes()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve vm types: %s", err)
+	}
+
+	v.presenter.PresentVMTypes(vmTypes)
+	return nil
+}
+
+func (v VMTypes) Usage() commands.Usage {
+	return commands.Usage{
+		Description:      "This authenticated command lists the built-in and custom VM types with their CPU, RAM and disk.",
+		ShortDescription: "lists VM types",
+	}
+}
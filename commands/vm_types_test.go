@@ -0,0 +1,62 @@
+package commands_test
+
+import (
+	"errors"
+
+	jhandacommands "github.com/pivotal-cf/jhanda/commands"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VMTypes", func() {
+	var (
+		service   *fakes.VMTypesLister
+		presenter *fakes.Presenter
+	)
+
+	BeforeEach(func() {
+		service = &fakes.VMTypesLister{}
+		presenter = &fakes.Presenter{}
+	})
+
+	Describe("Execute", func() {
+		It("presents the vm types", func() {
+			vmTypes := []api.VMType{
+				{Name: "micro", CPU: 1, RAM: 1024, EphemeralDisk: 8192, BuiltIn: true},
+				{Name: "some-custom-type", CPU: 2, RAM: 4096, EphemeralDisk: 32768},
+			}
+			service.VMTypesReturns(vmTypes, nil)
+
+			command := commands.NewVMTypes(service, presenter)
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(presenter.PresentVMTypesCallCount()).To(Equal(1))
+			Expect(presenter.PresentVMTypesArgsForCall(0)).To(Equal(vmTypes))
+		})
+
+		Context("when the vm types cannot be retrieved", func() {
+			It("returns an error", func() {
+				service.VMTypesReturns(nil, errors.New("some error"))
+
+				command := commands.NewVMTypes(service, presenter)
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("failed to retrieve vm types: some error"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			command := commands.NewVMTypes(nil, nil)
+			Expect(command.Usage()).To(Equal(jhandacommands.Usage{
+				Description:      "This authenticated command lists the built-in and custom VM types with their CPU, RAM and disk.",
+				ShortDescription: "lists VM types",
+			}))
+		})
+	})
+})
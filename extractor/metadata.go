@@ -0,0 +1,146 @@
+package extractor
+
+import "fmt"
+
+type Metadata struct {
+	Name                    string              `yaml:"name"`
+	ProductVersion          string              `yaml:"product_version"`
+	Label                   string              `yaml:"label"`
+	StemcellCriteria        StemcellCriteria    `yaml:"stemcell_criteria"`
+	RequiresProductVersions []ProductDependency `yaml:"requires_product_versions"`
+	Releases                []Release           `yaml:"releases"`
+	PropertyBlueprints      []PropertyBlueprint `yaml:"property_blueprints"`
+	JobTypes                []JobType           `yaml:"job_types"`
+	FormTypes               []FormType          `yaml:"form_types"`
+}
+
+type StemcellCriteria struct {
+	OS          string `yaml:"os"`
+	Version     string `yaml:"version"`
+	RequiresCPI bool   `yaml:"requires_cpi"`
+}
+
+type ProductDependency struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+}
+
+type Release struct {
+	Name    string `yaml:"name"`
+	File    string `yaml:"file"`
+	Version string `yaml:"version"`
+	SHA1    string `yaml:"sha1"`
+}
+
+type PropertyBlueprint struct {
+	Name               string              `yaml:"name"`
+	Type               string              `yaml:"type"`
+	Configurable       bool                `yaml:"configurable"`
+	Optional           bool                `yaml:"optional"`
+	Default            interface{}         `yaml:"default"`
+	Options            []PropertyOption    `yaml:"options"`
+	OptionTemplates    []OptionTemplate    `yaml:"option_templates"`
+	PropertyBlueprints []PropertyBlueprint `yaml:"property_blueprints"`
+}
+
+type PropertyOption struct {
+	Name  interface{} `yaml:"name"`
+	Label string      `yaml:"label"`
+}
+
+type OptionTemplate struct {
+	Name               string              `yaml:"name"`
+	SelectValue        string              `yaml:"select_value"`
+	PropertyBlueprints []PropertyBlueprint `yaml:"property_blueprints"`
+}
+
+type JobType struct {
+	Name                string               `yaml:"name"`
+	ResourceLabel       string               `yaml:"resource_label"`
+	Errand              bool                 `yaml:"errand"`
+	ResourceDefinitions []ResourceDefinition `yaml:"resource_definitions"`
+	InstanceDefinition  *ResourceDefinition  `yaml:"instance_definition"`
+	PropertyBlueprints  []PropertyBlueprint  `yaml:"property_blueprints"`
+}
+
+type ResourceDefinition struct {
+	Name         string      `yaml:"name"`
+	Type         string      `yaml:"type"`
+	Configurable bool        `yaml:"configurable"`
+	Default      interface{} `yaml:"default"`
+}
+
+type FormType struct {
+	Name           string          `yaml:"name"`
+	Label          string          `yaml:"label"`
+	PropertyInputs []PropertyInput `yaml:"property_inputs"`
+}
+
+type PropertyInput struct {
+	Reference              string          `yaml:"reference"`
+	Label                  string          `yaml:"label"`
+	Description            string          `yaml:"description"`
+	SelectorPropertyInputs []PropertyInput `yaml:"selector_property_inputs"`
+	PropertyInputs         []PropertyInput `yaml:"property_inputs"`
+}
+
+type ProductProperty struct {
+	Reference string
+	Blueprint PropertyBlueprint
+	Selector  string
+	Option    string
+}
+
+func (p PropertyBlueprint) Required() bool {
+	return p.Configurable && !p.Optional && p.Default == nil
+}
+
+func (p PropertyBlueprint) SelectValues() []string {
+	var values []string
+	for _, option := range p.OptionTemplates {
+		values = append(values, option.selectValue())
+	}
+
+	for _, option := range p.Options {
+		values = append(values, fmt.Sprintf("%v", option.Name))
+	}
+
+	return values
+}
+
+func (o OptionTemplate) selectValue() string {
+	if o.SelectValue != "" {
+		return o.SelectValue
+	}
+
+	return o.Name
+}
+
+func (m Metadata) Properties() []ProductProperty {
+	var properties []ProductProperty
+	properties = appendProperties(properties, ".properties", m.PropertyBlueprints, "", "")
+
+	for _, job := range m.JobTypes {
+		properties = appendProperties(properties, "."+job.Name, job.PropertyBlueprints, "", "")
+	}
+
+	return properties
+}
+
+func appendProperties(properties []ProductProperty, prefix string, blueprints []PropertyBlueprint, selector, option string) []ProductProperty {
+	for _, blueprint := range blueprints {
+		reference := fmt.Sprintf("%s.%s", prefix, blueprint.Name)
+		properties = append(properties, ProductProperty{
+			Reference: reference,
+			Blueprint: blueprint,
+			Selector:  selector,
+			Option:    option,
+		})
+
+		for _, template := range blueprint.OptionTemplates {
+			properties = appendProperties(properties, fmt.Sprintf("%s.%s", reference, template.Name), template.PropertyBlueprints, reference, template.selectValue())
+		}
+	}
+
+	return properties
+}
@@ -0,0 +1,84 @@
+package extractor_test
+
+import (
+	"github.com/pivotal-cf/om/extractor"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Metadata", func() {
+	Describe("Properties", func() {
+		It("flattens product, job and selector properties into references", func() {
+			metadata := extractor.Metadata{
+				PropertyBlueprints: []extractor.PropertyBlueprint{
+					{Name: "some-string", Type: "string"},
+					{
+						Name: "some-selector",
+						Type: "selector",
+						OptionTemplates: []extractor.OptionTemplate{
+							{
+								Name:        "some-option",
+								SelectValue: "Some Option",
+								PropertyBlueprints: []extractor.PropertyBlueprint{
+									{Name: "some-nested", Type: "integer"},
+								},
+							},
+						},
+					},
+				},
+				JobTypes: []extractor.JobType{
+					{
+						Name: "some-job",
+						PropertyBlueprints: []extractor.PropertyBlueprint{
+							{Name: "some-job-property", Type: "boolean"},
+						},
+					},
+				},
+			}
+
+			var references []string
+			for _, property := range metadata.Properties() {
+				references = append(references, property.Reference)
+			}
+
+			Expect(references).To(Equal([]string{
+				".properties.some-string",
+				".properties.some-selector",
+				".properties.some-selector.some-option.some-nested",
+				".some-job.some-job-property",
+			}))
+
+			nested := metadata.Properties()[2]
+			Expect(nested.Selector).To(Equal(".properties.some-selector"))
+			Expect(nested.Option).To(Equal("Some Option"))
+			Expect(nested.Blueprint.Type).To(Equal("integer"))
+		})
+	})
+
+	Describe("Required", func() {
+		It("is true for configurable properties without an optional flag or default", func() {
+			Expect(extractor.PropertyBlueprint{Configurable: true}.Required()).To(BeTrue())
+			Expect(extractor.PropertyBlueprint{Configurable: true, Optional: true}.Required()).To(BeFalse())
+			Expect(extractor.PropertyBlueprint{Configurable: true, Default: "foo"}.Required()).To(BeFalse())
+			Expect(extractor.PropertyBlueprint{}.Required()).To(BeFalse())
+		})
+	})
+
+	Describe("SelectValues", func() {
+		It("lists the values of option templates and dropdown options", func() {
+			selector := extractor.PropertyBlueprint{
+				OptionTemplates: []extractor.OptionTemplate{
+					{Name: "some-name", SelectValue: "some-value"},
+					{Name: "other-name"},
+				},
+			}
+			Expect(selector.SelectValues()).To(Equal([]string{"some-value", "other-name"}))
+
+			dropdown := extractor.PropertyBlueprint{
+				Options: []extractor.PropertyOption{{Name: 1}, {Name: "two"}},
+			}
+			Expect(dropdown.SelectValues()).To(Equal([]string{"1", "two"}))
+		})
+	})
+})
@@ -0,0 +1,239 @@
+package extractor
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+var replicaSuffixRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// ReplicateProduct writes a copy of the product file to outputPath under a new
+// name so that Ops Manager treats it as a distinct product. The product name
+// and job names get "-<suffix>" appended and the label gets " (<suffix>)";
+// references to the renamed jobs elsewhere in the metadata are updated to
+// match, including the errands listed in post_deploy_errands and
+// pre_delete_errands. Entries other than the metadata are copied without being
+// decompressed. It returns the name of the replicated product.
+func (u ProductUnzipper) ReplicateProduct(productPath, outputPath, suffix string) (string, error) {
+	if !replicaSuffixRegexp.MatchString(suffix) {
+		return "", fmt.Errorf("invalid suffix %q: only lowercase letters, digits and hyphens are allowed", suffix)
+	}
+
+	zipReader, err := zip.OpenReader(productPath)
+	if err != nil {
+		return "", err
+	}
+	defer zipReader.Close()
+
+	output, err := os.Create(outputPath)
+	if err != nil {
+		return "", fmt.Errorf("could not create replicated product: %s", err)
+	}
+	defer output.Close()
+
+	zipWriter := zip.NewWriter(output)
+
+	var name string
+	for _, file := range zipReader.File {
+		if matched, _ := regexp.MatchString("metadata/.*\\.yml", file.Name); matched && name == "" {
+			name, err = replicateMetadata(file, zipWriter, suffix)
+		} else {
+			err = copyZipFile(file, zipWriter)
+		}
+
+		if err != nil {
+			os.Remove(outputPath)
+			return "", err
+		}
+	}
+
+	if name == "" {
+		os.Remove(outputPath)
+		return "", fmt.Errorf("no metadata file was found in provided .pivotal")
+	}
+
+	err = zipWriter.Close()
+	if err != nil {
+		os.Remove(outputPath)
+		return "", fmt.Errorf("could not write replicated product: %s", err)
+	}
+
+	return name, nil
+}
+
+func replicateMetadata(file *zip.File, zipWriter *zip.Writer, suffix string) (string, error) {
+	contents, err := readZipFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	var metadata yaml.MapSlice
+	err = yaml.Unmarshal(contents, &metadata)
+	if err != nil {
+		return "", fmt.Errorf("could not extract product metadata: %s", err)
+	}
+
+	var name string
+	jobs := map[string]string{}
+	for i, item := range metadata {
+		switch item.Key {
+		case "name":
+			if original, ok := item.Value.(string); ok && original != "" {
+				name = fmt.Sprintf("%s-%s", original, suffix)
+				metadata[i].Value = name
+				renameProvidedProduct(metadata, original, name)
+			}
+		case "label":
+			if label, ok := item.Value.(string); ok {
+				metadata[i].Value = fmt.Sprintf("%s (%s)", label, suffix)
+			}
+		case "job_types":
+			for _, job := range mapSlices(item.Value) {
+				for j, field := range job {
+					if jobName, ok := field.Value.(string); ok && field.Key == "name" {
+						jobs[jobName] = fmt.Sprintf("%s-%s", jobName, suffix)
+						job[j].Value = jobs[jobName]
+					}
+				}
+			}
+		}
+	}
+
+	if name == "" {
+		return "", fmt.Errorf("could not extract product metadata: could not find product details in metadata file")
+	}
+
+	references := map[*regexp.Regexp]string{}
+	for original, renamed := range jobs {
+		references[regexp.MustCompile(`(^|[^\w.-])\.`+regexp.QuoteMeta(original)+`\.`)] = "${1}." + renamed + "."
+	}
+
+	for i, item := range metadata {
+		switch item.Key {
+		case "name", "label":
+		case "job_types":
+			for _, job := range mapSlices(item.Value) {
+				for j, field := range job {
+					if field.Key != "name" {
+						job[j].Value = renameJobReferences(field.Value, references)
+					}
+				}
+			}
+		case "post_deploy_errands", "pre_delete_errands":
+			for _, errand := range mapSlices(item.Value) {
+				for j, field := range errand {
+					if renamed, ok := jobs[fmt.Sprint(field.Value)]; ok && field.Key == "name" {
+						errand[j].Value = renamed
+					}
+				}
+			}
+		default:
+			metadata[i].Value = renameJobReferences(item.Value, references)
+		}
+	}
+
+	contents, err = yaml.Marshal(metadata)
+	if err != nil {
+		return "", fmt.Errorf("could not write product metadata: %s", err)
+	}
+
+	header := file.FileHeader
+	header.CompressedSize64 = 0
+	header.UncompressedSize64 = 0
+	header.CRC32 = 0
+
+	writer, err := zipWriter.CreateHeader(&header)
+	if err != nil {
+		return "", fmt.Errorf("could not write product metadata: %s", err)
+	}
+
+	_, err = writer.Write(contents)
+	if err != nil {
+		return "", fmt.Errorf("could not write product metadata: %s", err)
+	}
+
+	return name, nil
+}
+
+func renameProvidedProduct(metadata yaml.MapSlice, original, name string) {
+	for _, item := range metadata {
+		if item.Key != "provides_product_versions" {
+			continue
+		}
+
+		for _, product := range mapSlices(item.Value) {
+			for i, field := range product {
+				if field.Key == "name" && field.Value == original {
+					product[i].Value = name
+				}
+			}
+		}
+	}
+}
+
+func mapSlices(value interface{}) []yaml.MapSlice {
+	var slices []yaml.MapSlice
+
+	items, _ := value.([]interface{})
+	for _, item := range items {
+		if slice, ok := item.(yaml.MapSlice); ok {
+			slices = append(slices, slice)
+		}
+	}
+
+	return slices
+}
+
+// renameJobReferences rewrites property references that start with a renamed
+// job, such as ".some-job.some-property" or "(( .some-job.some-property.value ))".
+// References to other products, such as "..cf.some-job.some-property", are left
+// alone.
+func renameJobReferences(value interface{}, references map[*regexp.Regexp]string) interface{} {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		for i, item := range v {
+			v[i].Value = renameJobReferences(item.Value, references)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = renameJobReferences(item, references)
+		}
+		return v
+	case string:
+		for reference, replacement := range references {
+			v = reference.ReplaceAllString(v, replacement)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// copyZipFile copies a file with its header, recompressing it with the same
+// method. Copying the compressed bytes as they are would need Go 1.17.
+func copyZipFile(file *zip.File, zipWriter *zip.Writer) error {
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("could not read %s: %s", file.Name, err)
+	}
+	defer reader.Close()
+
+	header := file.FileHeader
+	writer, err := zipWriter.CreateHeader(&header)
+	if err != nil {
+		return fmt.Errorf("could not write %s: %s", file.Name, err)
+	}
+
+	_, err = io.Copy(writer, reader)
+	if err != nil {
+		return fmt.Errorf("could not write %s: %s", file.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package extractor_test
+
+import (
+	"archive/zip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pivotal-cf/om/extractor"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReplicateProduct", func() {
+	var (
+		unzipper    extractor.ProductUnzipper
+		productFile *os.File
+		outputDir   string
+	)
+
+	createProduct := func(metadata string) {
+		var err error
+		productFile, err = ioutil.TempFile("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		zipper := zip.NewWriter(productFile)
+
+		writer, err := zipper.Create("metadata/some-product.yml")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = io.WriteString(writer, metadata)
+		Expect(err).NotTo(HaveOccurred())
+
+		writer, err = zipper.Create("releases/some-release.tgz")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = io.WriteString(writer, "some-release-contents")
+		Expect(err).NotTo(HaveOccurred())
+
+		err = zipper.Close()
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	readProduct := func(path string) map[string]string {
+		zipReader, err := zip.OpenReader(path)
+		Expect(err).NotTo(HaveOccurred())
+		defer zipReader.Close()
+
+		files := map[string]string{}
+		for _, file := range zipReader.File {
+			reader, err := file.Open()
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := ioutil.ReadAll(reader)
+			Expect(err).NotTo(HaveOccurred())
+			reader.Close()
+
+			files[file.Name] = string(contents)
+		}
+
+		return files
+	}
+
+	BeforeEach(func() {
+		unzipper = extractor.ProductUnzipper{}
+
+		var err error
+		outputDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		createProduct(`---
+name: some-product
+product_version: 1.0.0
+label: Some Product
+provides_product_versions:
+- name: some-product
+  version: 1.0.0
+post_deploy_errands:
+- name: some-errand
+property_blueprints:
+- name: some-router
+  type: string
+form_types:
+- name: some-form
+  property_inputs:
+  - reference: .some-router.some-property
+  - reference: .properties.some-router
+job_types:
+- name: some-router
+  manifest: |
+    address: (( .some-router.some-property.value ))
+    cf_router: (( ..cf.some-router.ips ))
+  property_blueprints:
+  - name: some-property
+    type: string
+- name: some-errand
+  errand: true
+`)
+	})
+
+	AfterEach(func() {
+		os.Remove(productFile.Name())
+		os.RemoveAll(outputDir)
+	})
+
+	It("writes a copy of the product with the name, label and jobs renamed", func() {
+		outputPath := filepath.Join(outputDir, "replica.pivotal")
+
+		name, err := unzipper.ReplicateProduct(productFile.Name(), outputPath, "blue")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(name).To(Equal("some-product-blue"))
+
+		files := readProduct(outputPath)
+		Expect(files).To(HaveLen(2))
+		Expect(files["releases/some-release.tgz"]).To(Equal("some-release-contents"))
+		Expect(files["metadata/some-product.yml"]).To(MatchYAML(`---
+name: some-product-blue
+product_version: 1.0.0
+label: Some Product (blue)
+provides_product_versions:
+- name: some-product-blue
+  version: 1.0.0
+post_deploy_errands:
+- name: some-errand-blue
+property_blueprints:
+- name: some-router
+  type: string
+form_types:
+- name: some-form
+  property_inputs:
+  - reference: .some-router-blue.some-property
+  - reference: .properties.some-router
+job_types:
+- name: some-router-blue
+  manifest: |
+    address: (( .some-router-blue.some-property.value ))
+    cf_router: (( ..cf.some-router.ips ))
+  property_blueprints:
+  - name: some-property
+    type: string
+- name: some-errand-blue
+  errand: true
+`))
+
+		metadata, err := unzipper.ExtractProductMetadata(outputPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(metadata.Name).To(Equal("some-product-blue"))
+	})
+
+	Context("failure cases", func() {
+		Context("when the suffix is not valid", func() {
+			It("returns an error", func() {
+				_, err := unzipper.ReplicateProduct(productFile.Name(), filepath.Join(outputDir, "replica.pivotal"), "Blue Green")
+				Expect(err).To(MatchError(`invalid suffix "Blue Green": only lowercase letters, digits and hyphens are allowed`))
+			})
+		})
+
+		Context("when the product file does not exist", func() {
+			It("returns an error", func() {
+				_, err := unzipper.ReplicateProduct("/not/a/real/file.pivotal", filepath.Join(outputDir, "replica.pivotal"), "blue")
+				Expect(err).To(MatchError(ContainSubstring("no such file or directory")))
+			})
+		})
+
+		Context("when the output file cannot be created", func() {
+			It("returns an error", func() {
+				_, err := unzipper.ReplicateProduct(productFile.Name(), "/not/a/real/dir/replica.pivotal", "blue")
+				Expect(err).To(MatchError(ContainSubstring("could not create replicated product:")))
+			})
+		})
+
+		Context("when the metadata has no product name", func() {
+			It("returns an error and removes the output file", func() {
+				os.Remove(productFile.Name())
+				createProduct("label: Some Product")
+
+				outputPath := filepath.Join(outputDir, "replica.pivotal")
+				_, err := unzipper.ReplicateProduct(productFile.Name(), outputPath, "blue")
+				Expect(err).To(MatchError("could not extract product metadata: could not find product details in metadata file"))
+
+				_, err = os.Stat(outputPath)
+				Expect(os.IsNotExist(err)).To(BeTrue())
+			})
+		})
+	})
+})
@@ -12,11 +12,52 @@ import (
 type ProductUnzipper struct{}
 
 func (u ProductUnzipper) ExtractMetadata(productPath string) (string, string, error) {
-	zipReader, err := zip.OpenReader(productPath)
+	contents, err := u.readMetadata(productPath)
 	if err != nil {
 		return "", "", err
 	}
 
+	var metadata struct {
+		Name           string
+		ProductVersion string `yaml:"product_version"`
+	}
+	err = yaml.Unmarshal(contents, &metadata)
+	if err != nil {
+		return "", "", fmt.Errorf("could not extract product metadata: %s", err)
+	}
+
+	if metadata.Name == "" || metadata.ProductVersion == "" {
+		return "", "", errors.New("could not extract product metadata: could not find product details in metadata file")
+	}
+
+	return metadata.Name, metadata.ProductVersion, nil
+}
+
+func (u ProductUnzipper) ExtractProductMetadata(productPath string) (Metadata, error) {
+	contents, err := u.readMetadata(productPath)
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	var metadata Metadata
+	err = yaml.Unmarshal(contents, &metadata)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("could not extract product metadata: %s", err)
+	}
+
+	if metadata.Name == "" || metadata.ProductVersion == "" {
+		return Metadata{}, errors.New("could not extract product metadata: could not find product details in metadata file")
+	}
+
+	return metadata, nil
+}
+
+func (u ProductUnzipper) readMetadata(productPath string) ([]byte, error) {
+	zipReader, err := zip.OpenReader(productPath)
+	if err != nil {
+		return nil, err
+	}
+
 	defer zipReader.Close()
 
 	for _, file := range zipReader.File {
@@ -25,30 +66,13 @@ func (u ProductUnzipper) ExtractMetadata(productPath string) (string, string, er
 		if matched {
 			metadataFile, err := file.Open()
 			if err != nil {
-				return "", "", err
-			}
-
-			contents, err := ioutil.ReadAll(metadataFile)
-			if err != nil {
-				return "", "", err
-			}
-
-			var metadata struct {
-				Name           string
-				ProductVersion string `yaml:"product_version"`
-			}
-			err = yaml.Unmarshal(contents, &metadata)
-			if err != nil {
-				return "", "", fmt.Errorf("could not extract product metadata: %s", err)
-			}
-
-			if metadata.Name == "" || metadata.ProductVersion == "" {
-				return "", "", errors.New("could not extract product metadata: could not find product details in metadata file")
+				return nil, err
 			}
+			defer metadataFile.Close()
 
-			return metadata.Name, metadata.ProductVersion, nil
+			return ioutil.ReadAll(metadataFile)
 		}
 	}
 
-	return "", "", errors.New("no metadata file was found in provided .pivotal")
+	return nil, errors.New("no metadata file was found in provided .pivotal")
 }
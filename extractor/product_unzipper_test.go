@@ -16,7 +16,26 @@ const (
 	validYAML = `
 ---
 product_version: 1.8.14
-name: some-product`
+name: some-product
+label: Some Product
+stemcell_criteria:
+  os: ubuntu-trusty
+  version: "3468"
+requires_product_versions:
+- name: cf
+  version: ~> 1.12
+property_blueprints:
+- name: some-property
+  type: string
+  configurable: true
+job_types:
+- name: some-job
+  resource_label: Some Job
+  instance_definition:
+    name: instances
+    type: integer
+    configurable: true
+    default: 1`
 )
 
 var _ = Describe("Product Unzipper", func() {
@@ -55,6 +74,79 @@ var _ = Describe("Product Unzipper", func() {
 		os.Remove(productFile.Name())
 	})
 
+	Describe("ExtractProductMetadata", func() {
+		It("extracts the full metadata from the given pivotal file", func() {
+			metadata, err := unzipper.ExtractProductMetadata(productFile.Name())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(metadata).To(Equal(extractor.Metadata{
+				Name:           "some-product",
+				ProductVersion: "1.8.14",
+				Label:          "Some Product",
+				StemcellCriteria: extractor.StemcellCriteria{
+					OS:      "ubuntu-trusty",
+					Version: "3468",
+				},
+				RequiresProductVersions: []extractor.ProductDependency{
+					{Name: "cf", Version: "~> 1.12"},
+				},
+				PropertyBlueprints: []extractor.PropertyBlueprint{
+					{Name: "some-property", Type: "string", Configurable: true},
+				},
+				JobTypes: []extractor.JobType{
+					{
+						Name:          "some-job",
+						ResourceLabel: "Some Job",
+						InstanceDefinition: &extractor.ResourceDefinition{
+							Name:         "instances",
+							Type:         "integer",
+							Configurable: true,
+							Default:      1,
+						},
+					},
+				},
+			}))
+		})
+
+		Context("when an error occurs", func() {
+			Context("when the product tarball does not exist", func() {
+				It("returns an error", func() {
+					_, err := unzipper.ExtractProductMetadata("fake-file")
+					Expect(err).To(MatchError(ContainSubstring("no such file or directory")))
+				})
+			})
+
+			Context("when the metadata file does not contain product name or version", func() {
+				var badProductFile *os.File
+
+				BeforeEach(func() {
+					var err error
+					badProductFile, err = ioutil.TempFile("", "")
+					Expect(err).NotTo(HaveOccurred())
+
+					zipper := zip.NewWriter(badProductFile)
+					productWriter, err := zipper.Create("./metadata/some-product.yml")
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = io.WriteString(productWriter, `foo: bar`)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = zipper.Close()
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				AfterEach(func() {
+					os.Remove(badProductFile.Name())
+				})
+
+				It("returns an error", func() {
+					_, err := unzipper.ExtractProductMetadata(badProductFile.Name())
+					Expect(err).To(MatchError("could not extract product metadata: could not find product details in metadata file"))
+				})
+			})
+		})
+	})
+
 	Describe("ExtractMetadata", func() {
 		It("Extracts the product name and version from the given pivotal file", func() {
 			name, version, err := unzipper.ExtractMetadata(productFile.Name())
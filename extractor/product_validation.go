@@ -0,0 +1,165 @@
+package extractor
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"regexp"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+var requiredMetadataKeys = []string{
+	"name",
+	"product_version",
+	"metadata_version",
+	"label",
+	"stemcell_criteria",
+	"releases",
+	"job_types",
+}
+
+// ValidateProduct checks a product file without uploading it. It returns
+// every problem found in the metadata and the releases it references; an
+// error is only returned when the product file itself cannot be read.
+func (u ProductUnzipper) ValidateProduct(productPath string) ([]string, error) {
+	zipReader, err := zip.OpenReader(productPath)
+	if err != nil {
+		return nil, err
+	}
+	defer zipReader.Close()
+
+	files := map[string]*zip.File{}
+	var metadataFile *zip.File
+	for _, file := range zipReader.File {
+		files[path.Clean(file.Name)] = file
+
+		if metadataFile == nil {
+			if matched, _ := regexp.MatchString("metadata/.*\\.yml", file.Name); matched {
+				metadataFile = file
+			}
+		}
+	}
+
+	if metadataFile == nil {
+		return []string{"no metadata file was found in provided .pivotal"}, nil
+	}
+
+	contents, err := readZipFile(metadataFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys map[string]interface{}
+	err = yaml.Unmarshal(contents, &keys)
+	if err != nil {
+		return []string{fmt.Sprintf("metadata is not valid YAML: %s", err)}, nil
+	}
+
+	var metadata Metadata
+	err = yaml.Unmarshal(contents, &metadata)
+	if err != nil {
+		return []string{fmt.Sprintf("metadata does not match the tile schema: %s", err)}, nil
+	}
+
+	var problems []string
+	for _, key := range requiredMetadataKeys {
+		if _, ok := keys[key]; !ok {
+			problems = append(problems, fmt.Sprintf("metadata is missing required key %q", key))
+		}
+	}
+
+	problems = append(problems, duplicates("release", releaseNames(metadata.Releases))...)
+	problems = append(problems, duplicates("job", jobNames(metadata.JobTypes))...)
+
+	var references []string
+	for _, property := range metadata.Properties() {
+		references = append(references, property.Reference)
+	}
+	problems = append(problems, duplicates("property", references)...)
+
+	for _, release := range metadata.Releases {
+		problems = append(problems, validateRelease(release, files)...)
+	}
+
+	return problems, nil
+}
+
+func validateRelease(release Release, files map[string]*zip.File) []string {
+	if release.File == "" {
+		return []string{fmt.Sprintf("release %s: no file is listed in the metadata", release.Name)}
+	}
+
+	file, ok := files[path.Join("releases", release.File)]
+	if !ok {
+		return []string{fmt.Sprintf("release %s: releases/%s is missing from the product file", release.Name, release.File)}
+	}
+
+	if release.SHA1 == "" {
+		return nil
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return []string{fmt.Sprintf("release %s: could not read releases/%s: %s", release.Name, release.File, err)}
+	}
+	defer reader.Close()
+
+	hash := sha1.New()
+	_, err = io.Copy(hash, reader)
+	if err != nil {
+		return []string{fmt.Sprintf("release %s: could not read releases/%s: %s", release.Name, release.File, err)}
+	}
+
+	sum := fmt.Sprintf("%x", hash.Sum(nil))
+	if sum != release.SHA1 {
+		return []string{fmt.Sprintf("release %s: sha1 of releases/%s is %s, metadata expects %s", release.Name, release.File, sum, release.SHA1)}
+	}
+
+	return nil
+}
+
+func duplicates(kind string, names []string) []string {
+	var problems []string
+
+	seen := map[string]int{}
+	for _, name := range names {
+		seen[name]++
+		if seen[name] == 2 {
+			problems = append(problems, fmt.Sprintf("%s %q is defined more than once", kind, name))
+		}
+	}
+
+	return problems
+}
+
+func releaseNames(releases []Release) []string {
+	var names []string
+	for _, release := range releases {
+		names = append(names, release.Name)
+	}
+
+	return names
+}
+
+func jobNames(jobs []JobType) []string {
+	var names []string
+	for _, job := range jobs {
+		names = append(names, job.Name)
+	}
+
+	return names
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return ioutil.ReadAll(reader)
+}
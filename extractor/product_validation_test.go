@@ -0,0 +1,166 @@
+package extractor_test
+
+import (
+	"archive/zip"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/pivotal-cf/om/extractor"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateProduct", func() {
+	var (
+		unzipper    extractor.ProductUnzipper
+		productFile *os.File
+	)
+
+	createProduct := func(files map[string]string) {
+		var err error
+		productFile, err = ioutil.TempFile("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		zipper := zip.NewWriter(productFile)
+		for name, contents := range files {
+			writer, err := zipper.Create(name)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = io.WriteString(writer, contents)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		err = zipper.Close()
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		unzipper = extractor.ProductUnzipper{}
+	})
+
+	AfterEach(func() {
+		os.Remove(productFile.Name())
+	})
+
+	It("reports no problems for a valid product", func() {
+		createProduct(map[string]string{
+			"metadata/some-product.yml": `---
+name: some-product
+product_version: 1.0.0
+metadata_version: "2.0"
+label: Some Product
+stemcell_criteria:
+  os: ubuntu-trusty
+  version: "3468"
+releases:
+- name: some-release
+  file: some-release-1.0.0.tgz
+  version: 1.0.0
+  sha1: 62798a2ca629640fe2c494e53bf265367cd8c72d
+- name: other-release
+  file: other-release-2.0.0.tgz
+  version: 2.0.0
+job_types:
+- name: some-job
+  property_blueprints:
+  - name: some-property
+    type: string
+property_blueprints:
+- name: some-property
+  type: string`,
+			"releases/some-release-1.0.0.tgz":  "some-release-contents",
+			"releases/other-release-2.0.0.tgz": "other-release-contents",
+		})
+
+		problems, err := unzipper.ValidateProduct(productFile.Name())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("reports every problem found in the product", func() {
+		createProduct(map[string]string{
+			"metadata/some-product.yml": `---
+name: some-product
+product_version: 1.0.0
+releases:
+- name: some-release
+  file: some-release-1.0.0.tgz
+  sha1: 0000000000000000000000000000000000000000
+- name: missing-release
+  file: missing-release-1.0.0.tgz
+- name: missing-release
+- name: unlisted-release
+job_types:
+- name: some-job
+- name: some-job
+property_blueprints:
+- name: some-property
+  type: string
+- name: some-property
+  type: integer`,
+			"releases/some-release-1.0.0.tgz": "some-release-contents",
+		})
+
+		problems, err := unzipper.ValidateProduct(productFile.Name())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(problems).To(Equal([]string{
+			`metadata is missing required key "metadata_version"`,
+			`metadata is missing required key "label"`,
+			`metadata is missing required key "stemcell_criteria"`,
+			`release "missing-release" is defined more than once`,
+			`job "some-job" is defined more than once`,
+			`property ".properties.some-property" is defined more than once`,
+			"release some-release: sha1 of releases/some-release-1.0.0.tgz is 62798a2ca629640fe2c494e53bf265367cd8c72d, metadata expects 0000000000000000000000000000000000000000",
+			"release missing-release: releases/missing-release-1.0.0.tgz is missing from the product file",
+			"release missing-release: no file is listed in the metadata",
+			"release unlisted-release: no file is listed in the metadata",
+		}))
+	})
+
+	Context("when the metadata is not valid YAML", func() {
+		It("reports the problem", func() {
+			createProduct(map[string]string{
+				"metadata/some-product.yml": "%%%",
+			})
+
+			problems, err := unzipper.ValidateProduct(productFile.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(problems).To(ConsistOf(ContainSubstring("metadata is not valid YAML:")))
+		})
+	})
+
+	Context("when the metadata does not match the tile schema", func() {
+		It("reports the problem", func() {
+			createProduct(map[string]string{
+				"metadata/some-product.yml": "job_types: some-job",
+			})
+
+			problems, err := unzipper.ValidateProduct(productFile.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(problems).To(ConsistOf(ContainSubstring("metadata does not match the tile schema:")))
+		})
+	})
+
+	Context("when there is no metadata file", func() {
+		It("reports the problem", func() {
+			createProduct(map[string]string{
+				"releases/some-release.tgz": "some-release-contents",
+			})
+
+			problems, err := unzipper.ValidateProduct(productFile.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(problems).To(Equal([]string{"no metadata file was found in provided .pivotal"}))
+		})
+	})
+
+	Context("when the product file cannot be opened", func() {
+		It("returns an error", func() {
+			createProduct(map[string]string{})
+
+			_, err := unzipper.ValidateProduct("/not/a/real/file.pivotal")
+			Expect(err).To(MatchError(ContainSubstring("no such file or directory")))
+		})
+	})
+})
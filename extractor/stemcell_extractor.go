@@ -0,0 +1,78 @@
+package extractor
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+type StemcellManifest struct {
+	Name            string                  `yaml:"name"`
+	Version         string                  `yaml:"version"`
+	OperatingSystem string                  `yaml:"operating_system"`
+	CloudProperties StemcellCloudProperties `yaml:"cloud_properties"`
+}
+
+type StemcellCloudProperties struct {
+	Infrastructure string `yaml:"infrastructure"`
+}
+
+type StemcellExtractor struct{}
+
+func (s StemcellExtractor) ExtractStemcellManifest(stemcellPath string) (StemcellManifest, error) {
+	stemcellFile, err := os.Open(stemcellPath)
+	if err != nil {
+		return StemcellManifest{}, err
+	}
+
+	defer stemcellFile.Close()
+
+	gzipReader, err := gzip.NewReader(stemcellFile)
+	if err != nil {
+		return StemcellManifest{}, fmt.Errorf("could not extract stemcell manifest: %s", err)
+	}
+
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return StemcellManifest{}, fmt.Errorf("could not extract stemcell manifest: %s", err)
+		}
+
+		if filepath.Base(header.Name) != "stemcell.MF" {
+			continue
+		}
+
+		contents, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			return StemcellManifest{}, fmt.Errorf("could not extract stemcell manifest: %s", err)
+		}
+
+		var manifest StemcellManifest
+		err = yaml.Unmarshal(contents, &manifest)
+		if err != nil {
+			return StemcellManifest{}, fmt.Errorf("could not extract stemcell manifest: %s", err)
+		}
+
+		if manifest.Name == "" || manifest.Version == "" {
+			return StemcellManifest{}, errors.New("could not extract stemcell manifest: could not find stemcell details in manifest file")
+		}
+
+		return manifest, nil
+	}
+
+	return StemcellManifest{}, errors.New("no stemcell.MF file was found in provided stemcell")
+}
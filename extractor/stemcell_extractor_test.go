@@ -0,0 +1,131 @@
+package extractor_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+
+	"github.com/pivotal-cf/om/extractor"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func writeStemcell(files map[string]string) string {
+	stemcellFile, err := ioutil.TempFile("", "stemcell.tgz")
+	Expect(err).NotTo(HaveOccurred())
+	defer stemcellFile.Close()
+
+	gzipWriter := gzip.NewWriter(stemcellFile)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for name, contents := range files {
+		err = tarWriter.WriteHeader(&tar.Header{
+			Name: name,
+			Mode: 0644,
+			Size: int64(len(contents)),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = tarWriter.Write([]byte(contents))
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	Expect(tarWriter.Close()).To(Succeed())
+	Expect(gzipWriter.Close()).To(Succeed())
+
+	return stemcellFile.Name()
+}
+
+var _ = Describe("StemcellExtractor", func() {
+	var (
+		stemcellExtractor extractor.StemcellExtractor
+		stemcellPath      string
+	)
+
+	AfterEach(func() {
+		os.Remove(stemcellPath)
+	})
+
+	Describe("ExtractStemcellManifest", func() {
+		It("extracts the manifest from the given stemcell", func() {
+			stemcellPath = writeStemcell(map[string]string{
+				"./image": "some-image",
+				"./stemcell.MF": `---
+name: bosh-vsphere-esxi-ubuntu-trusty-go_agent
+version: 3445.11
+bosh_protocol: 1
+sha1: some-sha
+operating_system: ubuntu-trusty
+cloud_properties:
+  infrastructure: vsphere
+  hypervisor: esxi
+`,
+			})
+
+			manifest, err := stemcellExtractor.ExtractStemcellManifest(stemcellPath)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(manifest).To(Equal(extractor.StemcellManifest{
+				Name:            "bosh-vsphere-esxi-ubuntu-trusty-go_agent",
+				Version:         "3445.11",
+				OperatingSystem: "ubuntu-trusty",
+				CloudProperties: extractor.StemcellCloudProperties{
+					Infrastructure: "vsphere",
+				},
+			}))
+		})
+
+		Context("failure cases", func() {
+			Context("when the stemcell does not exist", func() {
+				It("returns an error", func() {
+					_, err := stemcellExtractor.ExtractStemcellManifest("/not/a/real/stemcell.tgz")
+					Expect(err).To(MatchError(ContainSubstring("no such file or directory")))
+				})
+			})
+
+			Context("when the stemcell is not a gzipped tarball", func() {
+				It("returns an error", func() {
+					stemcellFile, err := ioutil.TempFile("", "stemcell.tgz")
+					Expect(err).NotTo(HaveOccurred())
+					stemcellPath = stemcellFile.Name()
+
+					_, err = stemcellFile.WriteString("not a tarball")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(stemcellFile.Close()).To(Succeed())
+
+					_, err = stemcellExtractor.ExtractStemcellManifest(stemcellPath)
+					Expect(err).To(MatchError(ContainSubstring("could not extract stemcell manifest:")))
+				})
+			})
+
+			Context("when the stemcell has no manifest", func() {
+				It("returns an error", func() {
+					stemcellPath = writeStemcell(map[string]string{"./image": "some-image"})
+
+					_, err := stemcellExtractor.ExtractStemcellManifest(stemcellPath)
+					Expect(err).To(MatchError("no stemcell.MF file was found in provided stemcell"))
+				})
+			})
+
+			Context("when the manifest is not valid YAML", func() {
+				It("returns an error", func() {
+					stemcellPath = writeStemcell(map[string]string{"./stemcell.MF": "%%%"})
+
+					_, err := stemcellExtractor.ExtractStemcellManifest(stemcellPath)
+					Expect(err).To(MatchError(ContainSubstring("could not extract stemcell manifest:")))
+				})
+			})
+
+			Context("when the manifest is missing the name or version", func() {
+				It("returns an error", func() {
+					stemcellPath = writeStemcell(map[string]string{"./stemcell.MF": "name: some-stemcell"})
+
+					_, err := stemcellExtractor.ExtractStemcellManifest(stemcellPath)
+					Expect(err).To(MatchError("could not extract stemcell manifest: could not find stemcell details in manifest file"))
+				})
+			})
+		})
+	})
+})
@@ -18,6 +18,7 @@ import (
 	"github.com/pivotal-cf/om/network"
 	"github.com/pivotal-cf/om/presenters"
 	"github.com/pivotal-cf/om/progress"
+	"github.com/pivotal-cf/om/uploads"
 )
 
 var version = "unknown"
@@ -123,12 +124,16 @@ func main() {
 	certificateAuthoritiesService := api.NewCertificateAuthoritiesService(authedClient)
 	certificatesService := api.NewCertificatesService(authedClient)
 	directorService := api.NewDirectorService(authedClient)
+	stemcellAssignmentsService := api.NewStemcellAssignmentsService(authedClient)
+	vmTypesService := api.NewVMTypesService(authedClient)
+	verifiersService := api.NewVerifiersService(authedClient)
 
 	form, err := formcontent.NewForm()
 	if err != nil {
 		stdout.Fatal(err)
 	}
 
+	stemcellExtractor := extractor.StemcellExtractor{}
 	extractor := extractor.ProductUnzipper{}
 
 	var presenter presenters.Presenter
@@ -146,27 +151,29 @@ func main() {
 	commandSet["version"] = commands.NewVersion(version, os.Stdout)
 	commandSet["configure-authentication"] = commands.NewConfigureAuthentication(setupService, stdout)
 	commandSet["configure-bosh"] = commands.NewConfigureBosh(boshService, diagnosticService, stdout)
-	commandSet["revert-staged-changes"] = commands.NewRevertStagedChanges(dashboardService, stdout)
-	commandSet["upload-stemcell"] = commands.NewUploadStemcell(form, uploadStemcellService, diagnosticService, stdout)
-	commandSet["upload-product"] = commands.NewUploadProduct(form, extractor, availableProductsService, stdout)
-	commandSet["delete-unused-products"] = commands.NewDeleteUnusedProducts(availableProductsService, stdout)
-	commandSet["stage-product"] = commands.NewStageProduct(stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, stdout)
+	commandSet["revert-staged-changes"] = commands.NewRevertStagedChanges(pendingChangesService, dashboardService, stagedProductsService, deployedProductsService, stdout)
+	commandSet["upload-stemcell"] = commands.NewUploadStemcell(uploads.NewForm, uploadStemcellService, diagnosticService, stemcellAssignmentsService, stemcellExtractor, uploads.NewStemcellPool(authedClient, progress.NewPool()), stdout)
+	commandSet["upload-product"] = commands.NewUploadProduct(uploads.NewForm, extractor, availableProductsService, uploads.NewProductPool(authedClient, progress.NewPool()), stdout)
+	commandSet["delete-unused-products"] = commands.NewDeleteUnusedProducts(availableProductsService, diagnosticService, stdout)
+	commandSet["stage-product"] = commands.NewStageProduct(stagedProductsService, deployedProductsService, availableProductsService, diagnosticService, extractor, stdout)
 	commandSet["unstage-product"] = commands.NewUnstageProduct(stagedProductsService, stdout)
-	commandSet["configure-product"] = commands.NewConfigureProduct(stagedProductsService, jobsService, stdout)
+	commandSet["configure-product"] = commands.NewConfigureProduct(stagedProductsService, jobsService, errandsService, extractor, stdout)
 	commandSet["export-installation"] = commands.NewExportInstallation(exportInstallationService, stdout)
 	commandSet["import-installation"] = commands.NewImportInstallation(form, importInstallationService, setupService, stdout)
 	commandSet["delete-installation"] = commands.NewDeleteInstallation(deleteInstallationService, installationsService, logWriter, stdout, applySleepSeconds)
-	commandSet["apply-changes"] = commands.NewApplyChanges(installationsService, logWriter, stdout, applySleepSeconds)
+	commandSet["apply-changes"] = commands.NewApplyChanges(installationsService, verifiersService, stagedProductsService, logWriter, stdout, applySleepSeconds)
 	commandSet["curl"] = commands.NewCurl(requestService, stdout, stderr)
 	commandSet["available-products"] = commands.NewAvailableProducts(availableProductsService, presenter, stdout)
 	commandSet["errands"] = commands.NewErrands(presenter, errandsService, stagedProductsService)
+	commandSet["staged-config"] = commands.NewStagedConfig(stagedProductsService, jobsService, errandsService, stdout)
 	commandSet["set-errand-state"] = commands.NewSetErrandState(errandsService, stagedProductsService)
 	commandSet["credential-references"] = commands.NewCredentialReferences(credentialReferencesService, deployedProductsService, presenter, stdout)
 	commandSet["credentials"] = commands.NewCredentials(credentialsService, deployedProductsService, presenter, stdout)
+	commandSet["products"] = commands.NewProducts(presenter, availableProductsService, stagedProductsService, diagnosticService)
 	commandSet["staged-products"] = commands.NewStagedProducts(presenter, diagnosticService)
 	commandSet["deployed-products"] = commands.NewDeployedProducts(presenter, diagnosticService)
 	commandSet["delete-product"] = commands.NewDeleteProduct(availableProductsService)
-	commandSet["pending-changes"] = commands.NewPendingChanges(presenter, pendingChangesService)
+	commandSet["pending-changes"] = commands.NewPendingChanges(presenter, pendingChangesService, stagedProductsService, diagnosticService)
 	commandSet["installations"] = commands.NewInstallations(installationsService, presenter)
 	commandSet["installation-log"] = commands.NewInstallationLog(installationsService, stdout)
 	commandSet["certificate-authorities"] = commands.NewCertificateAuthorities(certificateAuthoritiesService, presenter)
@@ -176,7 +183,24 @@ func main() {
 	commandSet["create-certificate-authority"] = commands.NewCreateCertificateAuthority(certificateAuthoritiesService, presenter)
 	commandSet["activate-certificate-authority"] = commands.NewActivateCertificateAuthority(certificateAuthoritiesService, stdout)
 	commandSet["delete-certificate-authority"] = commands.NewDeleteCertificateAuthority(certificateAuthoritiesService, stdout)
-	commandSet["configure-director"] = commands.NewConfigureDirector(directorService, stdout)
+	commandSet["configure-director"] = commands.NewConfigureDirector(directorService, jobsService, stagedProductsService, vmTypesService, stdout)
+	commandSet["iaas-configurations"] = commands.NewIAASConfigurations(directorService, presenter)
+	commandSet["config-template"] = commands.NewConfigTemplate(extractor, stdout)
+	commandSet["diff-product-metadata"] = commands.NewDiffProductMetadata(extractor, stdout)
+	commandSet["validate-config"] = commands.NewValidateConfig(extractor, stdout)
+	commandSet["validate-product"] = commands.NewValidateProduct(extractor, stdout)
+	commandSet["replicate-product"] = commands.NewReplicateProduct(extractor, stdout)
+	commandSet["stemcell-assignments"] = commands.NewStemcellAssignments(presenter, stemcellAssignmentsService)
+	commandSet["assign-stemcell"] = commands.NewAssignStemcell(stemcellAssignmentsService, stdout)
+	commandSet["converge"] = commands.NewConverge(commandSet, diagnosticService, stagedProductsService, stemcellAssignmentsService, availableProductsService, extractor, stemcellExtractor, stdout)
+	commandSet["vm-types"] = commands.NewVMTypes(vmTypesService, presenter)
+	commandSet["configure-vm-types"] = commands.NewConfigureVMTypes(vmTypesService, stdout)
+	commandSet["vm-extensions"] = commands.NewVMExtensions(vmTypesService, presenter)
+	commandSet["create-vm-extension"] = commands.NewCreateVMExtension(vmTypesService, stdout)
+	commandSet["delete-vm-extension"] = commands.NewDeleteVMExtension(vmTypesService, stdout)
+	commandSet["migrate-bosh-config"] = commands.NewMigrateBoshConfig(stdout, stderr)
+	commandSet["verifiers"] = commands.NewVerifiers(verifiersService, stagedProductsService, presenter)
+	commandSet["run-verifiers"] = commands.NewRunVerifiers(verifiersService, stagedProductsService, presenter)
 
 	err = commandSet.Execute(command, args)
 	if err != nil {
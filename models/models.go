@@ -20,3 +20,35 @@ type Errand struct {
 	PostDeployEnabled string `json:"post_deploy_enabled,omitempty"`
 	PreDeleteEnabled  string `json:"pre_delete_enabled,omitempty"`
 }
+
+type ProductInventory struct {
+	Name              string   `json:"name"`
+	GUID              string   `json:"guid,omitempty"`
+	AvailableVersions []string `json:"available_versions"`
+	StagedVersion     string   `json:"staged_version,omitempty"`
+	DeployedVersion   string   `json:"deployed_version,omitempty"`
+	Stemcell          string   `json:"stemcell,omitempty"`
+	PendingUpgrade    bool     `json:"pending_upgrade"`
+}
+
+type PendingChange struct {
+	GUID     string                `json:"guid"`
+	Name     string                `json:"name"`
+	Version  string                `json:"version,omitempty"`
+	Action   string                `json:"action"`
+	Errands  []PendingChangeErrand `json:"errands"`
+	Complete bool                  `json:"complete"`
+	Problems []string              `json:"problems,omitempty"`
+}
+
+type PendingChangeErrand struct {
+	Name       string      `json:"name"`
+	PostDeploy interface{} `json:"post_deploy,omitempty"`
+	PreDelete  interface{} `json:"pre_delete,omitempty"`
+}
+
+type VerifierResult struct {
+	Type     string   `json:"type"`
+	Status   string   `json:"status"`
+	Messages []string `json:"messages,omitempty"`
+}
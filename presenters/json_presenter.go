@@ -60,24 +60,73 @@ func (j JSONPresenter) PresentCertificateAuthority(certificateAuthority api.CA)
 	})
 }
 
+// PresentIAASConfigurations presents the whole configurations, in the form that
+// the iaas-configurations of configure-director accepts.
+func (j JSONPresenter) PresentIAASConfigurations(configurations []api.IAASConfiguration) {
+	properties := []json.RawMessage{}
+	for _, configuration := range configurations {
+		properties = append(properties, configuration.Properties)
+	}
+
+	j.encodeJSON(&map[string][]json.RawMessage{
+		"iaas_configurations": properties,
+	})
+}
+
 func (j JSONPresenter) PresentInstallations(installations []models.Installation) {
 	j.encodeJSON(&map[string][]models.Installation{
 		"installations": installations,
 	})
 }
 
-func (j JSONPresenter) PresentPendingChanges(pendingChanges []api.ProductChange) {
-	j.encodeJSON(&map[string][]api.ProductChange{
+func (j JSONPresenter) PresentPendingChanges(pendingChanges []models.PendingChange) {
+	j.encodeJSON(&map[string][]models.PendingChange{
 		"pending_changes": pendingChanges,
 	})
 }
 
+func (j JSONPresenter) PresentProducts(products []models.ProductInventory) {
+	j.encodeJSON(&map[string][]models.ProductInventory{
+		"products": products,
+	})
+}
+
 func (j JSONPresenter) PresentStagedProducts(stagedProducts []api.DiagnosticProduct) {
 	j.encodeJSON(&map[string][]api.DiagnosticProduct{
 		"staged_products": stagedProducts,
 	})
 }
 
+func (j JSONPresenter) PresentStemcellAssignments(stemcellAssignments []api.StemcellAssignment) {
+	j.encodeJSON(&map[string][]api.StemcellAssignment{
+		"stemcell_assignments": stemcellAssignments,
+	})
+}
+
+func (j JSONPresenter) PresentVMExtensions(vmExtensions []api.VMExtension) {
+	j.encodeJSON(&map[string][]api.VMExtension{
+		"vm_extensions": vmExtensions,
+	})
+}
+
+func (j JSONPresenter) PresentVMTypes(vmTypes []api.VMType) {
+	j.encodeJSON(&map[string][]api.VMType{
+		"vm_types": vmTypes,
+	})
+}
+
+func (j JSONPresenter) PresentVerifiers(verifiers []api.Verifier) {
+	j.encodeJSON(&map[string][]api.Verifier{
+		"verifiers": verifiers,
+	})
+}
+
+func (j JSONPresenter) PresentVerifierResults(results []models.VerifierResult) {
+	j.encodeJSON(&map[string][]models.VerifierResult{
+		"verifier_results": results,
+	})
+}
+
 func (j JSONPresenter) encodeJSON(v interface{}) {
 	encoder := json.NewEncoder(j.stdout)
 	encoder.Encode(&v)
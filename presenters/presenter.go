@@ -15,7 +15,14 @@ type Presenter interface {
 	PresentDeployedProducts([]api.DiagnosticProduct)
 	PresentErrands([]models.Errand)
 	PresentCertificateAuthority(api.CA)
+	PresentIAASConfigurations([]api.IAASConfiguration)
 	PresentInstallations([]models.Installation)
-	PresentPendingChanges([]api.ProductChange)
+	PresentPendingChanges([]models.PendingChange)
+	PresentProducts([]models.ProductInventory)
 	PresentStagedProducts([]api.DiagnosticProduct)
+	PresentStemcellAssignments([]api.StemcellAssignment)
+	PresentVMExtensions([]api.VMExtension)
+	PresentVMTypes([]api.VMType)
+	PresentVerifiers([]api.Verifier)
+	PresentVerifierResults([]models.VerifierResult)
 }
@@ -3,6 +3,7 @@ package presenters
 import (
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/olekukonko/tablewriter"
@@ -108,6 +109,17 @@ func (t TablePresenter) PresentCertificateAuthority(certificateAuthority api.CA)
 	t.tableWriter.Render()
 }
 
+func (t TablePresenter) PresentIAASConfigurations(configurations []api.IAASConfiguration) {
+	t.tableWriter.SetAlignment(tablewriter.ALIGN_LEFT)
+	t.tableWriter.SetHeader([]string{"Name", "GUID"})
+
+	for _, configuration := range configurations {
+		t.tableWriter.Append([]string{configuration.Name, configuration.GUID})
+	}
+
+	t.tableWriter.Render()
+}
+
 func (t TablePresenter) PresentInstallations(installations []models.Installation) {
 	t.tableWriter.SetHeader([]string{"ID", "User", "Status", "Started At", "Finished At"})
 
@@ -133,18 +145,23 @@ func (t TablePresenter) PresentInstallations(installations []models.Installation
 	t.tableWriter.Render()
 }
 
-func (t TablePresenter) PresentPendingChanges(pendingChanges []api.ProductChange) {
-	t.tableWriter.SetHeader([]string{"PRODUCT", "ACTION", "ERRANDS"})
+func (t TablePresenter) PresentPendingChanges(pendingChanges []models.PendingChange) {
+	t.tableWriter.SetHeader([]string{"PRODUCT", "ACTION", "ERRANDS", "NAME", "VERSION", "COMPLETENESS"})
 
 	for _, change := range pendingChanges {
+		completeness := "complete"
+		if !change.Complete {
+			completeness = strings.Join(change.Problems, ", ")
+		}
+
 		if len(change.Errands) == 0 {
-			t.tableWriter.Append([]string{change.Product, change.Action, ""})
+			t.tableWriter.Append([]string{change.GUID, change.Action, "", change.Name, change.Version, completeness})
 		}
 		for i, errand := range change.Errands {
 			if i == 0 {
-				t.tableWriter.Append([]string{change.Product, change.Action, errand.Name})
+				t.tableWriter.Append([]string{change.GUID, change.Action, errand.Name, change.Name, change.Version, completeness})
 			} else {
-				t.tableWriter.Append([]string{"", "", errand.Name})
+				t.tableWriter.Append([]string{"", "", errand.Name, "", "", ""})
 			}
 		}
 	}
@@ -152,6 +169,30 @@ func (t TablePresenter) PresentPendingChanges(pendingChanges []api.ProductChange
 	t.tableWriter.Render()
 }
 
+func (t TablePresenter) PresentProducts(products []models.ProductInventory) {
+	t.tableWriter.SetAlignment(tablewriter.ALIGN_LEFT)
+	t.tableWriter.SetHeader([]string{"Name", "Available Versions", "Staged Version", "Deployed Version", "Stemcell", "GUID", "Pending Upgrade"})
+
+	for _, product := range products {
+		pendingUpgrade := ""
+		if product.PendingUpgrade {
+			pendingUpgrade = "yes"
+		}
+
+		t.tableWriter.Append([]string{
+			product.Name,
+			strings.Join(product.AvailableVersions, ", "),
+			product.StagedVersion,
+			product.DeployedVersion,
+			product.Stemcell,
+			product.GUID,
+			pendingUpgrade,
+		})
+	}
+
+	t.tableWriter.Render()
+}
+
 func (t TablePresenter) PresentStagedProducts(stagedProducts []api.DiagnosticProduct) {
 	t.tableWriter.SetHeader([]string{"Name", "Version"})
 
@@ -162,6 +203,83 @@ func (t TablePresenter) PresentStagedProducts(stagedProducts []api.DiagnosticPro
 	t.tableWriter.Render()
 }
 
+func (t TablePresenter) PresentStemcellAssignments(stemcellAssignments []api.StemcellAssignment) {
+	t.tableWriter.SetAlignment(tablewriter.ALIGN_LEFT)
+	t.tableWriter.SetHeader([]string{"Product", "Required OS", "Staged Stemcell", "Available Versions"})
+
+	for _, assignment := range stemcellAssignments {
+		t.tableWriter.Append([]string{
+			assignment.ProductName,
+			assignment.RequiredStemcellOS,
+			assignment.StagedStemcellVersion,
+			strings.Join(assignment.AvailableVersions, ", "),
+		})
+	}
+
+	t.tableWriter.Render()
+}
+
+func (t TablePresenter) PresentVMExtensions(vmExtensions []api.VMExtension) {
+	t.tableWriter.SetAlignment(tablewriter.ALIGN_LEFT)
+	t.tableWriter.SetAutoWrapText(false)
+	t.tableWriter.SetHeader([]string{"Name", "Cloud Properties"})
+
+	for _, vmExtension := range vmExtensions {
+		t.tableWriter.Append([]string{vmExtension.Name, string(vmExtension.CloudProperties)})
+	}
+
+	t.tableWriter.Render()
+}
+
+func (t TablePresenter) PresentVMTypes(vmTypes []api.VMType) {
+	t.tableWriter.SetAlignment(tablewriter.ALIGN_LEFT)
+	t.tableWriter.SetHeader([]string{"Name", "CPU", "RAM (MB)", "Disk (MB)", "Built-in"})
+
+	for _, vmType := range vmTypes {
+		t.tableWriter.Append([]string{
+			vmType.Name,
+			strconv.Itoa(vmType.CPU),
+			strconv.Itoa(vmType.RAM),
+			strconv.Itoa(vmType.EphemeralDisk),
+			strconv.FormatBool(vmType.BuiltIn),
+		})
+	}
+
+	t.tableWriter.Render()
+}
+
+func (t TablePresenter) PresentVerifiers(verifiers []api.Verifier) {
+	t.tableWriter.SetAlignment(tablewriter.ALIGN_LEFT)
+	t.tableWriter.SetHeader([]string{"Type", "Enabled"})
+
+	for _, verifier := range verifiers {
+		t.tableWriter.Append([]string{verifier.Type, strconv.FormatBool(verifier.Enabled)})
+	}
+
+	t.tableWriter.Render()
+}
+
+func (t TablePresenter) PresentVerifierResults(results []models.VerifierResult) {
+	t.tableWriter.SetAlignment(tablewriter.ALIGN_LEFT)
+	t.tableWriter.SetAutoWrapText(false)
+	t.tableWriter.SetHeader([]string{"Type", "Status", "Messages"})
+
+	for _, result := range results {
+		if len(result.Messages) == 0 {
+			t.tableWriter.Append([]string{result.Type, result.Status, ""})
+		}
+		for i, message := range result.Messages {
+			if i == 0 {
+				t.tableWriter.Append([]string{result.Type, result.Status, message})
+			} else {
+				t.tableWriter.Append([]string{"", "", message})
+			}
+		}
+	}
+
+	t.tableWriter.Render()
+}
+
 func sortCredentialMap(cm map[string]string) ([]string, []string) {
 	var header []string
 	var credential []string
@@ -1,6 +1,7 @@
 package presenters_test
 
 import (
+	"encoding/json"
 	"strconv"
 	"time"
 
@@ -167,6 +168,24 @@ var _ = Describe("TablePresenter", func() {
 		})
 	})
 
+	Describe("PresentIAASConfigurations", func() {
+		It("creates a table", func() {
+			tablePresenter.PresentIAASConfigurations([]api.IAASConfiguration{
+				{GUID: "some-guid", Name: "vcenter-one"},
+				{GUID: "other-guid", Name: "vcenter-two"},
+			})
+
+			Expect(fakeTableWriter.SetAlignmentArgsForCall(0)).To(Equal(tablewriter.ALIGN_LEFT))
+			Expect(fakeTableWriter.SetHeaderArgsForCall(0)).To(Equal([]string{"Name", "GUID"}))
+
+			Expect(fakeTableWriter.AppendCallCount()).To(Equal(2))
+			Expect(fakeTableWriter.AppendArgsForCall(0)).To(Equal([]string{"vcenter-one", "some-guid"}))
+			Expect(fakeTableWriter.AppendArgsForCall(1)).To(Equal([]string{"vcenter-two", "other-guid"}))
+
+			Expect(fakeTableWriter.RenderCallCount()).To(Equal(1))
+		})
+	})
+
 	Describe("PresentInstallations", func() {
 		var installations []models.Installation
 
@@ -225,13 +244,13 @@ var _ = Describe("TablePresenter", func() {
 	})
 
 	Describe("PresentPendingChanges", func() {
-		var pendingChanges []api.ProductChange
+		var pendingChanges []models.PendingChange
 		BeforeEach(func() {
-			pendingChanges = []api.ProductChange{
+			pendingChanges = []models.PendingChange{
 				{
-					Product: "some-product",
-					Action:  "update",
-					Errands: []api.Errand{
+					GUID:   "some-product",
+					Action: "update",
+					Errands: []models.PendingChangeErrand{
 						{
 							Name:       "some-errand",
 							PostDeploy: "on",
@@ -243,11 +262,17 @@ var _ = Describe("TablePresenter", func() {
 							PreDelete:  "false",
 						},
 					},
+					Name:     "some-product-name",
+					Version:  "1.0.0",
+					Complete: true,
 				},
 				{
-					Product: "some-product-without-errand",
-					Action:  "install",
-					Errands: []api.Errand{},
+					GUID:     "some-product-without-errand",
+					Action:   "install",
+					Errands:  []models.PendingChangeErrand{},
+					Name:     "some-product-without-errand-name",
+					Version:  "2.0.0",
+					Problems: []string{"configuration is incomplete", "stemcell is missing"},
 				},
 			}
 		})
@@ -256,12 +281,12 @@ var _ = Describe("TablePresenter", func() {
 			tablePresenter.PresentPendingChanges(pendingChanges)
 
 			Expect(fakeTableWriter.SetHeaderCallCount()).To(Equal(1))
-			Expect(fakeTableWriter.SetHeaderArgsForCall(0)).To(Equal([]string{"PRODUCT", "ACTION", "ERRANDS"}))
+			Expect(fakeTableWriter.SetHeaderArgsForCall(0)).To(Equal([]string{"PRODUCT", "ACTION", "ERRANDS", "NAME", "VERSION", "COMPLETENESS"}))
 
 			Expect(fakeTableWriter.AppendCallCount()).To(Equal(3))
-			Expect(fakeTableWriter.AppendArgsForCall(0)).To(Equal([]string{"some-product", "update", "some-errand"}))
-			Expect(fakeTableWriter.AppendArgsForCall(1)).To(Equal([]string{"", "", "some-errand-2"}))
-			Expect(fakeTableWriter.AppendArgsForCall(2)).To(Equal([]string{"some-product-without-errand", "install", ""}))
+			Expect(fakeTableWriter.AppendArgsForCall(0)).To(Equal([]string{"some-product", "update", "some-errand", "some-product-name", "1.0.0", "complete"}))
+			Expect(fakeTableWriter.AppendArgsForCall(1)).To(Equal([]string{"", "", "some-errand-2", "", "", ""}))
+			Expect(fakeTableWriter.AppendArgsForCall(2)).To(Equal([]string{"some-product-without-errand", "install", "", "some-product-without-errand-name", "2.0.0", "configuration is incomplete, stemcell is missing"}))
 		})
 	})
 
@@ -360,4 +385,130 @@ var _ = Describe("TablePresenter", func() {
 			Expect(fakeTableWriter.RenderCallCount()).To(Equal(1))
 		})
 	})
+
+	Describe("PresentProducts", func() {
+		It("creates a table", func() {
+			tablePresenter.PresentProducts([]models.ProductInventory{
+				{
+					Name:              "cf",
+					GUID:              "cf-some-guid",
+					AvailableVersions: []string{"1.11.0", "1.12.0"},
+					StagedVersion:     "1.12.0",
+					DeployedVersion:   "1.11.0",
+					Stemcell:          "bosh-stemcell-3541.10",
+					PendingUpgrade:    true,
+				},
+				{
+					Name:              "p-mysql",
+					AvailableVersions: []string{"2.0.0"},
+				},
+			})
+
+			Expect(fakeTableWriter.SetAlignmentArgsForCall(0)).To(Equal(tablewriter.ALIGN_LEFT))
+			Expect(fakeTableWriter.SetHeaderArgsForCall(0)).To(Equal([]string{"Name", "Available Versions", "Staged Version", "Deployed Version", "Stemcell", "GUID", "Pending Upgrade"}))
+
+			Expect(fakeTableWriter.AppendCallCount()).To(Equal(2))
+			Expect(fakeTableWriter.AppendArgsForCall(0)).To(Equal([]string{"cf", "1.11.0, 1.12.0", "1.12.0", "1.11.0", "bosh-stemcell-3541.10", "cf-some-guid", "yes"}))
+			Expect(fakeTableWriter.AppendArgsForCall(1)).To(Equal([]string{"p-mysql", "2.0.0", "", "", "", "", ""}))
+
+			Expect(fakeTableWriter.RenderCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("PresentStemcellAssignments", func() {
+		It("creates a table", func() {
+			tablePresenter.PresentStemcellAssignments([]api.StemcellAssignment{
+				{
+					ProductName:           "cf",
+					RequiredStemcellOS:    "ubuntu-trusty",
+					StagedStemcellVersion: "3541.10",
+					AvailableVersions:     []string{"3541.10", "3541.12"},
+				},
+				{
+					ProductName:        "p-mysql",
+					RequiredStemcellOS: "ubuntu-xenial",
+				},
+			})
+
+			Expect(fakeTableWriter.SetAlignmentArgsForCall(0)).To(Equal(tablewriter.ALIGN_LEFT))
+			Expect(fakeTableWriter.SetHeaderArgsForCall(0)).To(Equal([]string{"Product", "Required OS", "Staged Stemcell", "Available Versions"}))
+
+			Expect(fakeTableWriter.AppendCallCount()).To(Equal(2))
+			Expect(fakeTableWriter.AppendArgsForCall(0)).To(Equal([]string{"cf", "ubuntu-trusty", "3541.10", "3541.10, 3541.12"}))
+			Expect(fakeTableWriter.AppendArgsForCall(1)).To(Equal([]string{"p-mysql", "ubuntu-xenial", "", ""}))
+
+			Expect(fakeTableWriter.RenderCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("PresentVMExtensions", func() {
+		It("creates a table", func() {
+			tablePresenter.PresentVMExtensions([]api.VMExtension{
+				{Name: "some-extension", CloudProperties: json.RawMessage(`{"elbs":["some-elb"]}`)},
+			})
+
+			Expect(fakeTableWriter.SetAlignmentArgsForCall(0)).To(Equal(tablewriter.ALIGN_LEFT))
+			Expect(fakeTableWriter.SetHeaderArgsForCall(0)).To(Equal([]string{"Name", "Cloud Properties"}))
+
+			Expect(fakeTableWriter.AppendCallCount()).To(Equal(1))
+			Expect(fakeTableWriter.AppendArgsForCall(0)).To(Equal([]string{"some-extension", `{"elbs":["some-elb"]}`}))
+
+			Expect(fakeTableWriter.RenderCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("PresentVMTypes", func() {
+		It("creates a table", func() {
+			tablePresenter.PresentVMTypes([]api.VMType{
+				{Name: "micro", CPU: 1, RAM: 1024, EphemeralDisk: 8192, BuiltIn: true},
+				{Name: "some-custom-type", CPU: 2, RAM: 4096, EphemeralDisk: 32768},
+			})
+
+			Expect(fakeTableWriter.SetAlignmentArgsForCall(0)).To(Equal(tablewriter.ALIGN_LEFT))
+			Expect(fakeTableWriter.SetHeaderArgsForCall(0)).To(Equal([]string{"Name", "CPU", "RAM (MB)", "Disk (MB)", "Built-in"}))
+
+			Expect(fakeTableWriter.AppendCallCount()).To(Equal(2))
+			Expect(fakeTableWriter.AppendArgsForCall(0)).To(Equal([]string{"micro", "1", "1024", "8192", "true"}))
+			Expect(fakeTableWriter.AppendArgsForCall(1)).To(Equal([]string{"some-custom-type", "2", "4096", "32768", "false"}))
+
+			Expect(fakeTableWriter.RenderCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("PresentVerifiers", func() {
+		It("creates a table", func() {
+			tablePresenter.PresentVerifiers([]api.Verifier{
+				{Type: "IaasConfigurationVerifier", Enabled: true},
+				{Type: "NetworksPingableVerifier", Enabled: false},
+			})
+
+			Expect(fakeTableWriter.SetAlignmentArgsForCall(0)).To(Equal(tablewriter.ALIGN_LEFT))
+			Expect(fakeTableWriter.SetHeaderArgsForCall(0)).To(Equal([]string{"Type", "Enabled"}))
+
+			Expect(fakeTableWriter.AppendCallCount()).To(Equal(2))
+			Expect(fakeTableWriter.AppendArgsForCall(0)).To(Equal([]string{"IaasConfigurationVerifier", "true"}))
+			Expect(fakeTableWriter.AppendArgsForCall(1)).To(Equal([]string{"NetworksPingableVerifier", "false"}))
+
+			Expect(fakeTableWriter.RenderCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("PresentVerifierResults", func() {
+		It("creates a table with a row for each message", func() {
+			tablePresenter.PresentVerifierResults([]models.VerifierResult{
+				{Type: "WildcardDomainVerifier", Status: "failed", Messages: []string{"first problem", "second problem"}},
+				{Type: "AppsDomainVerifier", Status: "passed"},
+			})
+
+			Expect(fakeTableWriter.SetAlignmentArgsForCall(0)).To(Equal(tablewriter.ALIGN_LEFT))
+			Expect(fakeTableWriter.SetHeaderArgsForCall(0)).To(Equal([]string{"Type", "Status", "Messages"}))
+
+			Expect(fakeTableWriter.AppendCallCount()).To(Equal(3))
+			Expect(fakeTableWriter.AppendArgsForCall(0)).To(Equal([]string{"WildcardDomainVerifier", "failed", "first problem"}))
+			Expect(fakeTableWriter.AppendArgsForCall(1)).To(Equal([]string{"", "", "second problem"}))
+			Expect(fakeTableWriter.AppendArgsForCall(2)).To(Equal([]string{"AppsDomainVerifier", "passed", ""}))
+
+			Expect(fakeTableWriter.RenderCallCount()).To(Equal(1))
+		})
+	})
 })
@@ -0,0 +1,14 @@
+package progress
+
+import (
+	"io"
+	"time"
+)
+
+func NewPoolWithOutput(output io.Writer, interactive bool, refreshRate time.Duration) *Pool {
+	return &Pool{
+		output:      output,
+		interactive: interactive,
+		refreshRate: refreshRate,
+	}
+}
@@ -0,0 +1,113 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"gopkg.in/cheggaaa/pb.v1"
+)
+
+type Pool struct {
+	output      io.Writer
+	interactive bool
+	refreshRate time.Duration
+
+	mutex    sync.Mutex
+	bars     []Bar
+	prefixes []string
+	lines    int
+	quit     chan struct{}
+	done     chan struct{}
+}
+
+func NewPool() *Pool {
+	interactive := false
+	if stat, err := os.Stdout.Stat(); err == nil {
+		interactive = stat.Mode()&os.ModeCharDevice != 0
+	}
+
+	return &Pool{
+		output:      os.Stdout,
+		interactive: interactive,
+		refreshRate: 200 * time.Millisecond,
+	}
+}
+
+func (p *Pool) NewBar(prefix string) Bar {
+	bar := pb.New(0)
+	bar.SetUnits(pb.U_BYTES)
+	bar.Width = 80
+	bar.Prefix(prefix)
+	bar.ManualUpdate = true
+	bar.NotPrint = true
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.bars = append(p.bars, Bar{bar})
+	p.prefixes = append(p.prefixes, prefix)
+
+	return Bar{bar}
+}
+
+func (p *Pool) Start() {
+	p.quit = make(chan struct{})
+	p.done = make(chan struct{})
+
+	go func() {
+		defer close(p.done)
+
+		for {
+			select {
+			case <-p.quit:
+				return
+			case <-time.After(p.refreshRate):
+				if p.interactive {
+					p.render()
+				}
+			}
+		}
+	}()
+}
+
+func (p *Pool) Stop() {
+	close(p.quit)
+	<-p.done
+
+	p.render()
+}
+
+// render prints every bar on its own line. On a terminal the previous
+// frame is overwritten so each bar updates in place.
+func (p *Pool) render() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var out string
+	if p.interactive && p.lines > 0 {
+		out = fmt.Sprintf("\033[%dA", p.lines)
+	}
+
+	for i, bar := range p.bars {
+		if bar.GetTotal() > 0 {
+			bar.Update()
+		}
+
+		status := bar.String()
+		if status == "" {
+			status = fmt.Sprintf("%s waiting", p.prefixes[i])
+		}
+
+		if p.interactive {
+			out += fmt.Sprintf("\r%s\033[K\n", status)
+		} else {
+			out += fmt.Sprintf("%s\n", status)
+		}
+	}
+
+	fmt.Fprint(p.output, out)
+	p.lines = len(p.bars)
+}
@@ -0,0 +1,61 @@
+package progress_test
+
+import (
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/pivotal-cf/om/progress"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pool", func() {
+	var output *gbytes.Buffer
+
+	BeforeEach(func() {
+		output = gbytes.NewBuffer()
+	})
+
+	Context("when the output is not a terminal", func() {
+		It("prints every bar once when the pool stops", func() {
+			pool := progress.NewPoolWithOutput(output, false, time.Millisecond)
+			pool.NewBar("cf.pivotal")
+			bar := pool.NewBar("p-mysql.pivotal")
+
+			pool.Start()
+			Consistently(output.Contents, "20ms").Should(BeEmpty())
+
+			bar.SetTotal(4)
+			_, err := ioutil.ReadAll(bar.NewBarReader(strings.NewReader("data")))
+			Expect(err).NotTo(HaveOccurred())
+
+			pool.Stop()
+
+			lines := strings.Split(string(output.Contents()), "\n")
+			Expect(lines).To(HaveLen(3))
+			Expect(lines[0]).To(Equal("cf.pivotal waiting"))
+			Expect(lines[1]).To(HavePrefix("p-mysql.pivotal"))
+			Expect(lines[1]).To(ContainSubstring("100.00%"))
+			Expect(lines[2]).To(BeEmpty())
+			Expect(string(output.Contents())).NotTo(ContainSubstring("\033"))
+		})
+	})
+
+	Context("when the output is a terminal", func() {
+		It("redraws the bars in place until the pool stops", func() {
+			pool := progress.NewPoolWithOutput(output, true, time.Millisecond)
+			pool.NewBar("cf.pivotal")
+			pool.NewBar("p-mysql.pivotal")
+
+			pool.Start()
+			Eventually(output).Should(gbytes.Say("\r" + `cf\.pivotal waiting\033\[K\n` + "\r" + `p-mysql\.pivotal waiting\033\[K\n`))
+			Eventually(output).Should(gbytes.Say(`\033\[2A` + "\r" + `cf\.pivotal waiting`))
+			pool.Stop()
+
+			Expect(string(output.Contents())).To(HaveSuffix("\rp-mysql.pivotal waiting\033[K\n"))
+		})
+	})
+})
@@ -0,0 +1,143 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var comparatorRegexp = regexp.MustCompile(`^(~>|>=|<=|!=|=|>|<|~|\^)?\s*(\S+)$`)
+
+type comparator struct {
+	operator string
+	version  Version
+
+	// preRelease is whether the version was written with a pre-release,
+	// rather than given one to bound a range.
+	preRelease bool
+}
+
+type Constraint struct {
+	original    string
+	comparators []comparator
+}
+
+func NewConstraint(constraint string) (Constraint, error) {
+	c := Constraint{original: constraint}
+
+	for _, part := range strings.Split(constraint, ",") {
+		match := comparatorRegexp.FindStringSubmatch(strings.TrimSpace(part))
+		if match == nil {
+			return Constraint{}, fmt.Errorf("%q is not a valid version constraint", constraint)
+		}
+
+		comparators, err := parseComparator(match[1], match[2])
+		if err != nil {
+			return Constraint{}, fmt.Errorf("%q is not a valid version constraint: %s", constraint, err)
+		}
+
+		c.comparators = append(c.comparators, comparators...)
+	}
+
+	return c, nil
+}
+
+func parseComparator(operator, version string) ([]comparator, error) {
+	if operator == "" && strings.ContainsAny(version, "xX*") {
+		operator = "~"
+		version = strings.TrimRight(strings.NewReplacer("x", "", "X", "", "*", "").Replace(version), ".")
+	}
+
+	v, err := Parse(version)
+	if err != nil {
+		return nil, err
+	}
+
+	var upper Version
+	switch operator {
+	case "~>":
+		switch v.parts {
+		case 1, 2:
+			upper = Version{Major: v.Major + 1}
+		default:
+			upper = Version{Major: v.Major, Minor: v.Minor + 1}
+		}
+	case "~":
+		switch v.parts {
+		case 1:
+			upper = Version{Major: v.Major + 1}
+		default:
+			upper = Version{Major: v.Major, Minor: v.Minor + 1}
+		}
+	case "^":
+		switch {
+		case v.Major > 0 || v.parts == 1:
+			upper = Version{Major: v.Major + 1}
+		case v.Minor > 0 || v.parts == 2:
+			upper = Version{Minor: v.Minor + 1}
+		default:
+			upper = Version{Patch: v.Patch + 1}
+		}
+	case "":
+		return []comparator{{operator: "=", version: v, preRelease: len(v.PreRelease) > 0}}, nil
+	default:
+		return []comparator{{operator: operator, version: v, preRelease: len(v.PreRelease) > 0}}, nil
+	}
+
+	// Ranges include the pre-releases of their lower bound and exclude those
+	// of their upper bound; "0" is the lowest possible pre-release.
+	lower := Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, PreRelease: v.PreRelease}
+	if len(lower.PreRelease) == 0 {
+		lower.PreRelease = []string{"0"}
+	}
+	upper.PreRelease = []string{"0"}
+
+	return []comparator{
+		{operator: ">=", version: lower, preRelease: len(v.PreRelease) > 0},
+		{operator: "<", version: upper},
+	}, nil
+}
+
+func (c Constraint) Check(version Version) bool {
+	for _, comparator := range c.comparators {
+		result := version.comparePrecedence(comparator.version)
+
+		var ok bool
+		switch comparator.operator {
+		case "=":
+			ok = result == 0
+		case "!=":
+			ok = result != 0
+		case ">":
+			ok = result > 0
+		case ">=":
+			ok = result >= 0
+		case "<":
+			ok = result < 0
+		case "<=":
+			ok = result <= 0
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NamesPreRelease reports whether any of the versions in the constraint was
+// written with a pre-release, such as "~> 2.1.0-rc.1".
+func (c Constraint) NamesPreRelease() bool {
+	for _, comparator := range c.comparators {
+		if comparator.preRelease {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c Constraint) String() string {
+	return c.original
+}
@@ -0,0 +1,134 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var versionRegexp = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`)
+
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	PreRelease []string
+	Build      []string
+
+	original string
+	parts    int
+}
+
+func Parse(version string) (Version, error) {
+	match := versionRegexp.FindStringSubmatch(strings.TrimSpace(version))
+	if match == nil {
+		return Version{}, fmt.Errorf("%q is not a valid version", version)
+	}
+
+	v := Version{original: version}
+
+	numbers := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, number := range match[1:4] {
+		if number == "" {
+			break
+		}
+
+		*numbers[i], _ = strconv.Atoi(number)
+		v.parts++
+	}
+
+	if match[4] != "" {
+		v.PreRelease = strings.Split(match[4], ".")
+	}
+
+	if match[5] != "" {
+		v.Build = strings.Split(match[5], ".")
+	}
+
+	return v, nil
+}
+
+func (v Version) String() string {
+	if v.original != "" {
+		return v.original
+	}
+
+	version := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.PreRelease) > 0 {
+		version += "-" + strings.Join(v.PreRelease, ".")
+	}
+	if len(v.Build) > 0 {
+		version += "+" + strings.Join(v.Build, ".")
+	}
+
+	return version
+}
+
+// Compare orders versions by semver precedence. Build metadata does not
+// affect precedence and is only used to break ties so that sorting is stable.
+func (v Version) Compare(other Version) int {
+	if result := v.comparePrecedence(other); result != 0 {
+		return result
+	}
+
+	return compareIdentifiers(v.Build, other.Build, 1)
+}
+
+func (v Version) comparePrecedence(other Version) int {
+	for _, pair := range [][2]int{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if pair[0] != pair[1] {
+			return compareInts(pair[0], pair[1])
+		}
+	}
+
+	return compareIdentifiers(v.PreRelease, other.PreRelease, -1)
+}
+
+// compareIdentifiers compares dot separated identifiers. absent is the result
+// when only the receiver has no identifiers: a version without a pre-release
+// is greater than one with a pre-release, but one without build metadata is
+// less than one with it.
+func compareIdentifiers(a, b []string, absent int) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return -absent
+	case len(b) == 0:
+		return absent
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		aNumber, aErr := strconv.Atoi(a[i])
+		bNumber, bErr := strconv.Atoi(b[i])
+
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNumber != bNumber {
+				return compareInts(aNumber, bNumber)
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if a[i] != b[i] {
+				return strings.Compare(a[i], b[i])
+			}
+		}
+	}
+
+	return compareInts(len(a), len(b))
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
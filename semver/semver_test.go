@@ -0,0 +1,147 @@
+package semver_test
+
+import (
+	"sort"
+
+	"github.com/pivotal-cf/om/semver"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Version", func() {
+	Describe("Parse", func() {
+		It("parses full versions", func() {
+			version, err := semver.Parse("1.2.3-build.4+sha.abc")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(version.Major).To(Equal(1))
+			Expect(version.Minor).To(Equal(2))
+			Expect(version.Patch).To(Equal(3))
+			Expect(version.PreRelease).To(Equal([]string{"build", "4"}))
+			Expect(version.Build).To(Equal([]string{"sha", "abc"}))
+			Expect(version.String()).To(Equal("1.2.3-build.4+sha.abc"))
+		})
+
+		It("parses partial versions", func() {
+			version, err := semver.Parse("v2.1")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(version.Major).To(Equal(2))
+			Expect(version.Minor).To(Equal(1))
+			Expect(version.Patch).To(Equal(0))
+		})
+
+		Context("when the version is invalid", func() {
+			It("returns an error", func() {
+				_, err := semver.Parse("not-a-version")
+				Expect(err).To(MatchError(`"not-a-version" is not a valid version`))
+			})
+		})
+	})
+
+	Describe("Compare", func() {
+		It("orders versions by precedence", func() {
+			versions := []string{
+				"1.10.0",
+				"1.2.0+build.2",
+				"1.2.0",
+				"1.2.0-rc.1",
+				"1.2.0-alpha",
+				"1.2.0-alpha.10",
+				"1.2.0-alpha.2",
+				"1.2.0-alpha.beta",
+				"1.2.0+build.1",
+				"1.9.9",
+			}
+
+			parsed := []semver.Version{}
+			for _, v := range versions {
+				version, err := semver.Parse(v)
+				Expect(err).NotTo(HaveOccurred())
+				parsed = append(parsed, version)
+			}
+
+			sort.Slice(parsed, func(i, j int) bool {
+				return parsed[i].Compare(parsed[j]) < 0
+			})
+
+			sorted := []string{}
+			for _, version := range parsed {
+				sorted = append(sorted, version.String())
+			}
+
+			Expect(sorted).To(Equal([]string{
+				"1.2.0-alpha",
+				"1.2.0-alpha.2",
+				"1.2.0-alpha.10",
+				"1.2.0-alpha.beta",
+				"1.2.0-rc.1",
+				"1.2.0",
+				"1.2.0+build.1",
+				"1.2.0+build.2",
+				"1.9.9",
+				"1.10.0",
+			}))
+		})
+	})
+})
+
+var _ = Describe("Constraint", func() {
+	DescribeTable("Check",
+		func(constraint, version string, expected bool) {
+			c, err := semver.NewConstraint(constraint)
+			Expect(err).NotTo(HaveOccurred())
+
+			v, err := semver.Parse(version)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.Check(v)).To(Equal(expected))
+		},
+		Entry("exact match", "1.2.3", "1.2.3", true),
+		Entry("exact mismatch", "= 1.2.3", "1.2.4", false),
+		Entry("not equal", "!= 1.2.3", "1.2.4", true),
+		Entry("greater than", "> 1.2.3", "1.2.4", true),
+		Entry("less than or equal", "<= 1.2.3", "1.2.4", false),
+		Entry("ranges", ">= 1.2, < 2", "1.9.0", true),
+		Entry("ranges exclude upper bound", ">= 1.2, < 2", "2.0.0", false),
+		Entry("pessimistic minor", "~> 2.1", "2.9.0", true),
+		Entry("pessimistic minor lower bound", "~> 2.1", "2.0.9", false),
+		Entry("pessimistic minor upper bound", "~> 2.1", "3.0.0", false),
+		Entry("pessimistic patch", "~> 2.1.3", "2.1.9", true),
+		Entry("pessimistic patch upper bound", "~> 2.1.3", "2.2.0", false),
+		Entry("pessimistic includes pre-releases", "~> 2.1", "2.1.0-build.5", true),
+		Entry("pessimistic excludes next pre-releases", "~> 2.1.3", "2.2.0-build.1", false),
+		Entry("tilde", "~2.1", "2.1.7", true),
+		Entry("tilde upper bound", "~2.1", "2.2.0", false),
+		Entry("caret", "^2.1", "2.9.0", true),
+		Entry("caret upper bound", "^2.1", "3.0.0", false),
+		Entry("caret on zero major", "^0.2.1", "0.3.0", false),
+		Entry("caret on zero minor", "^0.0.1", "0.0.2", false),
+		Entry("wildcard", "2.1.x", "2.1.4", true),
+		Entry("wildcard mismatch", "2.1.*", "2.2.0", false),
+	)
+
+	DescribeTable("NamesPreRelease",
+		func(constraint string, expected bool) {
+			c, err := semver.NewConstraint(constraint)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.NamesPreRelease()).To(Equal(expected))
+		},
+		Entry("exact version", "1.2.3", false),
+		Entry("exact pre-release", "1.2.3-rc.1", true),
+		Entry("pessimistic", "~> 2.1", false),
+		Entry("pessimistic pre-release", "~> 2.1.0-rc.1", true),
+		Entry("range with a pre-release", ">= 2.1.0-rc.1, < 3", true),
+		Entry("wildcard", "2.1.x", false),
+	)
+
+	Context("when the constraint is invalid", func() {
+		It("returns an error", func() {
+			_, err := semver.NewConstraint("~> banana")
+			Expect(err).To(MatchError(`"~> banana" is not a valid version constraint: "banana" is not a valid version`))
+		})
+	})
+})
@@ -0,0 +1,77 @@
+package uploads
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+
+	"github.com/gosuri/uilive"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/formcontent"
+	"github.com/pivotal-cf/om/progress"
+)
+
+//go:generate counterfeiter -o ./fakes/http_client.go --fake-name HttpClient . httpClient
+type httpClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+type Multipart interface {
+	Finalize() (formcontent.ContentSubmission, error)
+	AddFile(key, path string) error
+	AddField(key, value string) error
+}
+
+// NewForm creates the multipart form of a single upload. Every upload needs
+// its own form, because a form cannot be reused once it is finalized.
+func NewForm() (Multipart, error) {
+	return formcontent.NewForm()
+}
+
+type ProductService interface {
+	Upload(api.UploadProductInput) (api.UploadProductOutput, error)
+	CheckProductAvailability(string, string) (bool, error)
+}
+
+type StemcellService interface {
+	Upload(api.StemcellUploadInput) (api.StemcellUploadOutput, error)
+}
+
+// ProductPool and StemcellPool give every file uploaded by a bulk upload a
+// service with its own bar in a shared progress display.
+type ProductPool struct {
+	client httpClient
+	*progress.Pool
+}
+
+func NewProductPool(client httpClient, pool *progress.Pool) ProductPool {
+	return ProductPool{
+		client: client,
+		Pool:   pool,
+	}
+}
+
+func (p ProductPool) New(path string) ProductService {
+	// the live "waiting for response" messages would interleave with the
+	// progress bars, so they are discarded during bulk uploads
+	liveWriter := uilive.New()
+	liveWriter.Out = ioutil.Discard
+
+	return api.NewAvailableProductsService(p.client, p.NewBar(filepath.Base(path)), liveWriter)
+}
+
+type StemcellPool struct {
+	client httpClient
+	*progress.Pool
+}
+
+func NewStemcellPool(client httpClient, pool *progress.Pool) StemcellPool {
+	return StemcellPool{
+		client: client,
+		Pool:   pool,
+	}
+}
+
+func (p StemcellPool) New(path string) StemcellService {
+	return api.NewUploadStemcellService(p.client, p.NewBar(filepath.Base(path)))
+}
@@ -0,0 +1,109 @@
+package uploads_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/progress"
+	"github.com/pivotal-cf/om/uploads"
+	"github.com/pivotal-cf/om/uploads/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("upload pools", func() {
+	var (
+		client *fakes.HttpClient
+		file   string
+	)
+
+	BeforeEach(func() {
+		client = &fakes.HttpClient{}
+
+		tempFile, err := ioutil.TempFile("", "upload")
+		Expect(err).NotTo(HaveOccurred())
+		defer tempFile.Close()
+
+		_, err = tempFile.WriteString("some contents")
+		Expect(err).NotTo(HaveOccurred())
+		file = tempFile.Name()
+	})
+
+	AfterEach(func() {
+		os.Remove(file)
+	})
+
+	Describe("NewForm", func() {
+		It("creates a new form for every upload", func() {
+			first, err := uploads.NewForm()
+			Expect(err).NotTo(HaveOccurred())
+			second, err := uploads.NewForm()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(first).NotTo(Equal(second))
+
+			Expect(first.AddFile("product[file]", file)).To(Succeed())
+			submission, err := first.Finalize()
+			Expect(err).NotTo(HaveOccurred())
+			body, err := ioutil.ReadAll(submission.Content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(body)).To(ContainSubstring("some contents"))
+		})
+	})
+
+	Describe("ProductPool", func() {
+		It("gives every upload a service that uses the client", func() {
+			pool := uploads.NewProductPool(client, progress.NewPool())
+
+			service := pool.New("/path/to/cf-2.1.0.pivotal")
+
+			client.DoReturns(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`[{"name": "cf", "product_version": "2.1.0"}]`)),
+			}, nil)
+
+			available, err := service.CheckProductAvailability("cf", "2.1.0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(available).To(BeTrue())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("GET"))
+			Expect(req.URL.Path).To(Equal("/api/v0/available_products"))
+		})
+	})
+
+	Describe("StemcellPool", func() {
+		It("gives every upload a service that uses the client", func() {
+			pool := uploads.NewStemcellPool(client, progress.NewPool())
+
+			service := pool.New("/path/to/bosh-stemcell-3541.tgz")
+
+			var uploaded []byte
+			client.DoStub = func(req *http.Request) (*http.Response, error) {
+				var err error
+				uploaded, err = ioutil.ReadAll(req.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewReader(nil))}, nil
+			}
+
+			_, err := service.Upload(api.StemcellUploadInput{
+				ContentLength: 13,
+				Stemcell:      strings.NewReader("some stemcell"),
+				ContentType:   "some content-type",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			req := client.DoArgsForCall(0)
+			Expect(req.Method).To(Equal("POST"))
+			Expect(req.URL.Path).To(Equal("/api/v0/stemcells"))
+			Expect(req.Header.Get("Content-Type")).To(Equal("some content-type"))
+			Expect(string(uploaded)).To(Equal("some stemcell"))
+		})
+	})
+})